@@ -0,0 +1,80 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package handlers holds the API Gateway custom authorizer in front of every task route. It only verifies credentials,
+// so it leaves out the routes and store middlewares of the task handlers and only opens the store to verify API keys.
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/authorizer"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/credentials"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/logger"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/settings"
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/JustonDavies/go_serverless_api/pkg/tracing"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// serviceName identifies this service in traces
+	serviceName = `auth-service`
+)
+
+var (
+	// lifecycle holds the store API keys are kept in, the pool is only opened by the first API key to be verified
+	lifecycle = task.NewLifecycle(settings.Connection(), nil)
+
+	// keyring verifies API keys, it borrows the connection pool of the store held by lifecycle
+	keyring = apikey.NewLifecycle(lifecycle)
+
+	// verifier checks the credential of every request, a configuration error denies bearer tokens rather than letting
+	// them through unauthenticated
+	verifier = credentials.NewVerifier(settings.Auth(), keyring)
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// init installs the default tracer before the authorizer runs
+func init() {
+	settings.Trace(serviceName)
+}
+
+//-- Event Handler -----------------------------------------------------------------------------------------------------
+// Authorize is the API Gateway request authorizer in front of every route. It verifies the credentials.APIKeyHeader of
+// the request, or its Authorization header when there is none, and allows the request with the context of its
+// principal, which the handlers read back instead of verifying the credential again. Scopes and tenants are still
+// enforced by each handler, so a verified caller is always allowed here.
+func Authorize(ctx context.Context, request events.APIGatewayCustomAuthorizerRequestTypeRequest) (events.APIGatewayCustomAuthorizerResponse, error) {
+	//-- Shared variables ----------
+	var start = time.Now()
+
+	//-- Trace ----------
+	var span *tracing.Span
+	ctx, span = tracing.Start(ctx, `authorize`, tracing.KindServer)
+	defer span.End()
+
+	//-- Verify ----------
+	if principal, err := verifier.Headers(ctx, request.Headers); errors.Is(err, auth.ErrUnauthenticated) {
+		slog.InfoContext(ctx, `request denied`, slog.String(`error`, err.Error()), logger.Latency(start))
+		return events.APIGatewayCustomAuthorizerResponse{}, authorizer.ErrUnauthorized
+	} else if err != nil {
+		slog.ErrorContext(ctx, `unable to verify the credential`, slog.String(`error`, err.Error()), logger.Latency(start))
+		span.RecordError(err)
+		return events.APIGatewayCustomAuthorizerResponse{}, err
+	} else {
+		var response = authorizer.Allow(principal, request.MethodArn)
+
+		span.SetAttribute(`enduser.id`, response.PrincipalID)
+		slog.InfoContext(ctx, `request allowed`, slog.String(`principal`, response.PrincipalID), logger.Latency(start))
+
+		return response, nil
+	}
+}
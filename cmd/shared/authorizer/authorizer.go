@@ -0,0 +1,154 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package authorizer carries a Principal from the API Gateway custom authorizer to the handlers behind it. Allow builds
+// the policy the authorizer returns along with the Context of its principal, API Gateway then hands that context to
+// every handler in event.RequestContext.Authorizer where Principal reads it back.
+package authorizer
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// Anonymous is the principalId of a request allowed without a principal, while authentication is disabled
+	Anonymous = `anonymous`
+
+	// Context keys, API Gateway only passes strings, numbers and booleans so lists are joined with listSeparator
+	subjectKey   = `subject`
+	issuerKey    = `issuer`
+	tenantKey    = `tenant`
+	rolesKey     = `roles`
+	scopesKey    = `scopes`
+	expiresAtKey = `expires_at`
+
+	listSeparator = `,`
+
+	policyVersion = `2012-10-17`
+	invokeAction  = `execute-api:Invoke`
+)
+
+var (
+	// ErrUnauthorized is the error API Gateway turns into a 401 response, any other error becomes a 500
+	ErrUnauthorized = errors.New(`Unauthorized`)
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// Allow grants principal every route of the API stage methodArn belongs to, so API Gateway may cache the result for
+// other routes, and attaches the Context of principal. A nil principal is allowed anonymously without a context.
+func Allow(principal *auth.Principal, methodArn string) events.APIGatewayCustomAuthorizerResponse {
+	var response = events.APIGatewayCustomAuthorizerResponse{
+		PrincipalID: Anonymous,
+		PolicyDocument: events.APIGatewayCustomAuthorizerPolicy{
+			Version: policyVersion,
+			Statement: []events.IAMPolicyStatement{
+				{Action: []string{invokeAction}, Effect: `Allow`, Resource: []string{Resource(methodArn)}},
+			},
+		},
+	}
+
+	if principal != nil {
+		response.PrincipalID = principal.Subject
+		response.Context = Context(principal)
+	}
+
+	return response
+}
+
+// Context flattens principal into the values an authorizer may return, Principal reverses it
+func Context(principal *auth.Principal) map[string]interface{} {
+	var context = map[string]interface{}{
+		subjectKey: principal.Subject,
+		issuerKey:  principal.Issuer,
+		tenantKey:  principal.Tenant,
+		rolesKey:   strings.Join(principal.Roles, listSeparator),
+	}
+
+	if principal.Scopes != nil {
+		context[scopesKey] = strings.Join(principal.Scopes, listSeparator)
+	}
+
+	if !principal.ExpiresAt.IsZero() {
+		context[expiresAtKey] = principal.ExpiresAt.Unix()
+	}
+
+	return context
+}
+
+// Principal reads the principal the authorizer attached to event. It returns nil when the request did not pass through
+// the authorizer, or was allowed anonymously, and an error when the context is not one Context produced.
+func Principal(event events.APIGatewayProxyRequest) (*auth.Principal, error) {
+	var context = event.RequestContext.Authorizer
+
+	var subject, ok = value(context, subjectKey)
+	if !ok {
+		return nil, nil
+	} else if len(subject) == 0 {
+		return nil, errors.New(`authorizer - the context does not name a subject`)
+	}
+
+	var principal = &auth.Principal{Subject: subject}
+	principal.Issuer, _ = value(context, issuerKey)
+	principal.Tenant, _ = value(context, tenantKey)
+
+	if roles, _ := value(context, rolesKey); len(roles) > 0 {
+		principal.Roles = strings.Split(roles, listSeparator)
+	}
+
+	if scopes, ok := value(context, scopesKey); ok {
+		principal.Scopes = make([]string, 0)
+		if len(scopes) > 0 {
+			principal.Scopes = strings.Split(scopes, listSeparator)
+		}
+	}
+
+	if expiresAt, ok := value(context, expiresAtKey); !ok {
+		return principal, nil
+	} else if seconds, err := strconv.ParseInt(expiresAt, 10, 64); err != nil {
+		return nil, errors.New(fmt.Sprintf(`authorizer - the context holds a malformed %s '%s'`, expiresAtKey, expiresAt))
+	} else {
+		principal.ExpiresAt = time.Unix(seconds, 0).UTC()
+	}
+
+	return principal, nil
+}
+
+// Resource widens methodArn (arn:aws:execute-api:<region>:<account>:<api>/<stage>/<method>/<path>) to every method and
+// path of its stage
+func Resource(methodArn string) string {
+	var parts = strings.SplitN(methodArn, `/`, 3)
+
+	if len(parts) < 2 {
+		return methodArn
+	}
+	return parts[0] + `/` + parts[1] + `/*`
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// value reads key from context as a string, API Gateway may deliver the numbers and booleans an authorizer returns as
+// either their JSON type or a string
+func value(context map[string]interface{}, key string) (string, bool) {
+	switch typed := context[key].(type) {
+	case string:
+		return typed, true
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), true
+	case int64:
+		return strconv.FormatInt(typed, 10), true
+	case int:
+		return strconv.Itoa(typed), true
+	case bool:
+		return strconv.FormatBool(typed), true
+	default:
+		return ``, false
+	}
+}
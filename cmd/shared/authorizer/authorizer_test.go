@@ -0,0 +1,113 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package authorizer
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+const (
+	methodArn = `arn:aws:execute-api:us-west-2:123456789012:abcdef1234/production/GET/tasks/1`
+)
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+// proxied returns the proxy event API Gateway delivers behind an authorizer which returned context
+func proxied(context map[string]interface{}) events.APIGatewayProxyRequest {
+	var event events.APIGatewayProxyRequest
+	event.RequestContext.Authorizer = context
+	return event
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestAllow(test *testing.T) {
+	//-- Shared Variables ----------
+	var response, anonymous events.APIGatewayCustomAuthorizerResponse
+	var principal *auth.Principal
+	var principalErr error
+
+	//-- Test Parameters ----------
+	var input = &auth.Principal{
+		Subject:   `user-1`,
+		Issuer:    `https://issuer.example.com`,
+		Roles:     []string{`member`, `auditor`},
+		Tenant:    `tenant-a`,
+		Scopes:    []string{`tasks:read`},
+		ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second).UTC(),
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	response = Allow(input, methodArn)
+	anonymous = Allow(nil, methodArn)
+	principal, principalErr = Principal(proxied(response.Context))
+
+	//-- Post-conditions ----------
+	assert.Equal(test, `user-1`, response.PrincipalID)
+	if assert.Len(test, response.PolicyDocument.Statement, 1) {
+		assert.Equal(test, `Allow`, response.PolicyDocument.Statement[0].Effect)
+		assert.Equal(test, []string{`execute-api:Invoke`}, response.PolicyDocument.Statement[0].Action)
+		assert.Equal(test, []string{`arn:aws:execute-api:us-west-2:123456789012:abcdef1234/production/*`}, response.PolicyDocument.Statement[0].Resource)
+	}
+
+	assert.Equal(test, Anonymous, anonymous.PrincipalID)
+	assert.Nil(test, anonymous.Context)
+
+	assert.Nil(test, principalErr)
+	assert.Equal(test, input, principal)
+}
+
+func TestPrincipal(test *testing.T) {
+	//-- Shared Variables ----------
+	var principal *auth.Principal
+	var principalErr error
+
+	//-- Test Parameters ----------
+	var stringified = map[string]interface{}{`principalId`: `user-1`, `subject`: `user-1`, `tenant`: `tenant-a`, `roles`: ``, `expires_at`: `1700000000`}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	principal, principalErr = Principal(proxied(stringified))
+
+	//-- Post-conditions ----------
+	assert.Nil(test, principalErr)
+	if assert.NotNil(test, principal) {
+		assert.Equal(test, `user-1`, principal.Subject)
+		assert.Equal(test, `tenant-a`, principal.Tenant)
+		assert.Nil(test, principal.Roles)
+		assert.Nil(test, principal.Scopes)
+		assert.Equal(test, int64(1700000000), principal.ExpiresAt.Unix())
+	}
+
+	for name, context := range map[string]map[string]interface{}{
+		`numeric expiry`: {`subject`: `user-1`, `expires_at`: float64(1700000000)},
+		`no scopes`:      {`subject`: `user-1`, `scopes`: ``},
+	} {
+		var principal, err = Principal(proxied(context))
+
+		assert.Nil(test, err, name)
+		assert.NotNil(test, principal, name)
+	}
+
+	var scoped, _ = Principal(proxied(map[string]interface{}{`subject`: `user-1`, `scopes`: ``}))
+	assert.False(test, scoped.Permits(`tasks:read`))
+
+	var missing, missingErr = Principal(proxied(map[string]interface{}{`principalId`: `user-1`}))
+	assert.Nil(test, missing)
+	assert.Nil(test, missingErr)
+
+	var _, emptyErr = Principal(proxied(map[string]interface{}{`subject`: ``}))
+	assert.NotNil(test, emptyErr)
+
+	var _, malformedErr = Principal(proxied(map[string]interface{}{`subject`: `user-1`, `expires_at`: `tomorrow`}))
+	assert.NotNil(test, malformedErr)
+}
@@ -0,0 +1,83 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package credentials verifies the credential a request presents, either a bearer token signed by the configured issuer
+// or an API key. The task handlers and the authorizer in front of them share one Verifier so both accept the same
+// credentials.
+package credentials
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/parameters"
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// APIKeyHeader carries an API key, a request which sends one is authenticated by it instead of a bearer token
+	APIKeyHeader = `X-API-Key`
+
+	// AuthorizationHeader carries a bearer token or an API key sent with the bearer scheme
+	AuthorizationHeader = `Authorization`
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Verifier verifies bearer tokens with an authenticator and API keys with the keys of a keyring
+type Verifier struct {
+	authenticator    auth.Authenticator
+	authenticatorErr error
+
+	keyring *apikey.Lifecycle
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// NewVerifier verifies bearer tokens as described by parameters and API keys held by keyring. A configuration error
+// fails each verification of a bearer token rather than letting it through unauthenticated.
+func NewVerifier(parameters auth.Parameters, keyring *apikey.Lifecycle) *Verifier {
+	var verifier = &Verifier{keyring: keyring}
+	verifier.authenticator, verifier.authenticatorErr = auth.NewAuthenticator(parameters)
+
+	return verifier
+}
+
+// Err returns the configuration error which fails every bearer token, if any
+func (verifier *Verifier) Err() error {
+	return verifier.authenticatorErr
+}
+
+// Headers verifies the APIKeyHeader of headers when it is present and their AuthorizationHeader when it is not, the
+// names of headers are matched without regard to case
+func (verifier *Verifier) Headers(ctx context.Context, headers map[string]string) (*auth.Principal, error) {
+	if secret, ok := parameters.Lookup(headers, APIKeyHeader); ok {
+		return verifier.Key(ctx, secret)
+	}
+
+	var authorization, _ = parameters.Lookup(headers, AuthorizationHeader)
+	return verifier.Authorization(ctx, authorization)
+}
+
+// Authorization checks an Authorization header, which carries either a bearer token or an API key sent with the bearer
+// scheme
+func (verifier *Verifier) Authorization(ctx context.Context, authorization string) (*auth.Principal, error) {
+	if secret, err := auth.BearerToken(authorization); err == nil && apikey.IsKey(secret) {
+		return verifier.Key(ctx, secret)
+	} else if verifier.authenticatorErr != nil {
+		return nil, verifier.authenticatorErr
+	}
+
+	return verifier.authenticator.Authenticate(ctx, authorization)
+}
+
+// Key returns the principal of the API key secret
+func (verifier *Verifier) Key(ctx context.Context, secret string) (*auth.Principal, error) {
+	if service, err := verifier.keyring.Service(ctx); err != nil {
+		return nil, err
+	} else if key, err := service.Verify(ctx, secret); err != nil {
+		return nil, err
+	} else {
+		return key.Principal(), nil
+	}
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
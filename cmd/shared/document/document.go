@@ -0,0 +1,134 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package document encodes and decodes the JSON:API (https://jsonapi.org/format/1.0/) documents exchanged with clients
+// and negotiates the JSON:API media type of a request. Errors are encoded by the responses package, which shares the
+// google/jsonapi error objects used here.
+package document
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/parameters"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+	"github.com/json-iterator/go"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// MediaType is the only media type a document is sent or accepted in
+	MediaType = jsonapi.MediaType
+
+	// defaultDomain is part of the host of every API served without a custom domain, whose paths begin with the stage
+	defaultDomain = `.execute-api.`
+
+	// qualityParameter weighs a media range of an Accept header, it is not a parameter of the media type itself
+	qualityParameter = `q`
+)
+
+var (
+	json = jsoniter.ConfigCompatibleWithStandardLibrary
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Document is a top level document, Data holds a single *Resource or a []*Resource collection and Meta any struct
+type Document struct {
+	Data  interface{} `json:"data"`
+	Links *Links      `json:"links,omitempty"`
+	Meta  interface{} `json:"meta,omitempty"`
+}
+
+// Resource is a resource object, Attributes is encoded as the attributes member and must not hold an id or type
+type Resource struct {
+	Type       string      `json:"type"`
+	ID         string      `json:"id,omitempty"`
+	Attributes interface{} `json:"attributes,omitempty"`
+	Links      *Links      `json:"links,omitempty"`
+}
+
+// Links is a links object, the links an endpoint may send are fields rather than the keys of a jsonapi.Links map
+type Links struct {
+	Self     string `json:"self,omitempty"`
+	Previous string `json:"prev,omitempty"`
+	Next     string `json:"next,omitempty"`
+}
+
+// incoming is a document sent by a client, its attributes are decoded once the type of the resource is known
+type incoming struct {
+	Data *struct {
+		Type       string              `json:"type"`
+		ID         string              `json:"id"`
+		Attributes jsoniter.RawMessage `json:"attributes"`
+	} `json:"data"`
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// Negotiate checks the Accept header of event allows a document in response, and when body is true that the request
+// body is a document as well. A Content-Type other than MediaType, or MediaType with parameters, is unsupported (415)
+// while an Accept header which only allows other types, or MediaType with parameters, is not acceptable (406).
+func Negotiate(event events.APIGatewayProxyRequest, body bool) *jsonapi.ErrorObject {
+	//-- Content-Type ----------
+	if contentType, _ := parameters.Header(event, `Content-Type`); body {
+		if kind, params, err := mime.ParseMediaType(contentType); err != nil || kind != MediaType {
+			return responses.UnsupportedMediaTypeErr(errors.New(fmt.Sprintf(`Content-Type - '%s' is not supported, use %s`, contentType, MediaType)))
+		} else if len(params) > 0 {
+			return responses.UnsupportedMediaTypeErr(errors.New(fmt.Sprintf(`Content-Type - %s may not be given media type parameters`, MediaType)))
+		}
+	}
+
+	//-- Accept ----------
+	if accept, ok := parameters.Header(event, `Accept`); ok && len(strings.TrimSpace(accept)) > 0 && !accepts(accept) {
+		return responses.NotAcceptableErr(errors.New(fmt.Sprintf(`Accept - '%s' does not allow %s without media type parameters`, accept, MediaType)))
+	}
+
+	return nil
+}
+
+// Decode reads the single resource document body, which must hold a resource of kind, into attributes and returns the
+// id of the resource. A missing resource is malformed (400) and a resource of another type conflicts (409).
+func Decode(body string, kind string, attributes interface{}) (string, *jsonapi.ErrorObject) {
+	var document incoming
+
+	if err := json.Unmarshal([]byte(body), &document); err != nil {
+		return ``, responses.MalformedRequestErr(err)
+	} else if document.Data == nil {
+		return ``, responses.MalformedRequestErr(errors.New(`data - a resource object is required`))
+	} else if document.Data.Type != kind {
+		return ``, responses.ConflictErr(errors.New(fmt.Sprintf(`data.type - '%s' does not match the '%s' this endpoint serves`, document.Data.Type, kind)))
+	} else if len(document.Data.Attributes) == 0 {
+		return document.Data.ID, nil
+	} else if err := json.Unmarshal(document.Data.Attributes, attributes); err != nil {
+		return ``, responses.MalformedRequestErr(err)
+	}
+
+	return document.Data.ID, nil
+}
+
+// Link resolves path against the root of the API that received event, keeping the stage API Gateway adds in front of
+// every path on its default domain. The link is relative to the host the client called.
+func Link(event events.APIGatewayProxyRequest, path string) string {
+	if host, _ := parameters.Header(event, `Host`); strings.Contains(host, defaultDomain) && len(event.RequestContext.Stage) > 0 {
+		return `/` + event.RequestContext.Stage + path
+	}
+	return path
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// accepts reports whether one of the media ranges of the Accept header accept allows an unparameterised MediaType
+func accepts(accept string) bool {
+	for _, value := range strings.Split(accept, `,`) {
+		if kind, params, err := mime.ParseMediaType(value); err != nil {
+			continue
+		} else if kind == `*/*` || kind == `application/*` {
+			return true
+		} else if _, weighted := params[qualityParameter]; kind == MediaType && (len(params) == 0 || weighted && len(params) == 1) {
+			return true
+		}
+	}
+
+	return false
+}
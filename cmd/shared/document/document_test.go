@@ -0,0 +1,169 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package document
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+// headed returns an event which carries headers
+func headed(headers map[string]string) events.APIGatewayProxyRequest {
+	return events.APIGatewayProxyRequest{Headers: headers}
+}
+
+// status is the HTTP status of err, zero when there is no error
+func status(err *jsonapi.ErrorObject) int {
+	if err == nil {
+		return 0
+	}
+
+	var code, _ = strconv.Atoi(err.Status)
+	return code
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestNegotiate(test *testing.T) {
+	//-- Shared Variables ----------
+	var statuses = make(map[string]int)
+
+	//-- Test Parameters ----------
+	var expected = map[string]int{
+		`document`:            0,
+		`no headers`:          0,
+		`lower case`:          0,
+		`any type`:            0,
+		`any application`:     0,
+		`weighted`:            0,
+		`listed`:              0,
+		`plain json`:          http.StatusUnsupportedMediaType,
+		`missing type`:        http.StatusUnsupportedMediaType,
+		`type parameters`:     http.StatusUnsupportedMediaType,
+		`accept other`:        http.StatusNotAcceptable,
+		`accept parameters`:   http.StatusNotAcceptable,
+		`accept unparseable`:  http.StatusNotAcceptable,
+		`body not negotiated`: 0,
+	}
+	var cases = map[string]struct {
+		headers map[string]string
+		body    bool
+	}{
+		`document`:            {map[string]string{`Content-Type`: MediaType, `Accept`: MediaType}, true},
+		`no headers`:          {nil, false},
+		`lower case`:          {map[string]string{`content-type`: MediaType, `accept`: MediaType}, true},
+		`any type`:            {map[string]string{`Content-Type`: MediaType, `Accept`: `*/*`}, true},
+		`any application`:     {map[string]string{`Content-Type`: MediaType, `Accept`: `application/*`}, true},
+		`weighted`:            {map[string]string{`Accept`: MediaType + `;q=0.8`}, false},
+		`listed`:              {map[string]string{`Accept`: `text/html, ` + MediaType + `; ext="bulk", ` + MediaType}, false},
+		`plain json`:          {map[string]string{`Content-Type`: `application/json`}, true},
+		`missing type`:        {nil, true},
+		`type parameters`:     {map[string]string{`Content-Type`: MediaType + `; charset=utf-8`}, true},
+		`accept other`:        {map[string]string{`Accept`: `application/json`}, false},
+		`accept parameters`:   {map[string]string{`Accept`: MediaType + `; ext="bulk"`}, false},
+		`accept unparseable`:  {map[string]string{`Accept`: `;;`}, false},
+		`body not negotiated`: {map[string]string{`Content-Type`: `text/plain`}, false},
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	for name, parameters := range cases {
+		statuses[name] = status(Negotiate(headed(parameters.headers), parameters.body))
+	}
+
+	//-- Post-conditions ----------
+	assert.Equal(test, expected, statuses)
+}
+
+func TestDecode(test *testing.T) {
+	//-- Shared Variables ----------
+	var attributes struct {
+		Name string `json:"name"`
+	}
+
+	//-- Test Parameters ----------
+	var body = `{"data": {"type": "tasks", "id": "7", "attributes": {"name": "Test document"}}}`
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	var id, decodeErr = Decode(body, `tasks`, &attributes)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, decodeErr)
+	assert.Equal(test, `7`, id)
+	assert.Equal(test, `Test document`, attributes.Name)
+}
+
+func TestDecodeInvalid(test *testing.T) {
+	//-- Shared Variables ----------
+	var attributes struct {
+		Name string `json:"name"`
+	}
+
+	//-- Test Parameters ----------
+	var expected = map[string]int{
+		`malformed`:       http.StatusBadRequest,
+		`missing data`:    http.StatusBadRequest,
+		`null data`:       http.StatusBadRequest,
+		`wrong type`:      http.StatusConflict,
+		`missing type`:    http.StatusConflict,
+		`bad attributes`:  http.StatusBadRequest,
+		`bare attributes`: http.StatusBadRequest,
+	}
+	var bodies = map[string]string{
+		`malformed`:       `{"data": `,
+		`missing data`:    `{"name": "Test document"}`,
+		`null data`:       `{"data": null}`,
+		`wrong type`:      `{"data": {"type": "keys", "attributes": {"name": "Test document"}}}`,
+		`missing type`:    `{"data": {"attributes": {"name": "Test document"}}}`,
+		`bad attributes`:  `{"data": {"type": "tasks", "attributes": {"name": 7}}}`,
+		`bare attributes`: `{"data": {"type": "tasks", "attributes": "Test document"}}`,
+	}
+
+	//-- Pre-conditions ----------
+	var statuses = make(map[string]int)
+
+	//-- Action ----------
+	for name, body := range bodies {
+		var _, err = Decode(body, `tasks`, &attributes)
+		statuses[name] = status(err)
+	}
+
+	//-- Post-conditions ----------
+	assert.Equal(test, expected, statuses)
+}
+
+func TestLink(test *testing.T) {
+	//-- Shared Variables ----------
+	var staged, custom, local events.APIGatewayProxyRequest
+
+	//-- Test Parameters ----------
+	var path = `/tasks/7`
+
+	//-- Pre-conditions ----------
+	staged = headed(map[string]string{`Host`: `abcdef1234.execute-api.us-west-2.amazonaws.com`})
+	staged.RequestContext.Stage = `production`
+
+	custom = headed(map[string]string{`Host`: `api.example.com`})
+	custom.RequestContext.Stage = `production`
+
+	local = headed(nil)
+
+	//-- Action ----------
+
+	//-- Post-conditions ----------
+	assert.Equal(test, `/production/tasks/7`, Link(staged, path))
+	assert.Equal(test, path, Link(custom, path))
+	assert.Equal(test, path, Link(local, path))
+}
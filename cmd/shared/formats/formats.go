@@ -0,0 +1,338 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package formats negotiates the media type of a response from its Accept header and encodes collections of records in
+// the flat formats reporting tools read (JSON, CSV, NDJSON and XML). Each record is a struct whose json tags name its
+// fields, so every format carries the same field set as the JSON response of the same struct.
+package formats
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/json-iterator/go"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	JSON   = `application/json`
+	CSV    = `text/csv`
+	NDJSON = `application/x-ndjson`
+	XML    = `application/xml`
+
+	// qualityParameter weighs a media range of an Accept header, it is not a parameter of the media type itself
+	qualityParameter = `q`
+
+	// formulaPrefixes start a CSV cell a spreadsheet would evaluate as a formula rather than display as text
+	formulaPrefixes = "=+-@\t\r"
+)
+
+var (
+	json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+	encoders = map[string]Encoder{
+		JSON:   encodeJSON,
+		CSV:    encodeCSV,
+		NDJSON: encodeNDJSON,
+		XML:    encodeXML,
+	}
+
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Encoder encodes the body of a response, which must be a Collection
+type Encoder func(body interface{}) ([]byte, error)
+
+// Collection is a list of records, Records is a slice of structs or of pointers to them. Name and Item are the XML
+// elements of the list and of each record, the other formats ignore them.
+type Collection struct {
+	Name    string
+	Item    string
+	Records interface{}
+}
+
+// field is a member of a record named by its json tag, index locates it for reflect.Value.FieldByIndex
+type field struct {
+	name  string
+	index []int
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// Lookup returns the encoder of mediaType, the media types it knows are JSON, CSV, NDJSON and XML
+func Lookup(mediaType string) (Encoder, bool) {
+	var encoder, ok = encoders[mediaType]
+	return encoder, ok
+}
+
+// Negotiate chooses the media type of offered the Accept header accept weighs highest, a type is weighed by the most
+// specific media range which matches it and ties go to the type offered first. A missing or empty header accepts the
+// first type offered while a media range with parameters other than its weight matches none, as none are offered.
+func Negotiate(accept string, offered ...string) (string, bool) {
+	if len(offered) == 0 {
+		return ``, false
+	} else if len(strings.TrimSpace(accept)) == 0 {
+		return offered[0], true
+	}
+
+	var chosen string
+	var best float64
+
+	for _, candidate := range offered {
+		if weight := weigh(accept, candidate); weight > best {
+			chosen, best = candidate, weight
+		}
+	}
+
+	return chosen, best > 0
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// weigh is the weight accept gives candidate, the weight of the most specific media range matching it or zero
+func weigh(accept string, candidate string) float64 {
+	var weight float64
+	var specificity = -1
+
+	for _, value := range strings.Split(accept, `,`) {
+		var kind, params, err = mime.ParseMediaType(value)
+		if err != nil {
+			continue
+		}
+
+		var quality = 1.0
+		if weighted, ok := params[qualityParameter]; ok {
+			if parsed, err := strconv.ParseFloat(weighted, 64); err != nil || parsed < 0 || parsed > 1 {
+				continue
+			} else {
+				quality = parsed
+			}
+		}
+
+		if _, ok := params[qualityParameter]; len(params) > 1 || len(params) == 1 && !ok {
+			continue
+		} else if level := match(kind, candidate); level > specificity {
+			weight, specificity = quality, level
+		}
+	}
+
+	return weight
+}
+
+// match is how specifically the media range kind matches candidate, 2 for the type itself, 1 for its subtypes, 0 for
+// any type and -1 when it does not match
+func match(kind string, candidate string) int {
+	switch {
+	case kind == candidate:
+		return 2
+	case strings.HasSuffix(kind, `/*`) && strings.HasPrefix(candidate, strings.TrimSuffix(kind, `*`)):
+		return 1
+	case kind == `*/*`:
+		return 0
+	default:
+		return -1
+	}
+}
+
+func encodeJSON(body interface{}) ([]byte, error) {
+	if records, err := recordsOf(body); err != nil {
+		return nil, err
+	} else {
+		var values = make([]interface{}, 0, records.Len())
+		for index := 0; index < records.Len(); index++ {
+			values = append(values, records.Index(index).Interface())
+		}
+
+		return json.Marshal(values)
+	}
+}
+
+func encodeNDJSON(body interface{}) ([]byte, error) {
+	var output bytes.Buffer
+
+	if records, err := recordsOf(body); err != nil {
+		return nil, err
+	} else {
+		for index := 0; index < records.Len(); index++ {
+			if line, err := json.Marshal(records.Index(index).Interface()); err != nil {
+				return nil, err
+			} else {
+				output.Write(line)
+				output.WriteByte('\n')
+			}
+		}
+	}
+
+	return output.Bytes(), nil
+}
+
+// encodeCSV writes a header row of field names and a row for each record, a missing value is an empty cell and a cell
+// a spreadsheet would evaluate as a formula is quoted as text with a leading apostrophe
+func encodeCSV(body interface{}) ([]byte, error) {
+	var output bytes.Buffer
+	var writer = csv.NewWriter(&output)
+
+	var records, err = recordsOf(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields = fieldsOf(records.Type().Elem())
+	var row = make([]string, len(fields))
+
+	for index, field := range fields {
+		row[index] = field.name
+	}
+	if err := writer.Write(row); err != nil {
+		return nil, err
+	}
+
+	for index := 0; index < records.Len(); index++ {
+		var record = reflect.Indirect(records.Index(index))
+
+		for column, field := range fields {
+			var cell, _ = format(record.FieldByIndex(field.index))
+			if len(cell) > 0 && strings.ContainsRune(formulaPrefixes, rune(cell[0])) {
+				cell = `'` + cell
+			}
+			row[column] = cell
+		}
+
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return output.Bytes(), writer.Error()
+}
+
+// encodeXML writes each record as an Item element within a Name element, a field is an element named after it and a
+// missing value leaves its element out
+func encodeXML(body interface{}) ([]byte, error) {
+	var output bytes.Buffer
+
+	var records, err = recordsOf(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var collection = body.(Collection)
+	var fields = fieldsOf(records.Type().Elem())
+
+	output.WriteString(xml.Header)
+	output.WriteString(`<` + collection.Name + `>`)
+
+	for index := 0; index < records.Len(); index++ {
+		var record = reflect.Indirect(records.Index(index))
+
+		output.WriteString(`<` + collection.Item + `>`)
+		for _, field := range fields {
+			if value, present := format(record.FieldByIndex(field.index)); present {
+				output.WriteString(`<` + field.name + `>`)
+				if err := xml.EscapeText(&output, []byte(value)); err != nil {
+					return nil, err
+				}
+				output.WriteString(`</` + field.name + `>`)
+			}
+		}
+		output.WriteString(`</` + collection.Item + `>`)
+	}
+
+	output.WriteString(`</` + collection.Name + `>`)
+
+	return output.Bytes(), nil
+}
+
+// recordsOf is the slice of records body holds, every element must be a struct or a pointer to one
+func recordsOf(body interface{}) (reflect.Value, error) {
+	var collection, ok = body.(Collection)
+	if !ok {
+		return reflect.Value{}, errors.New(fmt.Sprintf(`formats - a %T can not be encoded, a Collection is required`, body))
+	}
+
+	var value = reflect.ValueOf(collection.Records)
+	if value.Kind() != reflect.Slice {
+		return reflect.Value{}, errors.New(fmt.Sprintf(`formats - records must be a slice, not a %T`, collection.Records))
+	}
+
+	var element = value.Type().Elem()
+	if element.Kind() == reflect.Ptr {
+		element = element.Elem()
+	}
+	if element.Kind() != reflect.Struct {
+		return reflect.Value{}, errors.New(fmt.Sprintf(`formats - records must be structs, not %s`, element))
+	}
+
+	for index := 0; index < value.Len(); index++ {
+		if value.Index(index).Kind() == reflect.Ptr && value.Index(index).IsNil() {
+			return reflect.Value{}, errors.New(fmt.Sprintf(`formats - record %d is nil`, index))
+		}
+	}
+
+	return value, nil
+}
+
+// fieldsOf lists the fields of the record type kind in the order they are declared, named by their json tags. The
+// fields of an untagged embedded struct are promoted as they are by encoding/json, while a field tagged "-" is skipped.
+func fieldsOf(kind reflect.Type) []field {
+	var fields []field
+
+	if kind.Kind() == reflect.Ptr {
+		kind = kind.Elem()
+	}
+
+	for index := 0; index < kind.NumField(); index++ {
+		var member = kind.Field(index)
+		var name = strings.Split(member.Tag.Get(`json`), `,`)[0]
+
+		switch {
+		case name == `-`:
+			continue
+		case member.Anonymous && len(name) == 0 && member.Type.Kind() == reflect.Struct:
+			for _, promoted := range fieldsOf(member.Type) {
+				fields = append(fields, field{name: promoted.name, index: append([]int{index}, promoted.index...)})
+			}
+		case len(member.PkgPath) > 0:
+			continue
+		case len(name) == 0:
+			fields = append(fields, field{name: member.Name, index: []int{index}})
+		default:
+			fields = append(fields, field{name: name, index: []int{index}})
+		}
+	}
+
+	return fields
+}
+
+// format is the text of value and whether it is present, a nil pointer is missing and a time is written in UTC
+// (RFC3339)
+func format(value reflect.Value) (string, bool) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ``, false
+		}
+		value = value.Elem()
+	}
+
+	switch {
+	case value.Type() == timeType:
+		return value.Interface().(time.Time).UTC().Format(time.RFC3339Nano), true
+	case value.Kind() == reflect.String:
+		return value.String(), true
+	case value.Kind() == reflect.Bool:
+		return strconv.FormatBool(value.Bool()), true
+	case value.Kind() >= reflect.Int && value.Kind() <= reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), true
+	case value.Kind() >= reflect.Uint && value.Kind() <= reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), true
+	default:
+		return fmt.Sprint(value.Interface()), true
+	}
+}
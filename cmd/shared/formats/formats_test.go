@@ -0,0 +1,143 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package formats
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+type testAttributes struct {
+	Name    string     `json:"name"`
+	Details *string    `json:"details,omitempty"`
+	Secret  string     `json:"-"`
+	DueAt   *time.Time `json:"due_at,omitempty"`
+}
+
+type testRecord struct {
+	ID uint `json:"id"`
+	testAttributes
+
+	Done bool `json:"done"`
+}
+
+// collection holds a complete record and one missing its optional fields, whose name a spreadsheet would evaluate
+func collection() Collection {
+	var details = `Needs <escaping> & "quoting", too`
+	var due = time.Date(2019, 1, 1, 0, 0, 1, 0, time.FixedZone(`test`, 3600))
+
+	return Collection{
+		Name: `records`,
+		Item: `record`,
+		Records: []*testRecord{
+			{ID: 1, testAttributes: testAttributes{Name: `First`, Details: &details, Secret: `hidden`, DueAt: &due}, Done: true},
+			{ID: 2, testAttributes: testAttributes{Name: `=SUM(A1:A2)`}},
+		},
+	}
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestNegotiate(test *testing.T) {
+	//-- Shared Variables ----------
+	var chosen = make(map[string]string)
+
+	//-- Test Parameters ----------
+	var offered = []string{`application/vnd.api+json`, JSON, CSV, NDJSON, XML}
+	var expected = map[string]string{
+		``:                                      `application/vnd.api+json`,
+		`*/*`:                                   `application/vnd.api+json`,
+		`text/csv`:                              CSV,
+		`text/*`:                                CSV,
+		`application/*`:                         `application/vnd.api+json`,
+		`application/xml;q=0.5, text/csv;q=0.9`: CSV,
+		`application/x-ndjson, */*;q=0.1`:       NDJSON,
+		`*/*;q=0.5, application/json;q=0`:       `application/vnd.api+json`,
+		`text/html`:                             ``,
+		`application/json;q=0`:                  ``,
+		`application/json; charset=utf-8`:       ``,
+		`application/json;q=2`:                  ``,
+		`;;, text/csv`:                          CSV,
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	for accept := range expected {
+		chosen[accept], _ = Negotiate(accept, offered...)
+	}
+
+	//-- Post-conditions ----------
+	assert.Equal(test, expected, chosen)
+}
+
+func TestEncode(test *testing.T) {
+	//-- Shared Variables ----------
+	var outputs = make(map[string]string)
+
+	//-- Test Parameters ----------
+	var expected = map[string]string{
+		JSON: `[{"id":1,"name":"First","details":"Needs \u003cescaping\u003e \u0026 \"quoting\", too","due_at":"2019-01-01T00:00:01+01:00","done":true},` +
+			`{"id":2,"name":"=SUM(A1:A2)","done":false}]`,
+		NDJSON: `{"id":1,"name":"First","details":"Needs \u003cescaping\u003e \u0026 \"quoting\", too","due_at":"2019-01-01T00:00:01+01:00","done":true}` + "\n" +
+			`{"id":2,"name":"=SUM(A1:A2)","done":false}` + "\n",
+		CSV: "id,name,details,due_at,done\n" +
+			"1,First,\"Needs <escaping> & \"\"quoting\"\", too\",2018-12-31T23:00:01Z,true\n" +
+			"2,'=SUM(A1:A2),,,false\n",
+		XML: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<records>` +
+			`<record><id>1</id><name>First</name><details>Needs &lt;escaping&gt; &amp; &#34;quoting&#34;, too</details><due_at>2018-12-31T23:00:01Z</due_at><done>true</done></record>` +
+			`<record><id>2</id><name>=SUM(A1:A2)</name><done>false</done></record>` +
+			`</records>`,
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	for mediaType := range expected {
+		if encoder, ok := Lookup(mediaType); !ok {
+			test.Fatalf(`no encoder for %s`, mediaType)
+		} else if output, err := encoder(collection()); err != nil {
+			test.Fatalf(`unable to encode %s: %s`, mediaType, err)
+		} else {
+			outputs[mediaType] = string(output)
+		}
+	}
+
+	//-- Post-conditions ----------
+	assert.Equal(test, expected, outputs)
+}
+
+func TestEncodeInvalid(test *testing.T) {
+	//-- Shared Variables ----------
+
+	//-- Test Parameters ----------
+	var bodies = map[string]interface{}{
+		`not a collection`: []testRecord{{ID: 1}},
+		`not a slice`:      Collection{Records: testRecord{ID: 1}},
+		`not structs`:      Collection{Records: []string{`first`}},
+		`nil record`:       Collection{Records: []*testRecord{nil}},
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+
+	//-- Post-conditions ----------
+	for _, mediaType := range []string{JSON, CSV, NDJSON, XML} {
+		var encoder, _ = Lookup(mediaType)
+
+		for name, body := range bodies {
+			var _, err = encoder(body)
+			assert.NotNil(test, err, mediaType+` `+name)
+		}
+	}
+
+	var _, ok = Lookup(`text/html`)
+	assert.False(test, ok)
+}
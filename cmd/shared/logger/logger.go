@@ -1,36 +1,120 @@
 //-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package logger writes one JSON object per line at the level named by LOG_LEVEL, tagging each line with the request and
+// trace IDs carried by its context so CloudWatch Logs Insights can filter and aggregate them.
 package logger
 
 //-- Imports -----------------------------------------------------------------------------------------------------------
 import (
-	"bufio"
-	"bytes"
-	"log"
+	"context"
+	"io"
+	"log/slog"
 	"os"
+	"strings"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/JustonDavies/go_serverless_api/pkg/tracing"
+	"github.com/aws/aws-lambda-go/lambdacontext"
 )
 
 //-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	RequestIDKey       = `request_id`
+	LambdaRequestIDKey = `lambda_request_id`
+	TraceIDKey         = `trace_id`
+	SpanIDKey          = `span_id`
+	PrincipalKey       = `principal`
+)
+
 var (
 	environment = os.Getenv(`ENVIRONMENT`)
+	level       = os.Getenv(`LOG_LEVEL`)
 )
 
 //-- Structs -----------------------------------------------------------------------------------------------------------
+type requestIDKey struct{}
+
+// contextHandler adds the request and trace IDs found in the context of each record before passing it to the wrapped handler
+type contextHandler struct {
+	slog.Handler
+}
 
 //-- Exported Functions ------------------------------------------------------------------------------------------------
-func NewLogger() *log.Logger {
+// init makes the JSON logger the default, so the slog package functions and the standard log package share its output
+// in every binary which imports this package
+func init() {
+	slog.SetDefault(NewLogger())
+}
+
+// NewLogger returns a logger writing to stdout at LOG_LEVEL, output is discarded in the test environment
+func NewLogger() *slog.Logger {
 	if environment == `test` {
-		var buffer bytes.Buffer
-		var writer *bufio.Writer
-		var logger log.Logger
+		return New(io.Discard, ParseLevel(level))
+	}
+
+	return New(os.Stdout, ParseLevel(level))
+}
+
+// New returns a logger writing JSON lines at or above minimum to writer
+func New(writer io.Writer, minimum slog.Level) *slog.Logger {
+	return slog.New(contextHandler{slog.NewJSONHandler(writer, &slog.HandlerOptions{Level: minimum})})
+}
+
+// ParseLevel reads one of debug, info, warn or error, ignoring case, and falls back to info for anything else
+func ParseLevel(value string) slog.Level {
+	var parsed slog.Level
+
+	if err := parsed.UnmarshalText([]byte(strings.TrimSpace(value))); err != nil {
+		return slog.LevelInfo
+	}
+
+	return parsed
+}
+
+// WithRequestID returns a copy of ctx which tags every line logged through it with id
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, or an empty string when there is none
+func RequestID(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+	return ``
+}
+
+// Latency is the time elapsed since start in milliseconds, named the same way by every line which reports it
+func Latency(start time.Time) slog.Attr {
+	return slog.Float64(`latency_ms`, float64(time.Since(start).Microseconds())/1000)
+}
 
-		writer = bufio.NewWriter(&buffer)
+func (handler contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestID(ctx); len(id) > 0 {
+		record.AddAttrs(slog.String(RequestIDKey, id))
+	}
+
+	if invocation, ok := lambdacontext.FromContext(ctx); ok && len(invocation.AwsRequestID) > 0 {
+		record.AddAttrs(slog.String(LambdaRequestIDKey, invocation.AwsRequestID))
+	}
 
-		logger.SetOutput(writer)
+	if span := tracing.SpanContextFrom(ctx); span.IsValid() {
+		record.AddAttrs(slog.String(TraceIDKey, span.TraceID.String()), slog.String(SpanIDKey, span.SpanID.String()))
+	}
 
-		return &logger
+	if principal := auth.PrincipalFrom(ctx); principal != nil {
+		record.AddAttrs(slog.String(PrincipalKey, principal.Subject))
 	}
 
-	return log.New(os.Stdout, `task_service: `, 3)
+	return handler.Handler.Handle(ctx, record)
+}
+
+func (handler contextHandler) WithAttrs(attributes []slog.Attr) slog.Handler {
+	return contextHandler{handler.Handler.WithAttrs(attributes)}
+}
+
+func (handler contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{handler.Handler.WithGroup(name)}
 }
 
 //-- Internal Functions ------------------------------------------------------------------------------------------------
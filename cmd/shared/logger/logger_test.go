@@ -0,0 +1,89 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package logger
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestParseLevel(test *testing.T) {
+	//-- Shared Variables ----------
+
+	//-- Test Parameters ----------
+	var cases = map[string]slog.Level{
+		``:        slog.LevelInfo,
+		`debug`:   slog.LevelDebug,
+		`INFO`:    slog.LevelInfo,
+		` warn `:  slog.LevelWarn,
+		`Error`:   slog.LevelError,
+		`verbose`: slog.LevelInfo,
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+
+	//-- Post-conditions ----------
+	for value, level := range cases {
+		assert.Equal(test, level, ParseLevel(value), value)
+	}
+}
+
+func TestLoggerRequestIDs(test *testing.T) {
+	//-- Shared Variables ----------
+	var buffer bytes.Buffer
+	var ctx context.Context
+	var line map[string]interface{}
+
+	//-- Test Parameters ----------
+	var requestID = `test api gateway request`
+	var lambdaRequestID = `test lambda request`
+
+	//-- Pre-conditions ----------
+	ctx = lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{AwsRequestID: lambdaRequestID})
+	ctx = WithRequestID(ctx, requestID)
+
+	//-- Action ----------
+	New(&buffer, slog.LevelInfo).With(slog.String(`component`, `test`)).InfoContext(ctx, `tagged`)
+
+	//-- Post-conditions ----------
+	if err := json.Unmarshal(buffer.Bytes(), &line); err != nil {
+		test.Fatalf(`unable to parse log line '%s': %s`, buffer.String(), err)
+	}
+
+	assert.Equal(test, `tagged`, line[`msg`])
+	assert.Equal(test, `test`, line[`component`])
+	assert.Equal(test, requestID, line[RequestIDKey])
+	assert.Equal(test, lambdaRequestID, line[LambdaRequestIDKey])
+}
+
+func TestLoggerLevel(test *testing.T) {
+	//-- Shared Variables ----------
+	var buffer bytes.Buffer
+	var logger *slog.Logger
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	logger = New(&buffer, slog.LevelWarn)
+
+	//-- Action ----------
+	logger.Info(`dropped`)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, 0, buffer.Len())
+}
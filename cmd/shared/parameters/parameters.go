@@ -0,0 +1,140 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package parameters reads typed values out of the query string of an API Gateway proxy event, collecting a JSON API
+// error for every value which is malformed or out of range rather than stopping at the first. It also looks up
+// request headers, whose names API Gateway passes through with whatever case the client used.
+package parameters
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type Parser struct {
+	values map[string][]string
+	errs   []*jsonapi.ErrorObject
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// NewParser merges the single and multi value query string parameters of event, API Gateway populates both when a
+// parameter is repeated and only the multi value form carries every occurrence
+func NewParser(event events.APIGatewayProxyRequest) *Parser {
+	var parser = &Parser{values: make(map[string][]string)}
+
+	for name, value := range event.QueryStringParameters {
+		parser.values[name] = []string{value}
+	}
+	for name, values := range event.MultiValueQueryStringParameters {
+		if len(values) > 0 {
+			parser.values[name] = values
+		}
+	}
+
+	return parser
+}
+
+// Header returns the value of the named request header of event, matching the name without regard to case
+func Header(event events.APIGatewayProxyRequest, name string) (string, bool) {
+	return Lookup(event.Headers, name)
+}
+
+// Lookup returns the value of the named header in headers, matching the name without regard to case
+func Lookup(headers map[string]string, name string) (string, bool) {
+	if value, ok := headers[name]; ok {
+		return value, true
+	}
+
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+
+	return ``, false
+}
+
+// Has reports whether the named parameter was present in the query string
+func (parser *Parser) Has(name string) bool {
+	var _, ok = parser.values[name]
+	return ok
+}
+
+// Uint stores the named parameter in target when present, it must be a base 10 integer between minimum and maximum
+func (parser *Parser) Uint(name string, minimum uint64, maximum uint64, target *uint) {
+	if value, ok := parser.single(name); !ok {
+		return
+	} else if parsed, err := strconv.ParseUint(value, 10, 64); err != nil {
+		parser.fail(name, errors.New(fmt.Sprintf(`'%s' is not a non-negative integer`, value)))
+	} else if parsed < minimum || parsed > maximum {
+		parser.fail(name, errors.New(fmt.Sprintf(`%d must be between %d and %d`, parsed, minimum, maximum)))
+	} else {
+		*target = uint(parsed)
+	}
+}
+
+// Bool stores the named parameter in target when present, it must be one of true or false
+func (parser *Parser) Bool(name string, target **bool) {
+	if value, ok := parser.single(name); !ok {
+		return
+	} else if value != `true` && value != `false` {
+		parser.fail(name, errors.New(fmt.Sprintf(`'%s' must be one of true or false`, value)))
+	} else {
+		var parsed = value == `true`
+		*target = &parsed
+	}
+}
+
+// Time stores the named parameter in target when present, it must be an RFC3339 timestamp
+func (parser *Parser) Time(name string, target **time.Time) {
+	if value, ok := parser.single(name); !ok {
+		return
+	} else if parsed, err := time.Parse(time.RFC3339Nano, value); err != nil {
+		parser.fail(name, errors.New(fmt.Sprintf(`'%s' is not an RFC3339 timestamp`, value)))
+	} else {
+		*target = &parsed
+	}
+}
+
+// String stores the named parameter in target when present, it may not exceed maximum bytes
+func (parser *Parser) String(name string, maximum int, target *string) {
+	if value, ok := parser.single(name); !ok {
+		return
+	} else if len(value) > maximum {
+		parser.fail(name, errors.New(fmt.Sprintf(`value may not exceed %d characters`, maximum)))
+	} else {
+		*target = value
+	}
+}
+
+// Errors returns one error object for each parameter which failed to parse, or nil when all of them succeeded
+func (parser *Parser) Errors() []*jsonapi.ErrorObject {
+	return parser.errs
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+func (parser *Parser) single(name string) (string, bool) {
+	var values, ok = parser.values[name]
+
+	if !ok {
+		return ``, false
+	} else if len(values) > 1 {
+		parser.fail(name, errors.New(`parameter may only be given once`))
+		return ``, false
+	}
+
+	return values[0], true
+}
+
+func (parser *Parser) fail(name string, err error) {
+	parser.errs = append(parser.errs, responses.BadQueryParameterErr(errors.New(fmt.Sprintf(`%s - %s`, name, err))))
+}
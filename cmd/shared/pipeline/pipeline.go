@@ -0,0 +1,207 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package pipeline runs the steps every API Gateway handler shares (ignore warm-ups, parse, connect, act and respond) so
+// that each handler only supplies the work specific to its route.
+package pipeline
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/logger"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/parameters"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/warmup"
+	"github.com/JustonDavies/go_serverless_api/pkg/tracing"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+	"github.com/json-iterator/go"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+var (
+	json = jsoniter.ConfigCompatibleWithStandardLibrary
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Handler is the signature of every API Gateway proxy handler, whether it serves one route or routes to many
+type Handler func(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+
+// Stage is a single named step of a pipeline, returning any error objects stops the pipeline and responds with them.
+// The context a stage returns is passed to every later stage, which lets a stage such as Authenticate attach values.
+type Stage struct {
+	Name string
+	Run  func(ctx context.Context) (context.Context, []*jsonapi.ErrorObject)
+}
+
+// Result lets respond choose the status and headers of the response as well as its body, any other value returned by
+// respond is treated as the body of a 200 response. Encode replaces the JSON encoding of Body when it is set.
+type Result struct {
+	Status  int
+	Headers map[string]string
+	Body    interface{}
+	Encode  func(body interface{}) ([]byte, error)
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// Run ignores warm-up events, then executes stages in order for event and finally encodes the value returned by
+// respond as the body of the response, in JSON unless it is a Result with an encoder of its own. The request is traced
+// as a server span continuing the trace of any traceparent header on event, each stage and the encoding of the response
+// are its children.
+func Run(ctx context.Context, event events.APIGatewayProxyRequest, respond func() interface{}, stages ...Stage) (events.APIGatewayProxyResponse, error) {
+	ctx = logger.WithRequestID(ctx, event.RequestContext.RequestID)
+
+	//-- Ignore Warm-Ups ----------
+	if warmup.IsScheduledWarmupEvent(event) {
+		slog.DebugContext(ctx, `warm-up event ignored`)
+		return warmup.DefaultAPIGatewatResponse()
+	}
+
+	//-- Trace ----------
+	var start = time.Now()
+	var span *tracing.Span
+
+	if header, ok := parameters.Header(event, tracing.TraceparentHeader); ok {
+		if parent, err := tracing.ParseTraceparent(header); err == nil {
+			ctx = tracing.WithRemoteParent(ctx, parent)
+		}
+	}
+
+	ctx, span = tracing.Start(ctx, event.HTTPMethod+` `+event.Resource, tracing.KindServer)
+	defer span.End()
+
+	span.SetAttribute(`http.request.method`, event.HTTPMethod)
+	span.SetAttribute(`http.route`, event.Resource)
+	span.SetAttribute(`aws.request_id`, event.RequestContext.RequestID)
+
+	//-- Stages ----------
+	for _, stage := range stages {
+		var errs []*jsonapi.ErrorObject
+		if ctx, errs = run(ctx, stage); len(errs) > 0 {
+			return complete(ctx, event, start, stage.Name)(responses.APIGatewayProxyErrors(errs))
+		}
+		slog.DebugContext(ctx, `stage finished`, slog.String(`stage`, stage.Name), logger.Latency(start))
+	}
+
+	//-- Response ----------
+	var result Result
+	switch value := respond().(type) {
+	case Result:
+		result = value
+	default:
+		result = Result{Body: value}
+	}
+
+	if result.Status == 0 {
+		result.Status = http.StatusOK
+	}
+	if result.Encode == nil {
+		result.Encode = json.Marshal
+	}
+
+	var _, encoding = tracing.Start(ctx, `Response encoded`, tracing.KindInternal)
+	var output, err = result.Encode(result.Body)
+	encoding.RecordError(err)
+	encoding.End()
+
+	if err != nil {
+		return complete(ctx, event, start, `Response encoded`)(responses.APIGatewayProxyError(responses.InternalServerErr(err)))
+	} else {
+		return complete(ctx, event, start, ``)(events.APIGatewayProxyResponse{
+			Body:       string(output),
+			Headers:    result.Headers,
+			StatusCode: result.Status,
+		}, nil)
+	}
+}
+
+// Fail ends a stage with errs, the status of the first error becomes the status of the response
+func Fail(errs ...*jsonapi.ErrorObject) []*jsonapi.ErrorObject {
+	return errs
+}
+
+// Authenticate identifies the caller, the context it returns usually carries the caller's principal
+func Authenticate(run func(ctx context.Context) (context.Context, []*jsonapi.ErrorObject)) Stage {
+	return Stage{Name: `Request authenticated`, Run: run}
+}
+
+// Negotiate checks the request is encoded in, and accepts a response in, a media type the handler supports
+func Negotiate(run func(ctx context.Context) []*jsonapi.ErrorObject) Stage {
+	return Stage{Name: `Media type negotiated`, Run: keep(run)}
+}
+
+func Parse(run func(ctx context.Context) []*jsonapi.ErrorObject) Stage {
+	return Stage{Name: `Event parsed`, Run: keep(run)}
+}
+
+func Connect(run func(ctx context.Context) []*jsonapi.ErrorObject) Stage {
+	return Stage{Name: `Service started`, Run: keep(run)}
+}
+
+func Action(run func(ctx context.Context) []*jsonapi.ErrorObject) Stage {
+	return Stage{Name: `Action finished`, Run: keep(run)}
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// run executes stage within a span of its own, a stage which returns errors marks its span as failed. The context
+// returned carries the server span again rather than the span of stage, so later stages are not nested beneath it.
+func run(ctx context.Context, stage Stage) (context.Context, []*jsonapi.ErrorObject) {
+	var traced, span = tracing.Start(ctx, stage.Name, tracing.KindInternal)
+	defer span.End()
+
+	var next, errs = stage.Run(traced)
+	if len(errs) > 0 {
+		span.SetAttribute(`error.status`, errs[0].Status)
+		span.RecordError(errors.New(errs[0].Title))
+	}
+
+	if next == nil {
+		next = traced
+	}
+
+	return tracing.ContextWithSpan(next, tracing.FromContext(ctx)), errs
+}
+
+// keep adapts a stage which leaves the context unchanged
+func keep(run func(ctx context.Context) []*jsonapi.ErrorObject) func(ctx context.Context) (context.Context, []*jsonapi.ErrorObject) {
+	return func(ctx context.Context) (context.Context, []*jsonapi.ErrorObject) {
+		return ctx, run(ctx)
+	}
+}
+
+// complete logs the outcome of the request handled for event before passing its response through, failed names the
+// stage which stopped the pipeline and is empty when every stage succeeded
+func complete(ctx context.Context, event events.APIGatewayProxyRequest, start time.Time, failed string) func(events.APIGatewayProxyResponse, error) (events.APIGatewayProxyResponse, error) {
+	return func(response events.APIGatewayProxyResponse, err error) (events.APIGatewayProxyResponse, error) {
+		var level = slog.LevelInfo
+		var attributes = []slog.Attr{
+			slog.String(`method`, event.HTTPMethod),
+			slog.String(`resource`, event.Resource),
+			slog.Int(`status`, response.StatusCode),
+			slog.Int(`bytes`, len(response.Body)),
+			logger.Latency(start),
+		}
+
+		if len(failed) > 0 {
+			attributes = append(attributes, slog.String(`stage`, failed))
+		}
+
+		if err != nil || response.StatusCode >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		var span = tracing.FromContext(ctx)
+		span.SetAttribute(`http.response.status_code`, response.StatusCode)
+		if err != nil {
+			span.RecordError(err)
+		} else if response.StatusCode >= http.StatusInternalServerError {
+			span.RecordError(errors.New(http.StatusText(response.StatusCode)))
+		}
+
+		slog.LogAttrs(ctx, level, `request completed`, attributes...)
+		return response, err
+	}
+}
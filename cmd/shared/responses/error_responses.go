@@ -5,7 +5,7 @@ package responses
 import (
 	"bytes"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"strconv"
 
@@ -27,11 +27,47 @@ func BadPathParameterErr(err error) *jsonapi.ErrorObject {
 	}
 }
 
+func BadQueryParameterErr(err error) *jsonapi.ErrorObject {
+	return &jsonapi.ErrorObject{
+		Status: fmt.Sprintf(`%d`, http.StatusBadRequest),
+		Title:  http.StatusText(http.StatusBadRequest),
+		Detail: `Query parameter was invalid or unable to parse/process`,
+		Meta:   &map[string]interface{}{`error`: err.Error()},
+	}
+}
+
 func NotAcceptableErr(err error) *jsonapi.ErrorObject {
 	return &jsonapi.ErrorObject{
 		Status: fmt.Sprintf(`%d`, http.StatusNotAcceptable),
 		Title:  http.StatusText(http.StatusNotAcceptable),
-		Detail: `Request parameter was not acceptabe`,
+		Detail: `The response can not be encoded in a media type the request accepts`,
+		Meta:   &map[string]interface{}{`error`: err.Error()},
+	}
+}
+
+func UnsupportedMediaTypeErr(err error) *jsonapi.ErrorObject {
+	return &jsonapi.ErrorObject{
+		Status: fmt.Sprintf(`%d`, http.StatusUnsupportedMediaType),
+		Title:  http.StatusText(http.StatusUnsupportedMediaType),
+		Detail: `The request body is not encoded in a supported media type`,
+		Meta:   &map[string]interface{}{`error`: err.Error()},
+	}
+}
+
+func ConflictErr(err error) *jsonapi.ErrorObject {
+	return &jsonapi.ErrorObject{
+		Status: fmt.Sprintf(`%d`, http.StatusConflict),
+		Title:  http.StatusText(http.StatusConflict),
+		Detail: `The request conflicts with the resource it targets`,
+		Meta:   &map[string]interface{}{`error`: err.Error()},
+	}
+}
+
+func RequestTooLargeErr(err error) *jsonapi.ErrorObject {
+	return &jsonapi.ErrorObject{
+		Status: fmt.Sprintf(`%d`, http.StatusRequestEntityTooLarge),
+		Title:  http.StatusText(http.StatusRequestEntityTooLarge),
+		Detail: `The request carries more than the endpoint accepts at once`,
 		Meta:   &map[string]interface{}{`error`: err.Error()},
 	}
 }
@@ -72,6 +108,33 @@ func NotFound(err error) *jsonapi.ErrorObject {
 	}
 }
 
+func RouteNotFoundErr(err error) *jsonapi.ErrorObject {
+	return &jsonapi.ErrorObject{
+		Status: fmt.Sprintf(`%d`, http.StatusNotFound),
+		Title:  http.StatusText(http.StatusNotFound),
+		Detail: `Route not found`,
+		Meta:   &map[string]interface{}{`error`: err.Error()},
+	}
+}
+
+func MethodNotAllowedErr(err error) *jsonapi.ErrorObject {
+	return &jsonapi.ErrorObject{
+		Status: fmt.Sprintf(`%d`, http.StatusMethodNotAllowed),
+		Title:  http.StatusText(http.StatusMethodNotAllowed),
+		Detail: `Method not allowed for this route`,
+		Meta:   &map[string]interface{}{`error`: err.Error()},
+	}
+}
+
+func PreconditionFailedErr(err error) *jsonapi.ErrorObject {
+	return &jsonapi.ErrorObject{
+		Status: fmt.Sprintf(`%d`, http.StatusPreconditionFailed),
+		Title:  http.StatusText(http.StatusPreconditionFailed),
+		Detail: `The resource has changed since it was last read`,
+		Meta:   &map[string]interface{}{`error`: err.Error()},
+	}
+}
+
 func Unauthorized(err error) *jsonapi.ErrorObject {
 	return &jsonapi.ErrorObject{
 		Status: fmt.Sprintf(`%d`, http.StatusUnauthorized),
@@ -81,6 +144,15 @@ func Unauthorized(err error) *jsonapi.ErrorObject {
 	}
 }
 
+func Forbidden(err error) *jsonapi.ErrorObject {
+	return &jsonapi.ErrorObject{
+		Status: fmt.Sprintf(`%d`, http.StatusForbidden),
+		Title:  http.StatusText(http.StatusForbidden),
+		Detail: `The caller may not act on this resource`,
+		Meta:   &map[string]interface{}{`error`: err.Error()},
+	}
+}
+
 func APIGatewayProxyError(err *jsonapi.ErrorObject) (events.APIGatewayProxyResponse, error) {
 	var errs []*jsonapi.ErrorObject
 	errs = append(errs, err)
@@ -93,7 +165,7 @@ func APIGatewayProxyErrors(errs []*jsonapi.ErrorObject) (events.APIGatewayProxyR
 	var writer = new(bytes.Buffer)
 
 	if err := jsonapi.MarshalErrors(writer, errs); err != nil {
-		log.Println(`Error Serializing Errors: `, err)
+		slog.Error(`unable to serialize errors`, slog.String(`error`, err.Error()))
 	}
 
 	var status int
@@ -105,8 +177,15 @@ func APIGatewayProxyErrors(errs []*jsonapi.ErrorObject) (events.APIGatewayProxyR
 		}
 	}
 
+	//-- Headers ----------
+	var headers = map[string]string{`Content-Type`: jsonapi.MediaType}
+	if status == http.StatusUnauthorized {
+		headers[`WWW-Authenticate`] = `Bearer`
+	}
+
 	return events.APIGatewayProxyResponse{
 		StatusCode: status,
+		Headers:    headers,
 		Body:       writer.String(),
 	}, nil
 }
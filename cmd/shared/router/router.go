@@ -0,0 +1,114 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package router dispatches API Gateway proxy events to a handler by their resource template and HTTP method, letting
+// a single Lambda serve any number of routes.
+package router
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/pipeline"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/warmup"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type Router struct {
+	routes map[string]map[string]pipeline.Handler
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+func New() *Router {
+	return &Router{routes: make(map[string]map[string]pipeline.Handler)}
+}
+
+// Handle registers handler for events whose Resource equals resource (such as /tasks/{id}) and whose HTTPMethod
+// equals method, registering the same pair twice replaces the earlier handler
+func (router *Router) Handle(method string, resource string, handler pipeline.Handler) {
+	if _, ok := router.routes[resource]; !ok {
+		router.routes[resource] = make(map[string]pipeline.Handler)
+	}
+	router.routes[resource][strings.ToUpper(method)] = handler
+}
+
+// Match finds the registered resource template for a concrete request path (such as /tasks/12 for /tasks/{id}) and
+// extracts its path parameters. Literal segments take precedence over parameters when several templates match.
+func (router *Router) Match(path string) (string, map[string]string, bool) {
+	//-- Common variables ----------
+	var resource string
+	var parameters map[string]string
+	var fewest = -1
+
+	//-- Pick the matching template with the fewest parameters ----------
+	for template := range router.routes {
+		if captured, ok := match(template, path); !ok {
+			continue
+		} else if fewest < 0 || len(captured) < fewest || (len(captured) == fewest && template < resource) {
+			resource, parameters, fewest = template, captured, len(captured)
+		}
+	}
+
+	return resource, parameters, fewest >= 0
+}
+
+// Route is a pipeline.Handler which forwards event to the matching handler, responding 404 for an unknown resource and
+// 405 for a known resource without a handler for the method, naming the methods it does handle in the Allow header
+func (router *Router) Route(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	//-- Ignore Warm-Ups ----------
+	if warmup.IsScheduledWarmupEvent(event) {
+		slog.DebugContext(ctx, `warm-up event ignored`)
+		return warmup.DefaultAPIGatewatResponse()
+	}
+
+	//-- Dispatch ----------
+	if methods, ok := router.routes[event.Resource]; !ok {
+		return responses.APIGatewayProxyError(responses.RouteNotFoundErr(errors.New(fmt.Sprintf(`no route for resource '%s'`, event.Resource))))
+	} else if handler, ok := methods[strings.ToUpper(event.HTTPMethod)]; !ok {
+		var response, err = responses.APIGatewayProxyError(responses.MethodNotAllowedErr(errors.New(fmt.Sprintf(`method '%s' is not supported by resource '%s'`, event.HTTPMethod, event.Resource))))
+		response.Headers[`Allow`] = allow(methods)
+		return response, err
+	} else {
+		return handler(ctx, event)
+	}
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// allow lists the methods a resource handles in the form of an Allow header (RFC 9110), sorted for a stable value
+func allow(methods map[string]pipeline.Handler) string {
+	var names = make([]string, 0, len(methods))
+
+	for method := range methods {
+		names = append(names, method)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, `, `)
+}
+
+func match(template string, path string) (map[string]string, bool) {
+	var templateSegments = strings.Split(strings.Trim(template, `/`), `/`)
+	var pathSegments = strings.Split(strings.Trim(path, `/`), `/`)
+	var parameters = make(map[string]string)
+
+	if len(templateSegments) != len(pathSegments) {
+		return nil, false
+	}
+
+	for index, segment := range templateSegments {
+		if strings.HasPrefix(segment, `{`) && strings.HasSuffix(segment, `}`) && len(pathSegments[index]) > 0 {
+			parameters[strings.Trim(segment, `{}`)] = pathSegments[index]
+		} else if segment != pathSegments[index] {
+			return nil, false
+		}
+	}
+
+	return parameters, true
+}
@@ -0,0 +1,141 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package server adapts net/http requests into API Gateway proxy events so the Lambda handlers can be served locally
+// without the serverless stack.
+package server
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/router"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/uuid"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	localStage = `local`
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type server struct {
+	router *router.Router
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// New returns an http.Handler which serves every route registered with router
+func New(router *router.Router) http.Handler {
+	return &server{router: router}
+}
+
+func (server *server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	//-- Build the event ----------
+	var event, err = NewEvent(server.router, request)
+	if err != nil {
+		writeResponse(writer, errorResponse(err))
+		return
+	}
+
+	//-- Dispatch ----------
+	if response, err := server.router.Route(request.Context(), event); err != nil {
+		slog.ErrorContext(request.Context(), `unable to handle the request`, slog.String(`method`, request.Method), slog.String(`path`, request.URL.Path), slog.String(`error`, err.Error()))
+		writeResponse(writer, errorResponse(err))
+	} else {
+		writeResponse(writer, response)
+	}
+}
+
+// NewEvent converts request into the proxy event API Gateway would deliver, the resource and path parameters come from
+// the router template matching the request path. A path without a template keeps the raw path as its resource.
+func NewEvent(router *router.Router, request *http.Request) (events.APIGatewayProxyRequest, error) {
+	var event = events.APIGatewayProxyRequest{
+		Resource:   request.URL.Path,
+		Path:       request.URL.Path,
+		HTTPMethod: request.Method,
+		RequestContext: events.APIGatewayProxyRequestContext{
+			RequestID:  uuid.New().String(),
+			Stage:      localStage,
+			HTTPMethod: request.Method,
+			Identity:   events.APIGatewayRequestIdentity{SourceIP: request.RemoteAddr, UserAgent: request.UserAgent()},
+		},
+	}
+
+	//-- Resource & path parameters ----------
+	if resource, parameters, ok := router.Match(request.URL.Path); ok {
+		event.Resource = resource
+		event.PathParameters = parameters
+	}
+
+	//-- Headers ----------
+	event.Headers = make(map[string]string)
+	event.MultiValueHeaders = make(map[string][]string)
+	for name, values := range request.Header {
+		event.Headers[name] = values[len(values)-1]
+		event.MultiValueHeaders[name] = values
+	}
+
+	//-- Query string ----------
+	if query := request.URL.Query(); len(query) > 0 {
+		event.QueryStringParameters = make(map[string]string)
+		event.MultiValueQueryStringParameters = make(map[string][]string)
+		for name, values := range query {
+			event.QueryStringParameters[name] = values[len(values)-1]
+			event.MultiValueQueryStringParameters[name] = values
+		}
+	}
+
+	//-- Body ----------
+	if request.Body != nil {
+		if body, err := ioutil.ReadAll(request.Body); err != nil {
+			return event, err
+		} else if utf8.Valid(body) {
+			event.Body = string(body)
+		} else {
+			event.Body = base64.StdEncoding.EncodeToString(body)
+			event.IsBase64Encoded = true
+		}
+	}
+
+	return event, nil
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+func writeResponse(writer http.ResponseWriter, response events.APIGatewayProxyResponse) {
+	//-- Headers ----------
+	for name, value := range response.Headers {
+		writer.Header().Set(name, value)
+	}
+	for name, values := range response.MultiValueHeaders {
+		writer.Header().Del(name)
+		for _, value := range values {
+			writer.Header().Add(name, value)
+		}
+	}
+
+	//-- Status & body ----------
+	var body = []byte(response.Body)
+	if response.IsBase64Encoded {
+		if decoded, err := base64.StdEncoding.DecodeString(response.Body); err == nil {
+			body = decoded
+		}
+	}
+
+	if response.StatusCode == 0 {
+		response.StatusCode = http.StatusOK
+	}
+
+	writer.WriteHeader(response.StatusCode)
+	if _, err := writer.Write(body); err != nil {
+		slog.Error(`unable to write the response`, slog.String(`error`, err.Error()))
+	}
+}
+
+func errorResponse(err error) events.APIGatewayProxyResponse {
+	var response, _ = responses.APIGatewayProxyError(responses.InternalServerErr(err))
+	return response
+}
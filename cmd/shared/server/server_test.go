@@ -0,0 +1,119 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package server
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/router"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+func newRecordingRouter(captured *events.APIGatewayProxyRequest) *router.Router {
+	var routes = router.New()
+
+	var record = func(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		*captured = event
+
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusTeapot,
+			Headers:    map[string]string{`X-Resource`: event.Resource},
+			Body:       `recorded`,
+		}, nil
+	}
+
+	routes.Handle(http.MethodGet, `/tasks`, record)
+	routes.Handle(http.MethodPut, `/tasks/{id}`, record)
+	routes.Handle(http.MethodPost, `/tasks/batch`, record)
+
+	return routes
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestServerPathParameters(test *testing.T) {
+	//-- Shared Variables ----------
+	var captured events.APIGatewayProxyRequest
+	var recorder *httptest.ResponseRecorder
+
+	//-- Test Parameters ----------
+	var body = `{"name": "Test local server"}`
+
+	//-- Pre-conditions ----------
+	recorder = httptest.NewRecorder()
+
+	//-- Action ----------
+	New(newRecordingRouter(&captured)).ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, `/tasks/12`, strings.NewReader(body)))
+
+	//-- Post-conditions ----------
+	assert.Equal(test, http.StatusTeapot, recorder.Code)
+	assert.Equal(test, `/tasks/{id}`, recorder.Header().Get(`X-Resource`))
+	assert.Equal(test, `recorded`, recorder.Body.String())
+
+	assert.Equal(test, map[string]string{`id`: `12`}, captured.PathParameters)
+	assert.Equal(test, `/tasks/12`, captured.Path)
+	assert.Equal(test, body, captured.Body)
+}
+
+func TestServerLiteralPrecedence(test *testing.T) {
+	//-- Shared Variables ----------
+	var captured events.APIGatewayProxyRequest
+	var recorder *httptest.ResponseRecorder
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	recorder = httptest.NewRecorder()
+
+	//-- Action ----------
+	New(newRecordingRouter(&captured)).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, `/tasks/batch`, nil))
+
+	//-- Post-conditions ----------
+	assert.Equal(test, `/tasks/batch`, captured.Resource)
+	assert.Equal(test, 0, len(captured.PathParameters))
+}
+
+func TestServerQueryString(test *testing.T) {
+	//-- Shared Variables ----------
+	var captured events.APIGatewayProxyRequest
+	var recorder *httptest.ResponseRecorder
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	recorder = httptest.NewRecorder()
+
+	//-- Action ----------
+	New(newRecordingRouter(&captured)).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, `/tasks?limit=5&sort=id&sort=created_at`, nil))
+
+	//-- Post-conditions ----------
+	assert.Equal(test, `5`, captured.QueryStringParameters[`limit`])
+	assert.Equal(test, []string{`id`, `created_at`}, captured.MultiValueQueryStringParameters[`sort`])
+}
+
+func TestServerUnknownRoute(test *testing.T) {
+	//-- Shared Variables ----------
+	var captured events.APIGatewayProxyRequest
+	var recorder *httptest.ResponseRecorder
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	recorder = httptest.NewRecorder()
+
+	//-- Action ----------
+	New(newRecordingRouter(&captured)).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, `/projects/1`, nil))
+
+	//-- Post-conditions ----------
+	assert.Equal(test, http.StatusNotFound, recorder.Code)
+	assert.Equal(test, ``, captured.Resource)
+}
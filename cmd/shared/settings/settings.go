@@ -0,0 +1,92 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package settings reads the configuration every Lambda shares from the environment, the database connection, the
+// verification of bearer tokens and the export of traces. A malformed value is logged and left at its default.
+package settings
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/JustonDavies/go_serverless_api/pkg/tracing"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// Connection reads the store configuration from the environment, malformed limits fall back to the defaults
+func Connection() task.ConnectionParameters {
+	var parameters = task.ConnectionParameters{
+		Driver:  os.Getenv(`DATABASE_DRIVER`),
+		Options: os.Getenv(`DATABASE_CONNECTION_PARAMETERS`),
+	}
+
+	for name, target := range map[string]*int{
+		`DATABASE_MAX_OPEN_CONNECTIONS`: &parameters.MaxOpenConnections,
+		`DATABASE_MAX_IDLE_CONNECTIONS`: &parameters.MaxIdleConnections,
+	} {
+		if value := os.Getenv(name); len(value) == 0 {
+			continue
+		} else if parsed, err := strconv.Atoi(value); err != nil {
+			ignore(name, value, err)
+		} else {
+			*target = parsed
+		}
+	}
+
+	for name, target := range map[string]*time.Duration{
+		`DATABASE_CONNECTION_MAX_LIFETIME`: &parameters.ConnectionMaxLifetime,
+		`DATABASE_HEALTH_CHECK_INTERVAL`:   &parameters.HealthCheckInterval,
+	} {
+		if value := os.Getenv(name); len(value) == 0 {
+			continue
+		} else if parsed, err := time.ParseDuration(value); err != nil {
+			ignore(name, value, err)
+		} else {
+			*target = parsed
+		}
+	}
+
+	return parameters
+}
+
+// Auth reads the token verification settings from the environment. AUTH_JWKS is a file path or URL serving the
+// signing keys, AUTH_ISSUER and AUTH_AUDIENCE are checked when set and AUTH_DISABLED=true allows anonymous requests.
+func Auth() auth.Parameters {
+	var parameters = auth.Parameters{
+		JWKS:     os.Getenv(`AUTH_JWKS`),
+		Issuer:   os.Getenv(`AUTH_ISSUER`),
+		Audience: os.Getenv(`AUTH_AUDIENCE`),
+	}
+
+	if value := os.Getenv(`AUTH_DISABLED`); len(value) == 0 {
+		return parameters
+	} else if disabled, err := strconv.ParseBool(value); err != nil {
+		ignore(`AUTH_DISABLED`, value, err)
+	} else {
+		parameters.Disabled = disabled
+	}
+
+	return parameters
+}
+
+// Trace installs the default tracer of service, TRACE_EXPORT names where spans are written (stdout, stderr or a file
+// path) and leaving it empty disables tracing
+func Trace(service string) {
+	if exporter, err := tracing.NewExporter(os.Getenv(`TRACE_EXPORT`)); err != nil {
+		slog.Warn(`tracing disabled, unable to open the trace export`, slog.String(`setting`, `TRACE_EXPORT`), slog.String(`error`, err.Error()))
+	} else {
+		tracing.SetDefault(tracing.NewTracer(service, exporter))
+	}
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+func ignore(name string, value string, err error) {
+	slog.Warn(`ignoring malformed setting`, slog.String(`setting`, name), slog.String(`value`, value), slog.String(`error`, err.Error()))
+}
@@ -0,0 +1,13 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package main
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"github.com/JustonDavies/go_serverless_api/cmd/task/handlers"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+//-- Main --------------------------------------------------------------------------------------------------------------
+func main() {
+	lambda.Start(handlers.Batch)
+}
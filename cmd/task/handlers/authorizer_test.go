@@ -0,0 +1,108 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/authorizer"
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/JustonDavies/go_serverless_api/pkg/auth/authtest"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+const (
+	testMethodArn = `arn:aws:execute-api:us-west-2:123456789012:abcdef1234/production/GET/tasks`
+)
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+// authorized returns request as API Gateway delivers it behind an authorizer which responded with response
+func authorized(request events.APIGatewayProxyRequest, response events.APIGatewayCustomAuthorizerResponse) events.APIGatewayProxyRequest {
+	request.RequestContext.Authorizer = map[string]interface{}{`principalId`: response.PrincipalID}
+
+	for key, value := range response.Context {
+		request.RequestContext.Authorizer[key] = value
+	}
+
+	return request
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestAuthorizerContext(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+	var output taskOutput
+
+	var response events.APIGatewayCustomAuthorizerResponse
+	var read, unauthorized events.APIGatewayProxyResponse
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var principal = `test authorized owner`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	requireTokens(test, keys)
+
+	subject = task.Task{Name: `Test API authorized task`, OwnerID: principal}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	response = authorizer.Allow(&auth.Principal{Subject: principal}, testMethodArn)
+
+	//-- Action ----------
+	read, _ = Read(ctx, authorized(events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)}, Resource: `fake test resource`}, response))
+	unauthorized, _ = Read(ctx, events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)}, Resource: `fake test resource`})
+
+	//-- Post-conditions ----------
+	if assert.Equal(test, http.StatusOK, read.StatusCode) {
+		if err := decodeTask(read.Body, &output); err != nil {
+			test.Fatalf(`unable to marshal response: %s`, err)
+		} else {
+			assert.Equal(test, subject.ID, output.ID)
+		}
+	}
+
+	assert.Equal(test, http.StatusUnauthorized, unauthorized.StatusCode)
+}
+
+func TestAuthorizerContextScopes(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+
+	var response events.APIGatewayCustomAuthorizerResponse
+	var read, create events.APIGatewayProxyResponse
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var principal = &auth.Principal{Subject: `test authorized key owner`, Scopes: []string{`tasks:read`}}
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	requireTokens(test, keys)
+
+	ctx = context.Background()
+
+	response = authorizer.Allow(principal, testMethodArn)
+
+	//-- Action ----------
+	read, _ = Index(ctx, authorized(events.APIGatewayProxyRequest{QueryStringParameters: map[string]string{`limit`: `10`}, Resource: `fake test resource`}, response))
+	create, _ = Create(ctx, authorized(events.APIGatewayProxyRequest{Headers: documentHeaders(nil), Body: taskDocument(test, ``, CreateRequest{Name: `Test API authorized key task`}), Resource: `fake test resource`}, response))
+
+	//-- Post-conditions ----------
+	assert.Equal(test, http.StatusOK, read.StatusCode)
+	assert.Equal(test, http.StatusForbidden, create.StatusCode)
+}
@@ -0,0 +1,300 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/document"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/pipeline"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+	"github.com/json-iterator/go"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	CreateOperation = `create`
+	UpdateOperation = `update`
+	DeleteOperation = `delete`
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// BatchRequest is the meta member of a batch document, Operation is applied to every resource of its data and Mode
+// defaults to atomic
+type BatchRequest struct {
+	Operation string         `json:"operation"`
+	Mode      task.BatchMode `json:"mode,omitempty"`
+}
+
+// BatchResponse is the meta member of the response to a batch, the data of the response holds the tasks which were
+// stored while Results holds the outcome of every resource of the request at its index
+type BatchResponse struct {
+	Operation string              `json:"operation"`
+	Mode      task.BatchMode      `json:"mode"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Results   []BatchItemResponse `json:"results"`
+}
+
+// BatchItemResponse is the outcome of a single resource of a batch, ID names the task it stored and Error why it failed
+type BatchItemResponse struct {
+	Index  int    `json:"index"`
+	Status int    `json:"status"`
+	ID     string `json:"id,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// batchDocument is a batch sent by a client, the attributes of each resource are decoded once its operation is known.
+// The version an update expects is a member of the meta of its resource as a request carries a single If-Match header.
+type batchDocument struct {
+	Meta *BatchRequest   `json:"meta"`
+	Data []batchResource `json:"data"`
+}
+
+type batchResource struct {
+	Type       string              `json:"type"`
+	ID         string              `json:"id"`
+	Attributes jsoniter.RawMessage `json:"attributes"`
+	Meta       struct {
+		Version uint `json:"version"`
+	} `json:"meta"`
+}
+
+//-- Event Handler -----------------------------------------------------------------------------------------------------
+// Batch creates, updates or deletes up to task.MaxBatchSize tasks in a single transaction. An atomic batch stores every
+// task or none and responds with the error of the resource which failed, a per_item batch responds 200 with the tasks
+// it stored and the outcome of each resource. A resource which can not be decoded fails the whole request.
+func Batch(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	//-- Shared variables ----------
+	var service task.Service
+	var subjectTasks []*task.Task
+
+	var request BatchRequest
+	var result pipeline.Result
+
+	return pipeline.Run(ctx, event, func() interface{} { return result },
+		//-- Authenticate ----------
+		authenticate(event, apikey.WriteTasks),
+
+		//-- Negotiate media type ----------
+		negotiate(event, true),
+
+		//-- Parse event ----------
+		pipeline.Parse(func(ctx context.Context) []*jsonapi.ErrorObject {
+			var batch batchDocument
+
+			if err := json.Unmarshal([]byte(event.Body), &batch); err != nil {
+				return pipeline.Fail(responses.MalformedRequestErr(err))
+			} else if batch.Meta == nil {
+				return pipeline.Fail(responses.MalformedRequestErr(errors.New(`meta - the operation of the batch is required`)))
+			} else if len(batch.Data) == 0 {
+				return pipeline.Fail(responses.MalformedRequestErr(errors.New(`data - a batch requires at least one resource object`)))
+			} else if len(batch.Data) > task.MaxBatchSize {
+				return pipeline.Fail(responses.RequestTooLargeErr(errors.New(fmt.Sprintf(`data - %d resource objects were sent, %s`, len(batch.Data), task.ErrBatchTooLarge))))
+			}
+
+			request = *batch.Meta
+			if len(request.Mode) == 0 {
+				request.Mode = task.Atomic
+			}
+
+			if err := request.Mode.Validate(); err != nil {
+				return pipeline.Fail(responses.MalformedRequestErr(errors.New(fmt.Sprintf(`meta.mode - %s`, err))))
+			} else if request.Operation != CreateOperation && request.Operation != UpdateOperation && request.Operation != DeleteOperation {
+				return pipeline.Fail(responses.MalformedRequestErr(errors.New(fmt.Sprintf(`meta.operation - '%s' is not one of %s, %s or %s`, request.Operation, CreateOperation, UpdateOperation, DeleteOperation))))
+			}
+
+			for index, resource := range batch.Data {
+				if subject, err := parseBatchResource(request.Operation, resource); err != nil {
+					return pipeline.Fail(pointed(fmt.Sprintf(`/data/%d`, index), err))
+				} else {
+					subjectTasks = append(subjectTasks, subject)
+				}
+			}
+
+			return nil
+		}),
+
+		//-- Connect Service ----------
+		connect(&service),
+
+		//-- Action ---------
+		pipeline.Action(func(ctx context.Context) []*jsonapi.ErrorObject {
+			var results []task.BatchResult
+			var err error
+
+			switch request.Operation {
+			case CreateOperation:
+				results, err = service.BulkCreate(withActor(ctx, event), subjectTasks, request.Mode)
+			case UpdateOperation:
+				results, err = service.BulkUpdate(withActor(ctx, event), subjectTasks, request.Mode)
+			default:
+				results, err = service.BulkDelete(withActor(ctx, event), batchIDs(subjectTasks), request.Mode)
+			}
+
+			if err == task.ErrBatchTooLarge {
+				return pipeline.Fail(responses.RequestTooLargeErr(err))
+			} else if err == task.ErrInvalidBatchMode {
+				return pipeline.Fail(responses.MalformedRequestErr(err))
+			} else if err != nil {
+				return pipeline.Fail(responses.InternalServerErr(err))
+			}
+
+			//-- An atomic batch fails as the resource which rolled it back ----------
+			for _, outcome := range results {
+				if request.Mode == task.Atomic && outcome.Err != nil && !errors.Is(outcome.Err, task.ErrBatchAborted) {
+					return pipeline.Fail(pointed(fmt.Sprintf(`/data/%d`, outcome.Index), batchErr(request.Operation, outcome.Err)))
+				}
+			}
+
+			result = newBatchResult(event, request, results)
+			return nil
+		}),
+	)
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// parseBatchResource decodes the task resource of a batch applying operation. A created task may not name an id of its
+// own while an updated or deleted task must, the attributes of a deleted task are ignored.
+func parseBatchResource(operation string, resource batchResource) (*task.Task, *jsonapi.ErrorObject) {
+	var id uint64
+	var create CreateRequest
+	var update UpdateRequest
+
+	//-- Resource identity ----------
+	if resource.Type != TaskType {
+		return nil, responses.ConflictErr(errors.New(fmt.Sprintf(`data.type - '%s' does not match the '%s' this endpoint serves`, resource.Type, TaskType)))
+	} else if operation == CreateOperation && len(resource.ID) > 0 {
+		return nil, responses.Forbidden(errors.New(`data.id - client generated ids are not supported`))
+	} else if operation != CreateOperation && len(resource.ID) == 0 {
+		return nil, responses.MalformedRequestErr(errors.New(`data.id - the id of the task is required`))
+	} else if operation != CreateOperation {
+		if parsed, err := strconv.ParseUint(resource.ID, 10, 64); err != nil {
+			return nil, responses.MalformedRequestErr(errors.New(fmt.Sprintf(`data.id - '%s' is not the id of a task`, resource.ID)))
+		} else {
+			id = parsed
+		}
+	}
+
+	//-- Attributes ----------
+	switch operation {
+	case CreateOperation:
+		if err := decodeAttributes(resource.Attributes, &create); err != nil {
+			return nil, err
+		}
+
+		return &task.Task{
+			ID:         0,
+			Name:       create.Name,
+			Details:    create.Details,
+			ResolvedAt: create.ResolvedAt,
+			OwnerID:    create.OwnerID,
+		}, nil
+	case UpdateOperation:
+		if err := decodeAttributes(resource.Attributes, &update); err != nil {
+			return nil, err
+		}
+
+		return &task.Task{
+			ID:         uint(id),
+			Name:       update.Name,
+			Details:    update.Details,
+			ResolvedAt: update.ResolvedAt,
+			Version:    resource.Meta.Version,
+		}, nil
+	default:
+		return &task.Task{ID: uint(id)}, nil
+	}
+}
+
+// decodeAttributes decodes the attributes of a resource into target, a resource may leave its attributes out
+func decodeAttributes(attributes jsoniter.RawMessage, target interface{}) *jsonapi.ErrorObject {
+	if len(attributes) == 0 {
+		return nil
+	} else if err := json.Unmarshal(attributes, target); err != nil {
+		return responses.MalformedRequestErr(err)
+	}
+
+	return nil
+}
+
+// pointed names the resource of a batch err concerns with a JSON Pointer (RFC 6901) in its meta, the error objects of
+// the jsonapi package have no source member to carry it
+func pointed(pointer string, err *jsonapi.ErrorObject) *jsonapi.ErrorObject {
+	if err.Meta == nil {
+		err.Meta = &map[string]interface{}{}
+	}
+	(*err.Meta)[`pointer`] = pointer
+
+	return err
+}
+
+// batchErr is the error object of a resource of a batch applying operation, classified as the single task handlers
+// classify it
+func batchErr(operation string, err error) *jsonapi.ErrorObject {
+	switch {
+	case errors.Is(err, task.ErrForbidden):
+		return responses.Forbidden(err)
+	case errors.Is(err, task.ErrVersionConflict):
+		return responses.PreconditionFailedErr(err)
+	case errors.Is(err, sql.ErrNoRows):
+		return responses.NotFound(err)
+	case operation == DeleteOperation:
+		return responses.InternalServerErr(err)
+	default:
+		return responses.UnprocessableEntryErr(err)
+	}
+}
+
+// batchIDs lists the ids of subjects in order
+func batchIDs(subjects []*task.Task) []uint {
+	var ids = make([]uint, 0, len(subjects))
+
+	for _, subject := range subjects {
+		ids = append(ids, subject.ID)
+	}
+
+	return ids
+}
+
+// newBatchResult responds with the document of every task a batch stored, in the order of the request, and reports the
+// outcome of each resource in its meta
+func newBatchResult(event events.APIGatewayProxyRequest, request BatchRequest, results []task.BatchResult) pipeline.Result {
+	var resources = make([]*document.Resource, 0, len(results))
+	var response = BatchResponse{Operation: request.Operation, Mode: request.Mode, Results: make([]BatchItemResponse, 0, len(results))}
+
+	var stored = http.StatusOK
+	if request.Operation == CreateOperation {
+		stored = http.StatusCreated
+	}
+
+	for _, outcome := range results {
+		if outcome.Err != nil {
+			var failure = batchErr(request.Operation, outcome.Err)
+			var status, _ = strconv.Atoi(failure.Status)
+
+			response.Results = append(response.Results, BatchItemResponse{Index: outcome.Index, Status: status, Title: failure.Title, Error: outcome.Err.Error()})
+			response.Failed++
+		} else {
+			resources = append(resources, newTaskResource(event, outcome.Task))
+
+			response.Results = append(response.Results, BatchItemResponse{Index: outcome.Index, Status: stored, ID: strconv.FormatUint(uint64(outcome.Task.ID), 10)})
+			response.Succeeded++
+		}
+	}
+
+	return pipeline.Result{
+		Headers: map[string]string{`Content-Type`: document.MediaType},
+		Body:    &document.Document{Data: resources, Meta: &response},
+	}
+}
@@ -0,0 +1,274 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+// batchOutput is the response to a batch decoded for assertions
+type batchOutput struct {
+	Tasks []taskOutput
+	Meta  BatchResponse
+}
+
+func decodeBatch(body string, output *batchOutput) error {
+	var decoded struct {
+		Data []resourceOutput `json:"data"`
+		Meta BatchResponse    `json:"meta"`
+	}
+
+	if err := json.Unmarshal([]byte(body), &decoded); err != nil {
+		return err
+	}
+
+	*output = batchOutput{Tasks: make([]taskOutput, len(decoded.Data)), Meta: decoded.Meta}
+
+	for index, resource := range decoded.Data {
+		if err := decodeResource(resource, &output.Tasks[index]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// batchStatuses lists the status of each result of output in order
+func batchStatuses(output batchOutput) []int {
+	var statuses = make([]int, 0, len(output.Meta.Results))
+
+	for _, result := range output.Meta.Results {
+		statuses = append(statuses, result.Status)
+	}
+
+	return statuses
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestBatchCreateTasks(test *testing.T) {
+	//-- Shared Variables ----------
+	var output batchOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var name = `Test API batch create`
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		Headers: documentHeaders(nil),
+		Body: `{
+			"meta": {"operation": "create", "mode": "per_item"},
+			"data": [
+				{"type": "tasks", "attributes": {"name": "` + name + ` 0"}},
+				{"type": "tasks", "attributes": {"name": "~~~"}},
+				{"type": "tasks", "attributes": {"name": "` + name + ` 2", "details": "Testing details of a batch"}}
+			]
+		}`,
+		Resource: `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Batch(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if err := decodeBatch(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.Equal(test, 2, len(output.Tasks)) {
+		assert.Equal(test, []int{http.StatusCreated, http.StatusUnprocessableEntity, http.StatusCreated}, batchStatuses(output))
+		assert.Equal(test, 2, output.Meta.Succeeded)
+		assert.Equal(test, 1, output.Meta.Failed)
+		assert.Equal(test, task.PerItem, output.Meta.Mode)
+		assert.Equal(test, fmt.Sprintf(`%d`, output.Tasks[1].ID), output.Meta.Results[2].ID)
+		assert.Equal(test, name+` 2`, output.Tasks[1].Name)
+		assert.Equal(test, uint(1), output.Tasks[1].Version)
+		assert.NotEmpty(test, output.Meta.Results[1].Error)
+	}
+}
+
+func TestBatchUpdateTasksAtomic(test *testing.T) {
+	//-- Shared Variables ----------
+	var payload jsonapi.ErrorsPayload
+	var output taskOutput
+
+	var request events.APIGatewayProxyRequest
+	var response, readResponse events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var first, second task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API atomic batch update`
+
+	//-- Pre-conditions ----------
+	first = task.Task{Name: name}
+	second = task.Task{Name: name}
+	insertTask(test, &first)
+	insertTask(test, &second)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		Headers: documentHeaders(nil),
+		Body: fmt.Sprintf(`{
+			"meta": {"operation": "update"},
+			"data": [
+				{"type": "tasks", "id": "%d", "attributes": {"name": "Test API atomic batch renamed"}},
+				{"type": "tasks", "id": "%d", "attributes": {"name": "Test API atomic batch renamed"}, "meta": {"version": 7}}
+			]
+		}`, first.ID, second.ID),
+		Resource: `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Batch(ctx, request)
+	readResponse, _ = Read(ctx, events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, first.ID)}, Resource: `fake test resource`})
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusPreconditionFailed, response.StatusCode)
+
+	if err := json.Unmarshal([]byte(response.Body), &payload); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.Equal(test, 1, len(payload.Errors)) && assert.NotNil(test, payload.Errors[0].Meta) {
+		assert.Equal(test, `/data/1`, (*payload.Errors[0].Meta)[`pointer`])
+	}
+
+	if err := decodeTask(readResponse.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, name, output.Name)
+		assert.Equal(test, uint(1), output.Version)
+	}
+}
+
+func TestBatchDeleteTasks(test *testing.T) {
+	//-- Shared Variables ----------
+	var output batchOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API batch delete`
+
+	//-- Pre-conditions ----------
+	subject = task.Task{Name: name}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		Headers:  documentHeaders(nil),
+		Body:     fmt.Sprintf(`{"meta": {"operation": "delete", "mode": "per_item"}, "data": [{"type": "tasks", "id": "%d"}, {"type": "tasks", "id": "%d"}]}`, subject.ID, subject.ID+1000),
+		Resource: `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Batch(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if err := decodeBatch(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.Equal(test, 1, len(output.Tasks)) {
+		assert.Equal(test, []int{http.StatusOK, http.StatusNotFound}, batchStatuses(output))
+		assert.Equal(test, subject.ID, output.Tasks[0].ID)
+		assert.NotNil(test, output.Tasks[0].DeletedAt)
+	}
+}
+
+func TestBatchTasksInvalid(test *testing.T) {
+	//-- Shared Variables ----------
+	var statuses = make(map[string]int)
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var oversized = strings.Repeat(`{"type": "tasks", "id": "1"},`, task.MaxBatchSize)
+	var expected = map[string]int{
+		`not a document`:    http.StatusUnsupportedMediaType,
+		`malformed`:         http.StatusBadRequest,
+		`missing meta`:      http.StatusBadRequest,
+		`empty`:             http.StatusBadRequest,
+		`too large`:         http.StatusRequestEntityTooLarge,
+		`unknown operation`: http.StatusBadRequest,
+		`unknown mode`:      http.StatusBadRequest,
+		`wrong type`:        http.StatusConflict,
+		`client id`:         http.StatusForbidden,
+		`missing id`:        http.StatusBadRequest,
+		`invalid id`:        http.StatusBadRequest,
+		`not found`:         http.StatusNotFound,
+	}
+	var cases = map[string]struct {
+		headers map[string]string
+		body    string
+	}{
+		`not a document`:    {map[string]string{`Content-Type`: `application/json`}, `{"meta": {"operation": "delete"}, "data": [{"type": "tasks", "id": "1"}]}`},
+		`malformed`:         {documentHeaders(nil), `{"meta": `},
+		`missing meta`:      {documentHeaders(nil), `{"data": [{"type": "tasks", "id": "1"}]}`},
+		`empty`:             {documentHeaders(nil), `{"meta": {"operation": "delete"}, "data": []}`},
+		`too large`:         {documentHeaders(nil), `{"meta": {"operation": "delete"}, "data": [` + oversized + `{"type": "tasks", "id": "1"}]}`},
+		`unknown operation`: {documentHeaders(nil), `{"meta": {"operation": "restore"}, "data": [{"type": "tasks", "id": "1"}]}`},
+		`unknown mode`:      {documentHeaders(nil), `{"meta": {"operation": "delete", "mode": "best_effort"}, "data": [{"type": "tasks", "id": "1"}]}`},
+		`wrong type`:        {documentHeaders(nil), `{"meta": {"operation": "delete"}, "data": [{"type": "keys", "id": "1"}]}`},
+		`client id`:         {documentHeaders(nil), `{"meta": {"operation": "create"}, "data": [{"type": "tasks", "id": "1", "attributes": {"name": "Test API batch id"}}]}`},
+		`missing id`:        {documentHeaders(nil), `{"meta": {"operation": "update"}, "data": [{"type": "tasks", "attributes": {"name": "Test API batch id"}}]}`},
+		`invalid id`:        {documentHeaders(nil), `{"meta": {"operation": "delete"}, "data": [{"type": "tasks", "id": "one"}]}`},
+		`not found`:         {documentHeaders(nil), `{"meta": {"operation": "delete", "mode": "atomic"}, "data": [{"type": "tasks", "id": "4294967295"}]}`},
+	}
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	//-- Action ----------
+	for label, parameters := range cases {
+		if response, err := Batch(ctx, events.APIGatewayProxyRequest{
+			Headers:  parameters.headers,
+			Body:     parameters.body,
+			Resource: `fake test resource`,
+		}); err != nil {
+			test.Fatalf(`unexpected error from handler: %s`, err)
+		} else {
+			statuses[label] = response.StatusCode
+		}
+	}
+
+	//-- Post-conditions ----------
+	assert.Equal(test, expected, statuses)
+}
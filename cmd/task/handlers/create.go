@@ -0,0 +1,88 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/document"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/pipeline"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// CreateRequest holds the attributes of the new task, OwnerID names its owner. It defaults to the caller and only an admin
+// may name another.
+type CreateRequest struct {
+	Name       string     `json:"name"`
+	Details    *string    `json:"details,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	OwnerID    string     `json:"owner_id,omitempty"`
+}
+
+//-- Event Handler -----------------------------------------------------------------------------------------------------
+// Create responds 201 with the document of the new task and its Location, the document may not name an id of its own
+func Create(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	//-- Shared variables ----------
+	var service task.Service
+	var subjectTask *task.Task
+
+	var request *CreateRequest
+	var result pipeline.Result
+
+	return pipeline.Run(ctx, event, func() interface{} { return result },
+		//-- Authenticate ----------
+		authenticate(event, apikey.WriteTasks),
+
+		//-- Negotiate media type ----------
+		negotiate(event, true),
+
+		//-- Parse event ----------
+		pipeline.Parse(func(ctx context.Context) []*jsonapi.ErrorObject {
+			request = &CreateRequest{}
+
+			if id, err := document.Decode(event.Body, TaskType, request); err != nil {
+				return pipeline.Fail(err)
+			} else if len(id) > 0 {
+				return pipeline.Fail(responses.Forbidden(errors.New(`data.id - client generated ids are not supported`)))
+			}
+
+			return nil
+		}),
+
+		//-- Connect Service ----------
+		connect(&service),
+
+		//-- Action ---------
+		pipeline.Action(func(ctx context.Context) []*jsonapi.ErrorObject {
+			subjectTask = &task.Task{
+				ID:         0,
+				Name:       request.Name,
+				Details:    request.Details,
+				ResolvedAt: request.ResolvedAt,
+				OwnerID:    request.OwnerID,
+			}
+
+			if err := service.Create(withActor(ctx, event), subjectTask); err == task.ErrForbidden {
+				return pipeline.Fail(responses.Forbidden(err))
+			} else if err != nil {
+				return pipeline.Fail(responses.UnprocessableEntryErr(err))
+			}
+
+			result = newTaskResult(event, subjectTask)
+			result.Status = http.StatusCreated
+			result.Headers[`Location`] = taskLink(event, subjectTask)
+
+			return nil
+		}),
+	)
+}
@@ -0,0 +1,181 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/document"
+	"github.com/JustonDavies/go_serverless_api/pkg/auth/authtest"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestCreateTask(test *testing.T) {
+	//-- Shared Variables ----------
+	var input CreateRequest
+	var output taskOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var name = `Test API create task`
+	var details = `Testing creating a task through the event handler API`
+	var resolvedAt = time.Now()
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	input = CreateRequest{
+		Name:       name,
+		Details:    &details,
+		ResolvedAt: &resolvedAt,
+	}
+
+	request = events.APIGatewayProxyRequest{Headers: documentHeaders(nil), Body: taskDocument(test, ``, input), Resource: `fake test resource`}
+
+	//-- Action ----------
+	response, eventErr = Create(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusCreated, response.StatusCode)
+	assert.Equal(test, document.MediaType, response.Headers[`Content-Type`])
+
+	if err := decodeTask(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.NotEqual(test, uint(0), output.ID)
+		assert.Equal(test, fmt.Sprintf(`/tasks/%d`, output.ID), response.Headers[`Location`])
+		assert.Equal(test, response.Headers[`Location`], output.Links[`self`])
+		assert.NotEqual(test, time.Time{}, output.CreatedAt)
+		assert.Nil(test, output.UpdatedAt)
+	}
+}
+
+func TestCreateTaskInvalid(test *testing.T) {
+	//-- Shared Variables ----------
+	var input CreateRequest
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var name = `Test API create invalid task ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~`
+	var details = `Testing creating am invalid task through the event handler API`
+	var resolvedAt = time.Now()
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	input = CreateRequest{
+		Name:       name,
+		Details:    &details,
+		ResolvedAt: &resolvedAt,
+	}
+
+	request = events.APIGatewayProxyRequest{Headers: documentHeaders(nil), Body: taskDocument(test, ``, input), Resource: `fake test resource`}
+
+	//-- Action ----------
+	response, eventErr = Create(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusUnprocessableEntity, response.StatusCode)
+}
+
+func TestCreateTaskOwnership(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+	var output taskOutput
+
+	var own, other, admin events.APIGatewayProxyResponse
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var ownBody = `{"data": {"type": "tasks", "attributes": {"name": "Test API create own task"}}}`
+	var otherBody = `{"data": {"type": "tasks", "attributes": {"name": "Test API create other task", "owner_id": "test other"}}}`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	requireTokens(test, keys)
+
+	ctx = context.Background()
+
+	//-- Action ----------
+	own, _ = Create(ctx, events.APIGatewayProxyRequest{Headers: documentHeaders(bearer(test, keys, `test owner`)), Body: ownBody, Resource: `fake test resource`})
+	other, _ = Create(ctx, events.APIGatewayProxyRequest{Headers: documentHeaders(bearer(test, keys, `test owner`)), Body: otherBody, Resource: `fake test resource`})
+	admin, _ = Create(ctx, events.APIGatewayProxyRequest{Headers: documentHeaders(bearer(test, keys, `test admin`, string(task.AdminRole))), Body: otherBody, Resource: `fake test resource`})
+
+	//-- Post-conditions ----------
+	assert.Equal(test, http.StatusCreated, own.StatusCode)
+	assert.Equal(test, http.StatusForbidden, other.StatusCode)
+	assert.Equal(test, http.StatusCreated, admin.StatusCode)
+
+	if err := decodeTask(own.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, `test owner`, output.OwnerID)
+	}
+
+	if err := decodeTask(admin.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, `test other`, output.OwnerID)
+	}
+}
+
+func TestCreateTaskDocument(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var attributes = `{"name": "Test API create task document"}`
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	//-- Action ----------
+
+	//-- Post-conditions ----------
+	for name, parameters := range map[string]struct {
+		headers map[string]string
+		body    string
+		status  int
+	}{
+		`plain json`:        {map[string]string{`Content-Type`: `application/json`}, `{"name": "Test API create plain task"}`, http.StatusUnsupportedMediaType},
+		`media parameters`:  {map[string]string{`Content-Type`: document.MediaType + `; charset=utf-8`}, `{"data": {"type": "tasks", "attributes": ` + attributes + `}}`, http.StatusUnsupportedMediaType},
+		`not acceptable`:    {documentHeaders(map[string]string{`Accept`: `text/html`}), `{"data": {"type": "tasks", "attributes": ` + attributes + `}}`, http.StatusNotAcceptable},
+		`missing data`:      {documentHeaders(nil), attributes, http.StatusBadRequest},
+		`wrong type`:        {documentHeaders(nil), `{"data": {"type": "keys", "attributes": ` + attributes + `}}`, http.StatusConflict},
+		`client identifier`: {documentHeaders(nil), `{"data": {"type": "tasks", "id": "7", "attributes": ` + attributes + `}}`, http.StatusForbidden},
+	} {
+		var response, eventErr = Create(ctx, events.APIGatewayProxyRequest{Headers: parameters.headers, Body: parameters.body, Resource: `fake test resource`})
+
+		assert.Nil(test, eventErr, name)
+		assert.Equal(test, parameters.status, response.StatusCode, name)
+		assert.Equal(test, document.MediaType, response.Headers[`Content-Type`], name)
+	}
+}
@@ -0,0 +1,62 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/document"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/pipeline"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+
+//-- Event Handler -----------------------------------------------------------------------------------------------------
+func Delete(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	//-- Shared variables ----------
+	var subjectID uint
+	var service task.Service
+
+	var result pipeline.Result
+
+	return pipeline.Run(ctx, event, func() interface{} { return result },
+		//-- Authenticate ----------
+		authenticate(event, apikey.WriteTasks),
+
+		//-- Negotiate media type ----------
+		negotiate(event, false),
+
+		//-- Parse event ----------
+		pipeline.Parse(func(ctx context.Context) []*jsonapi.ErrorObject {
+			if err := parseID(event, &subjectID); err != nil {
+				return pipeline.Fail(err)
+			}
+
+			return nil
+		}),
+
+		//-- Connect Service ----------
+		connect(&service),
+
+		//-- Action ---------
+		pipeline.Action(func(ctx context.Context) []*jsonapi.ErrorObject {
+			if subject, err := service.Delete(withActor(ctx, event), subjectID); err != nil {
+				return pipeline.Fail(responses.NotFound(err))
+			} else {
+				result = pipeline.Result{
+					Headers: map[string]string{`Content-Type`: document.MediaType},
+					Body:    &document.Document{Data: newTaskResource(event, subject)},
+				}
+			}
+
+			return nil
+		}),
+	)
+}
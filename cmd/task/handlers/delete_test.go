@@ -1,12 +1,11 @@
 //-- Package Declaration -----------------------------------------------------------------------------------------------
-package main
+package handlers
 
 //-- Imports -----------------------------------------------------------------------------------------------------------
 import (
 	"context"
 	"fmt"
 	"net/http"
-	"os"
 	"testing"
 	"time"
 
@@ -20,34 +19,11 @@ import (
 //-- Decorators --------------------------------------------------------------------------------------------------------
 
 //-- Helpers -----------------------------------------------------------------------------------------------------------
-func insertTask(test *testing.T, input *task.Task) {
-	var ctx context.Context
-	var store task.Store
-	var service task.Service
-
-	ctx = context.Background()
-
-	store = task.NewPostgresStore()
-	if err := store.Open(os.Getenv(`DATABASE_CONNECTION_PARAMETERS`)); err != nil {
-		test.Fatalf(`an unexpected error occured while opening the database: %s`, err)
-	}
-
-	service = task.NewService(nil, store)
-	defer func() {
-		if err := service.Shutdown(); err != nil {
-			test.Fatalf(`an unrecoverable error has occured while tring to shutdown the service: %s`, err)
-		}
-	}()
-
-	if err := service.Create(ctx, input); err != nil {
-		test.Fatalf(`an unexpected error occured while opening the database: %s`, err)
-	}
-}
 
 //-- Tests -------------------------------------------------------------------------------------------------------------
 func TestDeleteTask(test *testing.T) {
 	//-- Shared Variables ----------
-	var output Response
+	var output taskOutput
 
 	var request events.APIGatewayProxyRequest
 	var response events.APIGatewayProxyResponse
@@ -76,13 +52,13 @@ func TestDeleteTask(test *testing.T) {
 	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)}, Resource: `fake test resource`}
 
 	//-- Action ----------
-	response, eventErr = Handler(ctx, request)
+	response, eventErr = Delete(ctx, request)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, eventErr)
 	assert.Equal(test, http.StatusOK, response.StatusCode)
 
-	if err := json.Unmarshal([]byte(response.Body), &output); err != nil {
+	if err := decodeTask(response.Body, &output); err != nil {
 		test.Fatalf(`unable to marshal response: %s`, err)
 	} else {
 		assert.Equal(test, subject.ID, output.ID)
@@ -91,6 +67,7 @@ func TestDeleteTask(test *testing.T) {
 		assert.Equal(test, subject.ResolvedAt.Unix(), output.ResolvedAt.Unix())
 		assert.Equal(test, subject.CreatedAt.Unix(), output.CreatedAt.Unix())
 		assert.Equal(test, subject.UpdatedAt, output.UpdatedAt) //It hasn't been updated yet so these should be nil
+		assert.NotNil(test, output.DeletedAt)
 	}
 }
 
@@ -111,7 +88,7 @@ func TestDeleteTaskNotFound(test *testing.T) {
 	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, 0)}, Resource: `fake test resource`}
 
 	//-- Action ----------
-	response, eventErr = Handler(ctx, request)
+	response, eventErr = Delete(ctx, request)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, eventErr)
@@ -0,0 +1,340 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package handlers holds the API Gateway handlers for every task route. Each handler can be started as its own Lambda
+// or registered with a router by NewRouter to serve every route from one Lambda.
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/authorizer"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/credentials"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/document"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/logger"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/parameters"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/pipeline"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/router"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/settings"
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/JustonDavies/go_serverless_api/pkg/tracing"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+	"github.com/json-iterator/go"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// serviceName identifies this service in traces
+	serviceName = `task-service`
+
+	// TaskType is the JSON:API type of every task resource
+	TaskType = `tasks`
+
+	TasksResource = `/tasks`
+	TaskResource  = `/tasks/{id}`
+
+	// TaskBatchResource ends in a literal segment, which routers prefer over the id parameter of TaskResource
+	TaskBatchResource = `/tasks/batch`
+
+	TaskRestoreResource = `/tasks/{id}/restore`
+	TaskHistoryResource = `/tasks/{id}/history`
+	TaskResolveResource = `/tasks/{id}/resolve`
+	TaskReopenResource  = `/tasks/{id}/reopen`
+
+	KeysResource = `/keys`
+	KeyResource  = `/keys/{id}`
+
+	// TenantHeader names the tenant of a request, it selects the tenant when authentication is disabled and otherwise
+	// must agree with the tenant of the token
+	TenantHeader = `X-Tenant-ID`
+)
+
+var (
+	json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+	// lifecycle is shared by every handler in the process so warm invocations reuse one connection pool
+	lifecycle = task.NewLifecycle(settings.Connection(), []task.Middleware{
+		task.NewTraceMiddleware(),
+		task.NewMetricsMiddleware(task.NewEMFSink(os.Stdout, os.Getenv(`METRICS_NAMESPACE`))),
+		task.NewLogMiddleware(logger.NewLogger()),
+	})
+
+	// keyring verifies and manages API keys, it borrows the connection pool of the task store held by lifecycle
+	keyring = apikey.NewLifecycle(lifecycle)
+
+	// verifier checks the credential of every request the authorizer did not already verify
+	verifier = credentials.NewVerifier(settings.Auth(), keyring)
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// TaskResponse holds the attributes of a task resource, its id is a member of the resource object rather than of them
+type TaskResponse struct {
+	Name       string     `json:"name"`
+	Details    *string    `json:"details,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	OwnerID    string     `json:"owner_id,omitempty"`
+
+	CreatedAt time.Time  `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	Version   uint       `json:"version"`
+}
+
+// TaskRecord is a task in a flat format such as CSV, its id is a field alongside the attributes of TaskResponse
+type TaskRecord struct {
+	ID uint `json:"id"`
+	TaskResponse
+}
+
+// KeyResponse describes an API key, Key holds the secret key only in the response which created it
+type KeyResponse struct {
+	ID      uint     `json:"id"`
+	Name    string   `json:"name"`
+	Prefix  string   `json:"prefix"`
+	Scopes  []string `json:"scopes"`
+	OwnerID string   `json:"owner_id,omitempty"`
+	Key     string   `json:"key,omitempty"`
+
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// init installs the default tracer before any handler runs
+func init() {
+	settings.Trace(serviceName)
+}
+
+// NewRouter registers every task route, the migration and purge handlers are not HTTP routes and are left out
+func NewRouter() *router.Router {
+	var routes = router.New()
+
+	routes.Handle(http.MethodPost, TasksResource, Create)
+	routes.Handle(http.MethodGet, TasksResource, Index)
+	routes.Handle(http.MethodPost, TaskBatchResource, Batch)
+	routes.Handle(http.MethodGet, TaskResource, Read)
+	routes.Handle(http.MethodPut, TaskResource, Update)
+	routes.Handle(http.MethodPatch, TaskResource, Patch)
+	routes.Handle(http.MethodDelete, TaskResource, Delete)
+	routes.Handle(http.MethodPost, TaskRestoreResource, Restore)
+	routes.Handle(http.MethodGet, TaskHistoryResource, History)
+	routes.Handle(http.MethodPost, TaskResolveResource, Resolve)
+	routes.Handle(http.MethodPost, TaskReopenResource, Reopen)
+	routes.Handle(http.MethodPost, KeysResource, CreateKey)
+	routes.Handle(http.MethodGet, KeysResource, IndexKeys)
+	routes.Handle(http.MethodDelete, KeyResource, RevokeKey)
+
+	return routes
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// connect fetches the process-wide task service into service, the store behind it stays open between invocations
+func connect(service *task.Service) pipeline.Stage {
+	return pipeline.Connect(func(ctx context.Context) []*jsonapi.ErrorObject {
+		if result, err := lifecycle.Service(ctx); err != nil {
+			return pipeline.Fail(responses.InternalServerErr(err))
+		} else {
+			*service = result
+		}
+
+		return nil
+	})
+}
+
+// negotiate checks the request accepts a JSON:API document in response and, when body is true, sends one
+func negotiate(event events.APIGatewayProxyRequest, body bool) pipeline.Stage {
+	return pipeline.Negotiate(func(ctx context.Context) []*jsonapi.ErrorObject {
+		if err := document.Negotiate(event, body); err != nil {
+			return pipeline.Fail(err)
+		}
+
+		return nil
+	})
+}
+
+// connectKeys fetches the process-wide API key service into service
+func connectKeys(service *apikey.Service) pipeline.Stage {
+	return pipeline.Connect(func(ctx context.Context) []*jsonapi.ErrorObject {
+		if result, err := keyring.Service(ctx); err != nil {
+			return pipeline.Fail(responses.InternalServerErr(err))
+		} else {
+			*service = result
+		}
+
+		return nil
+	})
+}
+
+// authenticate identifies the caller of event and carries the resulting principal and tenant through the context of
+// every later stage. A missing or invalid credential responds 401 and a principal not permitted scope responds 403.
+func authenticate(event events.APIGatewayProxyRequest, scope apikey.Scope) pipeline.Stage {
+	return pipeline.Authenticate(func(ctx context.Context) (context.Context, []*jsonapi.ErrorObject) {
+		if principal, err := identify(ctx, event); errors.Is(err, auth.ErrUnauthenticated) {
+			return ctx, pipeline.Fail(responses.Unauthorized(err))
+		} else if err != nil {
+			return ctx, pipeline.Fail(responses.InternalServerErr(err))
+		} else if principal != nil && !principal.Permits(string(scope)) {
+			return ctx, pipeline.Fail(responses.Forbidden(errors.New(fmt.Sprintf(`the credential does not grant the %s scope`, scope))))
+		} else if principal != nil {
+			tracing.FromContext(ctx).SetAttribute(`enduser.id`, principal.Subject)
+			ctx = auth.WithPrincipal(ctx, principal)
+		}
+
+		return resolveTenant(ctx, event)
+	})
+}
+
+// identify returns the principal an authorizer in front of the handler already verified, otherwise it verifies the
+// credential in the headers of event
+func identify(ctx context.Context, event events.APIGatewayProxyRequest) (*auth.Principal, error) {
+	if principal, err := authorizer.Principal(event); err != nil || principal != nil {
+		return principal, err
+	}
+
+	return verifier.Headers(ctx, event.Headers)
+}
+
+// resolveTenant confines ctx to the tenant of the request. The tenant of a token is authoritative and a TenantHeader
+// naming another tenant responds 403, without a token the header selects the tenant. Either falls back to the default
+// tenant.
+func resolveTenant(ctx context.Context, event events.APIGatewayProxyRequest) (context.Context, []*jsonapi.ErrorObject) {
+	var tenant, named = parameters.Header(event, TenantHeader)
+
+	if err := task.ValidateTenant(tenant); named && err != nil {
+		return ctx, pipeline.Fail(responses.MalformedRequestErr(err))
+	} else if named && auth.PrincipalFrom(ctx) == nil {
+		ctx = task.WithTenant(ctx, tenant)
+	} else if named && tenant != task.TenantFrom(ctx) {
+		return ctx, pipeline.Fail(responses.Forbidden(errors.New(fmt.Sprintf(`the token does not grant access to tenant '%s'`, tenant))))
+	}
+
+	tracing.FromContext(ctx).SetAttribute(`tenant.id`, task.TenantFrom(ctx))
+
+	return ctx, nil
+}
+
+// withActor records who made event against the changes made through the returned context. A verified bearer token
+// already names the actor, otherwise the principal of an authorizer is preferred over the caller's IAM identity.
+func withActor(ctx context.Context, event events.APIGatewayProxyRequest) context.Context {
+	if auth.PrincipalFrom(ctx) != nil {
+		return ctx
+	} else if principal, ok := event.RequestContext.Authorizer[`principalId`].(string); ok && len(principal) > 0 {
+		return task.WithActor(ctx, principal)
+	} else if len(event.RequestContext.Identity.UserArn) > 0 {
+		return task.WithActor(ctx, event.RequestContext.Identity.UserArn)
+	}
+
+	return ctx
+}
+
+func parseID(event events.APIGatewayProxyRequest, id *uint) *jsonapi.ErrorObject {
+	if parsed, err := strconv.ParseUint(event.PathParameters[`id`], 10, 64); err != nil {
+		return responses.BadPathParameterErr(err)
+	} else {
+		*id = uint(parsed)
+	}
+
+	return nil
+}
+
+// parseIfMatch reads the version a conditional update expects from the If-Match header of event. A missing header or *
+// leaves version at zero, which updates unconditionally. Weak or unknown entity tags can never match a task and fail
+// the precondition, lists of tags are not supported.
+func parseIfMatch(event events.APIGatewayProxyRequest, version *uint) *jsonapi.ErrorObject {
+	var value, ok = parameters.Header(event, `If-Match`)
+	value = strings.TrimSpace(value)
+
+	if !ok || value == `*` {
+		*version = 0
+	} else if strings.Contains(value, `,`) {
+		return responses.MalformedRequestErr(errors.New(`If-Match may only contain a single entity tag`))
+	} else if strings.HasPrefix(value, `W/`) {
+		return responses.PreconditionFailedErr(errors.New(`weak entity tags never match for an update`))
+	} else if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return responses.MalformedRequestErr(errors.New(fmt.Sprintf(`'%s' is not a quoted entity tag`, value)))
+	} else if parsed, err := strconv.ParseUint(value[1:len(value)-1], 10, 64); err != nil || parsed == 0 {
+		return responses.PreconditionFailedErr(errors.New(fmt.Sprintf(`%s does not match the task`, value)))
+	} else {
+		*version = uint(parsed)
+	}
+
+	return nil
+}
+
+// entityTag is the strong ETag of subject, it changes whenever the task is updated
+func entityTag(subject *task.Task) string {
+	return fmt.Sprintf(`"%d"`, subject.Version)
+}
+
+// newTaskResult responds with the document of subject and its ETag so clients can make their next update conditional
+// on it
+func newTaskResult(event events.APIGatewayProxyRequest, subject *task.Task) pipeline.Result {
+	return pipeline.Result{
+		Headers: map[string]string{`Content-Type`: document.MediaType, `ETag`: entityTag(subject)},
+		Body:    &document.Document{Data: newTaskResource(event, subject)},
+	}
+}
+
+// newTaskResource is the resource object of subject, linked to the route which reads it
+func newTaskResource(event events.APIGatewayProxyRequest, subject *task.Task) *document.Resource {
+	return &document.Resource{
+		Type:       TaskType,
+		ID:         strconv.FormatUint(uint64(subject.ID), 10),
+		Attributes: newTaskResponse(subject),
+		Links:      &document.Links{Self: taskLink(event, subject)},
+	}
+}
+
+// taskLink is the URL of the route which reads subject
+func taskLink(event events.APIGatewayProxyRequest, subject *task.Task) string {
+	return document.Link(event, strings.Replace(TaskResource, `{id}`, strconv.FormatUint(uint64(subject.ID), 10), 1))
+}
+
+func newTaskResponse(subject *task.Task) *TaskResponse {
+	return &TaskResponse{
+		Name:       subject.Name,
+		Details:    subject.Details,
+		ResolvedAt: subject.ResolvedAt,
+		OwnerID:    subject.OwnerID,
+		CreatedAt:  subject.CreatedAt,
+		UpdatedAt:  subject.UpdatedAt,
+		DeletedAt:  subject.DeletedAt,
+		Version:    subject.Version,
+	}
+}
+
+func newTaskRecord(subject *task.Task) *TaskRecord {
+	return &TaskRecord{ID: subject.ID, TaskResponse: *newTaskResponse(subject)}
+}
+
+func newKeyResponse(subject *apikey.Key) *KeyResponse {
+	var response = &KeyResponse{
+		ID:         subject.ID,
+		Name:       subject.Name,
+		Prefix:     subject.Prefix,
+		Scopes:     make([]string, 0, len(subject.Scopes)),
+		OwnerID:    subject.OwnerID,
+		ExpiresAt:  subject.ExpiresAt,
+		CreatedAt:  subject.CreatedAt,
+		LastUsedAt: subject.LastUsedAt,
+		RevokedAt:  subject.RevokedAt,
+	}
+
+	for _, scope := range subject.Scopes {
+		response.Scopes = append(response.Scopes, string(scope))
+	}
+
+	return response
+}
@@ -0,0 +1,563 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/credentials"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/document"
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/JustonDavies/go_serverless_api/pkg/auth/authtest"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+// TestMain lets requests through anonymously, the authentication tests install a verifier of their own
+func TestMain(main *testing.M) {
+	verifier = credentials.NewVerifier(auth.Parameters{Disabled: true}, keyring)
+	os.Exit(main.Run())
+}
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+func insertTask(test *testing.T, input *task.Task) {
+	var ctx = context.Background()
+
+	if service, err := lifecycle.Service(ctx); err != nil {
+		test.Fatalf(`an unexpected error occured while opening the database: %s`, err)
+	} else if err := service.Create(ctx, input); err != nil {
+		test.Fatalf(`an unexpected error occured while inserting a task: %s`, err)
+	}
+}
+
+func deleteTasks(test *testing.T) {
+	var ctx = task.AcrossTenants(context.Background())
+
+	if service, err := lifecycle.Service(ctx); err != nil {
+		test.Fatalf(`an unexpected error occured while opening the database: %s`, err)
+	} else if tasks, err := service.List(ctx, task.Query{}, 1000000, 0); err != nil {
+		test.Fatalf(`an unexpected error occured while fetching all tasks in the database: %s`, err)
+	} else {
+		for _, item := range tasks {
+			if _, err := service.Delete(ctx, item.ID); err != nil {
+				test.Fatalf(`an unexpected error occured while deleting all tasks the database: %s`, err)
+			}
+		}
+	}
+}
+
+// requireTokens verifies bearer tokens signed by keys until test finishes
+func requireTokens(test *testing.T, keys *authtest.Keys) {
+	var previous = verifier
+
+	verifier = credentials.NewVerifier(auth.Parameters{JWKS: keys.Path, Issuer: authtest.Issuer, Audience: authtest.Audience}, keyring)
+	if err := verifier.Err(); err != nil {
+		test.Fatalf(`unable to create the authenticator: %s`, err)
+	}
+
+	test.Cleanup(func() { verifier = previous })
+}
+
+// bearer returns the headers of a request authenticated as subject holding roles, with a token signed by keys
+func bearer(test *testing.T, keys *authtest.Keys, subject string, roles ...string) map[string]string {
+	var claims = authtest.Valid(subject)
+	claims[`roles`] = roles
+
+	return map[string]string{`Authorization`: `Bearer ` + keys.HS256(test, claims)}
+}
+
+// taskOutput is a task resource decoded for assertions, its id is parsed alongside its attributes
+type taskOutput struct {
+	TaskResponse
+
+	ID    uint
+	Links map[string]string
+}
+
+// indexOutput is a collection of tasks decoded for assertions, Next and Previous hold the cursors of its page links
+type indexOutput struct {
+	Tasks    []taskOutput
+	Next     string
+	Previous string
+	Links    map[string]string
+	Meta     map[string]interface{}
+}
+
+type resourceOutput struct {
+	Type       string            `json:"type"`
+	ID         string            `json:"id"`
+	Attributes TaskResponse      `json:"attributes"`
+	Links      map[string]string `json:"links"`
+}
+
+// taskDocument encodes attributes as the document of a task resource, id is left out when it is empty
+func taskDocument(test *testing.T, id string, attributes interface{}) string {
+	var body, err = json.Marshal(document.Document{Data: document.Resource{Type: TaskType, ID: id, Attributes: attributes}})
+	if err != nil {
+		test.Fatalf(`unable to marshal request: %s`, err)
+	}
+
+	return string(body)
+}
+
+// documentHeaders adds the headers of a request which sends and accepts a JSON:API document to headers
+func documentHeaders(headers map[string]string) map[string]string {
+	var merged = map[string]string{`Content-Type`: document.MediaType, `Accept`: document.MediaType}
+
+	for name, value := range headers {
+		merged[name] = value
+	}
+
+	return merged
+}
+
+func decodeTask(body string, output *taskOutput) error {
+	var decoded struct {
+		Data resourceOutput `json:"data"`
+	}
+
+	if err := json.Unmarshal([]byte(body), &decoded); err != nil {
+		return err
+	} else if decoded.Data.Type != TaskType {
+		return errors.New(fmt.Sprintf(`unexpected resource type '%s'`, decoded.Data.Type))
+	}
+
+	return decodeResource(decoded.Data, output)
+}
+
+func decodeTasks(body string, output *indexOutput) error {
+	var decoded struct {
+		Data  []resourceOutput       `json:"data"`
+		Links map[string]string      `json:"links"`
+		Meta  map[string]interface{} `json:"meta"`
+	}
+
+	if err := json.Unmarshal([]byte(body), &decoded); err != nil {
+		return err
+	}
+
+	*output = indexOutput{Tasks: make([]taskOutput, len(decoded.Data)), Links: decoded.Links, Meta: decoded.Meta}
+
+	for index, resource := range decoded.Data {
+		if err := decodeResource(resource, &output.Tasks[index]); err != nil {
+			return err
+		}
+	}
+
+	for name, target := range map[string]*string{`next`: &output.Next, `prev`: &output.Previous} {
+		if link, ok := decoded.Links[name]; !ok {
+			continue
+		} else if parsed, err := url.Parse(link); err != nil {
+			return err
+		} else {
+			*target = parsed.Query().Get(`cursor`)
+		}
+	}
+
+	return nil
+}
+
+func decodeResource(resource resourceOutput, output *taskOutput) error {
+	if id, err := strconv.ParseUint(resource.ID, 10, 64); err != nil {
+		return err
+	} else {
+		*output = taskOutput{TaskResponse: resource.Attributes, ID: uint(id), Links: resource.Links}
+	}
+
+	return nil
+}
+
+// serverlessRoutes reads the method and path of every http event declared in serverless.yml
+func serverlessRoutes(test *testing.T) [][2]string {
+	var routes [][2]string
+	var path string
+
+	var content, err = ioutil.ReadFile(`../../../serverless.yml`)
+	if err != nil {
+		test.Fatalf(`unable to read serverless.yml: %s`, err)
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		var trimmed = strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, `path:`) {
+			path = `/` + strings.TrimSpace(strings.TrimPrefix(trimmed, `path:`))
+		} else if strings.HasPrefix(trimmed, `method:`) && len(path) > 0 {
+			routes = append(routes, [2]string{strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(trimmed, `method:`))), path})
+			path = ``
+		}
+	}
+
+	return routes
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestRouterMatchesServerless(test *testing.T) {
+	//-- Shared Variables ----------
+	var routes [][2]string
+	var resources []string
+
+	//-- Test Parameters ----------
+	var router = NewRouter()
+
+	//-- Pre-conditions ----------
+	routes = serverlessRoutes(test)
+
+	//-- Action ----------
+	for _, route := range routes {
+		var resource, _, _ = router.Match(route[1])
+		resources = append(resources, resource)
+	}
+
+	//-- Post-conditions ----------
+	if assert.NotEqual(test, 0, len(routes)) {
+		for index, route := range routes {
+			assert.Equal(test, route[1], resources[index])
+		}
+	}
+}
+
+func TestRouterDispatch(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		HTTPMethod:            http.MethodGet,
+		Resource:              TasksResource,
+		QueryStringParameters: map[string]string{`limit`: `ten`},
+	}
+
+	//-- Action ----------
+	response, eventErr = NewRouter().Route(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestRouterMethodNotAllowed(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{HTTPMethod: http.MethodPost, Resource: TaskResource}
+
+	//-- Action ----------
+	response, eventErr = NewRouter().Route(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusMethodNotAllowed, response.StatusCode)
+	assert.Equal(test, `DELETE, GET, PATCH, PUT`, response.Headers[`Allow`])
+}
+
+func TestRouterNotFound(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet, Resource: `/projects`}
+
+	//-- Action ----------
+	response, eventErr = NewRouter().Route(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusNotFound, response.StatusCode)
+}
+
+func TestRouterWarmup(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{}
+
+	//-- Action ----------
+	response, eventErr = NewRouter().Route(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+}
+
+func TestAuthenticateMissingToken(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	requireTokens(test, authtest.NewKeys(test))
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{Resource: `fake test resource`}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusUnauthorized, response.StatusCode)
+	assert.Equal(test, `Bearer`, response.Headers[`WWW-Authenticate`])
+}
+
+func TestAuthenticateExpiredToken(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var claims = authtest.Valid(`test subject`)
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	requireTokens(test, keys)
+
+	ctx = context.Background()
+	claims[`exp`] = time.Now().Add(-time.Hour).Unix()
+
+	request = events.APIGatewayProxyRequest{
+		Headers:  map[string]string{`Authorization`: `Bearer ` + keys.RS256(test, claims)},
+		Resource: `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusUnauthorized, response.StatusCode)
+}
+
+func TestAuthenticateValidToken(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+	var output HistoryResponse
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API authenticated task`
+	var principal = `test subject`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	requireTokens(test, keys)
+
+	subject = task.Task{Name: name, OwnerID: principal}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		Headers:        map[string]string{`authorization`: `Bearer ` + keys.HS256(test, authtest.Valid(principal))},
+		PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)},
+		Resource:       `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Delete(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if response, err := History(ctx, request); err != nil {
+		test.Fatalf(`unable to fetch the history: %s`, err)
+	} else if err := json.Unmarshal([]byte(response.Body), &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.Len(test, output.History, 2) {
+		assert.Equal(test, principal, output.History[1].Actor)
+	}
+}
+
+func TestAuthenticateNotConfigured(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	var previous = verifier
+	verifier = credentials.NewVerifier(auth.Parameters{}, keyring)
+	defer func() { verifier = previous }()
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{Resource: `fake test resource`}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusInternalServerError, response.StatusCode)
+}
+
+func TestResolveTenantHeader(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var created, own, other, malformed events.APIGatewayProxyResponse
+
+	var ctx context.Context
+
+	var output taskOutput
+
+	//-- Test Parameters ----------
+	var body = `{"data": {"type": "tasks", "attributes": {"name": "Test API tenant task"}}}`
+
+	//-- Pre-conditions ----------
+	defer deleteTasks(test)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{Body: body, Headers: documentHeaders(map[string]string{TenantHeader: `tenant-a`}), Resource: `fake test resource`}
+	created, _ = Create(ctx, request)
+
+	if err := decodeTask(created.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	}
+
+	//-- Action ----------
+	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, output.ID)}, Resource: `fake test resource`}
+
+	request.Headers = map[string]string{TenantHeader: `tenant-a`}
+	own, _ = Read(ctx, request)
+
+	request.Headers = map[string]string{TenantHeader: `tenant-b`}
+	other, _ = Read(ctx, request)
+
+	request.Headers = map[string]string{TenantHeader: `tenant b`}
+	malformed, _ = Read(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, http.StatusCreated, created.StatusCode)
+	assert.Equal(test, http.StatusOK, own.StatusCode)
+	assert.Equal(test, http.StatusNotFound, other.StatusCode)
+	assert.Equal(test, http.StatusBadRequest, malformed.StatusCode)
+}
+
+func TestResolveTenantToken(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+
+	var request events.APIGatewayProxyRequest
+	var own, named, mismatched, elsewhere events.APIGatewayProxyResponse
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var claims = authtest.Valid(`test admin`)
+	claims[`roles`] = []string{string(task.AdminRole)}
+	claims[`tenant_id`] = `tenant-a`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	requireTokens(test, keys)
+	defer deleteTasks(test)
+
+	ctx = context.Background()
+
+	subject = task.Task{Name: `Test API tenant token`}
+	insertTask(test, &subject)
+
+	request = events.APIGatewayProxyRequest{QueryStringParameters: map[string]string{`limit`: `10`}, Resource: `fake test resource`}
+
+	//-- Action ----------
+	request.Headers = map[string]string{`Authorization`: `Bearer ` + keys.HS256(test, claims)}
+	own, _ = Index(ctx, request)
+
+	request.Headers[TenantHeader] = `tenant-a`
+	named, _ = Index(ctx, request)
+
+	request.Headers[TenantHeader] = task.DefaultTenant
+	mismatched, _ = Index(ctx, request)
+
+	request.Headers = map[string]string{`Authorization`: `Bearer ` + keys.HS256(test, claims)}
+	request.PathParameters = map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)}
+	elsewhere, _ = Read(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, http.StatusOK, own.StatusCode)
+	assert.Equal(test, http.StatusOK, named.StatusCode)
+	assert.Equal(test, http.StatusForbidden, mismatched.StatusCode)
+	assert.Equal(test, http.StatusNotFound, elsewhere.StatusCode)
+}
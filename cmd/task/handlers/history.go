@@ -0,0 +1,99 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/parameters"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/pipeline"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	defaultHistoryLimit = 100
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type HistoryEntryResponse struct {
+	ID      uint          `json:"id"`
+	TaskID  uint          `json:"task_id"`
+	Action  task.Action   `json:"action"`
+	Actor   string        `json:"actor"`
+	Version uint          `json:"version"`
+	Changes []task.Change `json:"changes"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type HistoryResponse struct {
+	History []HistoryEntryResponse `json:"history"`
+	Next    string                 `json:"next,omitempty"`
+}
+
+//-- Event Handler -----------------------------------------------------------------------------------------------------
+func History(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	//-- Shared variables ----------
+	var subjectID uint
+	var limit uint = defaultHistoryLimit
+	var cursor string
+	var service task.Service
+
+	var response *HistoryResponse
+
+	return pipeline.Run(ctx, event, func() interface{} { return response },
+		//-- Authenticate ----------
+		authenticate(event, apikey.ReadTasks),
+
+		//-- Parse event ----------
+		pipeline.Parse(func(ctx context.Context) []*jsonapi.ErrorObject {
+			if err := parseID(event, &subjectID); err != nil {
+				return pipeline.Fail(err)
+			}
+
+			var parser = parameters.NewParser(event)
+
+			parser.Uint(`limit`, 0, maximumLimit, &limit)
+			parser.String(`cursor`, maximumCursor, &cursor)
+
+			return parser.Errors()
+		}),
+
+		//-- Connect Service ----------
+		connect(&service),
+
+		//-- Action ---------
+		pipeline.Action(func(ctx context.Context) []*jsonapi.ErrorObject {
+			if page, err := service.History(ctx, subjectID, limit, cursor); err == task.ErrInvalidCursor {
+				return pipeline.Fail(responses.MalformedRequestErr(err))
+			} else if err != nil {
+				return pipeline.Fail(responses.InternalServerErr(err))
+			} else {
+				response = &HistoryResponse{
+					History: make([]HistoryEntryResponse, 0, len(page.Entries)),
+					Next:    page.Next,
+				}
+
+				for _, entry := range page.Entries {
+					response.History = append(response.History, HistoryEntryResponse{
+						ID:        entry.ID,
+						TaskID:    entry.TaskID,
+						Action:    entry.Action,
+						Actor:     entry.Actor,
+						Version:   entry.Version,
+						Changes:   entry.Changes,
+						CreatedAt: entry.CreatedAt,
+					})
+				}
+			}
+
+			return nil
+		}),
+	)
+}
@@ -0,0 +1,159 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestHistoryTask(test *testing.T) {
+	//-- Shared Variables ----------
+	var output HistoryResponse
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API history task`
+	var principal = `test principal`
+
+	//-- Pre-conditions ----------
+	subject = task.Task{Name: name}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)}, Resource: `fake test resource`}
+	request.RequestContext.Authorizer = map[string]interface{}{`principalId`: principal}
+
+	if _, err := Delete(ctx, request); err != nil {
+		test.Fatalf(`unable to delete task: %s`, err)
+	}
+
+	//-- Action ----------
+	response, eventErr = History(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if err := json.Unmarshal([]byte(response.Body), &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.Len(test, output.History, 2) {
+		assert.Equal(test, task.Created, output.History[0].Action)
+		assert.Equal(test, subject.ID, output.History[0].TaskID)
+
+		assert.Equal(test, task.Deleted, output.History[1].Action)
+		assert.Equal(test, principal, output.History[1].Actor)
+		assert.Empty(test, output.Next)
+
+		if assert.Len(test, output.History[1].Changes, 1) {
+			assert.Equal(test, `deleted_at`, output.History[1].Changes[0].Field)
+		}
+	}
+}
+
+func TestHistoryTaskPaging(test *testing.T) {
+	//-- Shared Variables ----------
+	var first, second HistoryResponse
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API history paging task`
+
+	//-- Pre-conditions ----------
+	subject = task.Task{Name: name}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)}, Resource: `fake test resource`}
+
+	if _, err := Delete(ctx, request); err != nil {
+		test.Fatalf(`unable to delete task: %s`, err)
+	} else if _, err := Restore(ctx, request); err != nil {
+		test.Fatalf(`unable to restore task: %s`, err)
+	}
+
+	//-- Action ----------
+	request.QueryStringParameters = map[string]string{`limit`: `2`}
+	response, _ = History(ctx, request)
+
+	if err := json.Unmarshal([]byte(response.Body), &first); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	}
+
+	request.QueryStringParameters = map[string]string{`limit`: `2`, `cursor`: first.Next}
+	response, _ = History(ctx, request)
+
+	if err := json.Unmarshal([]byte(response.Body), &second); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	}
+
+	//-- Post-conditions ----------
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if assert.Len(test, first.History, 2) && assert.Len(test, second.History, 1) {
+		assert.Equal(test, task.Created, first.History[0].Action)
+		assert.Equal(test, task.Deleted, first.History[1].Action)
+		assert.NotEmpty(test, first.Next)
+
+		assert.Equal(test, task.Restored, second.History[0].Action)
+		assert.Empty(test, second.Next)
+	}
+}
+
+func TestHistoryTaskInvalidCursor(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var cursor = `not a cursor`
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		PathParameters:        map[string]string{`id`: `1`},
+		QueryStringParameters: map[string]string{`cursor`: cursor},
+		Resource:              `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = History(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusBadRequest, response.StatusCode)
+}
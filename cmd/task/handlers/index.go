@@ -0,0 +1,316 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/document"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/formats"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/parameters"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/pipeline"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	maximumLimit   = 1000
+	maximumOffset  = math.MaxInt32
+	maximumCursor  = 512
+	maximumName    = 50
+	maximumSort    = 16
+	maximumDeleted = 16
+	maximumOwner   = 255
+
+	// recordElement is the XML element of each task in a collection, the collection itself is named by TaskType
+	recordElement = `task`
+)
+
+var (
+	// indexFormats are the media types Index responds in, a request which accepts any of them gets the first
+	indexFormats = []string{document.MediaType, formats.JSON, formats.CSV, formats.NDJSON, formats.XML}
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type IndexRequest struct {
+	Limit  uint   `json:"limit"`
+	Offset uint   `json:"offset"`
+	Cursor string `json:"cursor,omitempty"`
+
+	Resolved     *bool      `json:"resolved,omitempty"`
+	Name         string     `json:"name,omitempty"`
+	Owner        string     `json:"owner,omitempty"`
+	CreatedFrom  *time.Time `json:"created_from,omitempty"`
+	CreatedTo    *time.Time `json:"created_to,omitempty"`
+	UpdatedFrom  *time.Time `json:"updated_from,omitempty"`
+	UpdatedTo    *time.Time `json:"updated_to,omitempty"`
+	ResolvedFrom *time.Time `json:"resolved_from,omitempty"`
+	ResolvedTo   *time.Time `json:"resolved_to,omitempty"`
+	Deleted      string     `json:"deleted,omitempty"`
+
+	Sort  string `json:"sort,omitempty"`
+	Order string `json:"order,omitempty"`
+}
+
+// IndexMeta is the meta of a collection document, Offset is only sent for a page requested by offset
+type IndexMeta struct {
+	Limit  uint `json:"limit"`
+	Offset uint `json:"offset,omitempty"`
+	Count  int  `json:"count"`
+}
+
+//-- Event Handler -----------------------------------------------------------------------------------------------------
+// Index responds with a collection document of tasks. Its links hold the page itself along with the next and prev pages
+// when they exist, and its meta the limit, offset and count of the page. A request which accepts JSON, CSV, NDJSON or
+// XML rather than a document receives the tasks as flat records in that format, with the links in a Link header.
+func Index(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	//-- Shared variables ----------
+	var service task.Service
+
+	var request *IndexRequest
+	var query task.Query
+	var format string
+	var result pipeline.Result
+
+	return pipeline.Run(ctx, event, func() interface{} { return result },
+		//-- Authenticate ----------
+		authenticate(event, apikey.ReadTasks),
+
+		//-- Negotiate media type ----------
+		pipeline.Negotiate(func(ctx context.Context) []*jsonapi.ErrorObject {
+			var accept, _ = parameters.Header(event, `Accept`)
+
+			if chosen, ok := formats.Negotiate(accept, indexFormats...); !ok {
+				return pipeline.Fail(responses.NotAcceptableErr(errors.New(fmt.Sprintf(`Accept - '%s' allows none of %s`, accept, strings.Join(indexFormats, `, `)))))
+			} else {
+				format = chosen
+			}
+
+			return nil
+		}),
+
+		//-- Parse event ----------
+		pipeline.Parse(func(ctx context.Context) []*jsonapi.ErrorObject {
+			request = &IndexRequest{}
+
+			//-- Body parameters are deprecated as many clients, proxies and caches drop GET bodies ----------
+			if len(event.Body) > 0 {
+				slog.WarnContext(ctx, `deprecated body parameters detected, the query string should be used instead`)
+
+				if err := json.Unmarshal([]byte(event.Body), request); err != nil {
+					return pipeline.Fail(responses.MalformedRequestErr(err))
+				}
+			}
+
+			//-- Query string parameters take precedence ----------
+			var parser = parameters.NewParser(event)
+
+			if len(event.Body) == 0 && !parser.Has(`limit`) {
+				return pipeline.Fail(responses.BadQueryParameterErr(errors.New(`limit - parameter is required`)))
+			}
+
+			parser.Uint(`limit`, 0, maximumLimit, &request.Limit)
+			parser.Uint(`offset`, 0, maximumOffset, &request.Offset)
+			parser.String(`cursor`, maximumCursor, &request.Cursor)
+
+			parser.Bool(`resolved`, &request.Resolved)
+			parser.String(`name`, maximumName, &request.Name)
+			parser.String(`owner`, maximumOwner, &request.Owner)
+			parser.Time(`created_from`, &request.CreatedFrom)
+			parser.Time(`created_to`, &request.CreatedTo)
+			parser.Time(`updated_from`, &request.UpdatedFrom)
+			parser.Time(`updated_to`, &request.UpdatedTo)
+			parser.Time(`resolved_from`, &request.ResolvedFrom)
+			parser.Time(`resolved_to`, &request.ResolvedTo)
+			parser.String(`deleted`, maximumDeleted, &request.Deleted)
+
+			parser.String(`sort`, maximumSort, &request.Sort)
+			parser.String(`order`, maximumSort, &request.Order)
+
+			if errs := parser.Errors(); len(errs) > 0 {
+				return errs
+			}
+
+			if len(request.Cursor) > 0 && request.Offset > 0 {
+				return pipeline.Fail(responses.MalformedRequestErr(errors.New(`cursor and offset may not be combined`)))
+			}
+
+			query = task.Query{
+				Filter: task.Filter{
+					Resolved:   request.Resolved,
+					Name:       request.Name,
+					Owner:      request.Owner,
+					Deleted:    task.Deletion(request.Deleted),
+					CreatedAt:  task.TimeRange{From: request.CreatedFrom, To: request.CreatedTo},
+					UpdatedAt:  task.TimeRange{From: request.UpdatedFrom, To: request.UpdatedTo},
+					ResolvedAt: task.TimeRange{From: request.ResolvedFrom, To: request.ResolvedTo},
+				},
+				Sort: task.Sort{
+					Field:     task.SortField(request.Sort),
+					Direction: task.SortDirection(request.Order),
+				},
+			}
+
+			if err := query.Validate(); err != nil {
+				return pipeline.Fail(responses.MalformedRequestErr(err))
+			}
+
+			return nil
+		}),
+
+		//-- Connect Service ----------
+		connect(&service),
+
+		//-- Action ---------
+		pipeline.Action(func(ctx context.Context) []*jsonapi.ErrorObject {
+			var tasks []task.Task
+			var links = document.Links{Self: request.link(event, nil)}
+			var meta = IndexMeta{Limit: request.Limit}
+
+			if request.Offset > 0 {
+				if page, err := service.List(ctx, query, request.Limit, request.Offset); err == task.ErrForbidden {
+					return pipeline.Fail(responses.Forbidden(err))
+				} else if err != nil {
+					return pipeline.Fail(responses.NotFound(err))
+				} else {
+					tasks = page
+					meta.Offset = request.Offset
+
+					if request.Limit > 0 && uint(len(page)) == request.Limit {
+						links.Next = request.link(event, map[string]string{`offset`: strconv.FormatUint(uint64(request.Offset+request.Limit), 10)})
+					}
+					if request.Offset > request.Limit {
+						links.Previous = request.link(event, map[string]string{`offset`: strconv.FormatUint(uint64(request.Offset-request.Limit), 10)})
+					} else {
+						links.Previous = request.link(event, map[string]string{`offset`: ``})
+					}
+				}
+			} else {
+				if page, err := service.Paginate(ctx, query, request.Limit, request.Cursor); err == task.ErrInvalidCursor {
+					return pipeline.Fail(responses.MalformedRequestErr(err))
+				} else if err == task.ErrForbidden {
+					return pipeline.Fail(responses.Forbidden(err))
+				} else if err != nil {
+					return pipeline.Fail(responses.NotFound(err))
+				} else {
+					tasks = page.Tasks
+
+					if len(page.Next) > 0 {
+						links.Next = request.link(event, map[string]string{`cursor`: page.Next})
+					}
+					if len(page.Previous) > 0 {
+						links.Previous = request.link(event, map[string]string{`cursor`: page.Previous})
+					}
+				}
+			}
+
+			if encoder, ok := formats.Lookup(format); ok {
+				var records = make([]*TaskRecord, 0, len(tasks))
+				for index := range tasks {
+					records = append(records, newTaskRecord(&tasks[index]))
+				}
+
+				result = pipeline.Result{
+					Headers: map[string]string{`Content-Type`: format, `Vary`: `Accept`},
+					Body:    formats.Collection{Name: TaskType, Item: recordElement, Records: records},
+					Encode:  encoder,
+				}
+
+				if header := linkHeader(links); len(header) > 0 {
+					result.Headers[`Link`] = header
+				}
+
+				return nil
+			}
+
+			var data = make([]*document.Resource, 0, len(tasks))
+			for index := range tasks {
+				data = append(data, newTaskResource(event, &tasks[index]))
+			}
+			meta.Count = len(data)
+
+			result = pipeline.Result{
+				Headers: map[string]string{`Content-Type`: document.MediaType, `Vary`: `Accept`},
+				Body:    &document.Document{Data: data, Links: &links, Meta: meta},
+			}
+
+			return nil
+		}),
+	)
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// linkHeader is the Link header (RFC 8288) of the next and prev pages in links, which a flat format has no room for
+func linkHeader(links document.Links) string {
+	var values []string
+
+	for _, link := range []struct{ relation, target string }{{`next`, links.Next}, {`prev`, links.Previous}} {
+		if len(link.target) > 0 {
+			values = append(values, fmt.Sprintf(`<%s>; rel="%s"`, link.target, link.relation))
+		}
+	}
+
+	return strings.Join(values, `, `)
+}
+
+// link is the URL of the page request asks for with the parameters in changes replaced, an empty change removes the
+// parameter. Parameters sent in a deprecated body are carried into the query string.
+func (request IndexRequest) link(event events.APIGatewayProxyRequest, changes map[string]string) string {
+	var values = url.Values{}
+
+	for name, value := range map[string]string{
+		`limit`:   strconv.FormatUint(uint64(request.Limit), 10),
+		`cursor`:  request.Cursor,
+		`name`:    request.Name,
+		`owner`:   request.Owner,
+		`deleted`: request.Deleted,
+		`sort`:    request.Sort,
+		`order`:   request.Order,
+	} {
+		if len(value) > 0 {
+			values.Set(name, value)
+		}
+	}
+
+	if request.Offset > 0 {
+		values.Set(`offset`, strconv.FormatUint(uint64(request.Offset), 10))
+	}
+	if request.Resolved != nil {
+		values.Set(`resolved`, strconv.FormatBool(*request.Resolved))
+	}
+
+	for name, value := range map[string]*time.Time{
+		`created_from`:  request.CreatedFrom,
+		`created_to`:    request.CreatedTo,
+		`updated_from`:  request.UpdatedFrom,
+		`updated_to`:    request.UpdatedTo,
+		`resolved_from`: request.ResolvedFrom,
+		`resolved_to`:   request.ResolvedTo,
+	} {
+		if value != nil {
+			values.Set(name, value.Format(time.RFC3339Nano))
+		}
+	}
+
+	for name, value := range changes {
+		values.Del(name)
+
+		if len(value) > 0 {
+			values.Set(name, value)
+		}
+	}
+
+	return document.Link(event, TasksResource) + `?` + values.Encode()
+}
@@ -0,0 +1,1043 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"fmt"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/document"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/formats"
+	"github.com/JustonDavies/go_serverless_api/pkg/auth/authtest"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestIndexTask(test *testing.T) {
+	//-- Shared Variables ----------
+	var input IndexRequest
+	var output indexOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var name = `Test API list task`
+	var quantity = 10
+
+	var limit uint = 25
+	var offset uint = 0
+
+	//-- Pre-conditions ----------
+	deleteTasks(test)
+	for i := 0; i < quantity; i++ {
+		var item = task.Task{}
+		item.Name = fmt.Sprintf(`%s %d`, name, i)
+
+		insertTask(test, &item)
+	}
+
+	ctx = context.Background()
+
+	input = IndexRequest{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if result, err := json.Marshal(input); err != nil {
+		test.Fatalf(`unable to marshal request: %s`, err)
+	} else {
+		request = events.APIGatewayProxyRequest{Body: string(result), Resource: `fake test resource`}
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if err := decodeTasks(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, quantity, len(output.Tasks))
+	}
+}
+
+func TestIndexTaskLimit(test *testing.T) {
+	//-- Shared Variables ----------
+	var input IndexRequest
+	var output indexOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var name = `Test API limited list task`
+	var quantity = 10
+
+	var limit uint = 5
+	var offset uint = 0
+
+	//-- Pre-conditions ----------
+	deleteTasks(test)
+	for i := 0; i < quantity; i++ {
+		var item = task.Task{}
+		item.Name = fmt.Sprintf(`%s %d`, name, i)
+
+		insertTask(test, &item)
+	}
+
+	ctx = context.Background()
+
+	input = IndexRequest{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if result, err := json.Marshal(input); err != nil {
+		test.Fatalf(`unable to marshal request: %s`, err)
+	} else {
+		request = events.APIGatewayProxyRequest{Body: string(result), Resource: `fake test resource`}
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if err := decodeTasks(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, int(limit), len(output.Tasks))
+	}
+}
+
+func TestIndexTaskZeroLimit(test *testing.T) {
+	//-- Shared Variables ----------
+	var input IndexRequest
+	var output indexOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var name = `Test API zero-limited list task`
+	var quantity = 10
+
+	var limit uint = 0
+	var offset uint = 0
+
+	//-- Pre-conditions ----------
+	deleteTasks(test)
+	for i := 0; i < quantity; i++ {
+		var item = task.Task{}
+		item.Name = fmt.Sprintf(`%s %d`, name, i)
+
+		insertTask(test, &item)
+	}
+
+	ctx = context.Background()
+
+	input = IndexRequest{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if result, err := json.Marshal(input); err != nil {
+		test.Fatalf(`unable to marshal request: %s`, err)
+	} else {
+		request = events.APIGatewayProxyRequest{Body: string(result), Resource: `fake test resource`}
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if err := decodeTasks(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, int(limit), len(output.Tasks))
+	}
+}
+
+func TestIndexTaskOverLimit(test *testing.T) {
+	//-- Shared Variables ----------
+	var input IndexRequest
+	var output indexOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var name = `Test API zero-limited list task`
+	var quantity = 10
+
+	var limit uint = 50
+	var offset uint = 0
+
+	//-- Pre-conditions ----------
+	deleteTasks(test)
+	for i := 0; i < quantity; i++ {
+		var item = task.Task{}
+		item.Name = fmt.Sprintf(`%s %d`, name, i)
+
+		insertTask(test, &item)
+	}
+
+	ctx = context.Background()
+
+	input = IndexRequest{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if result, err := json.Marshal(input); err != nil {
+		test.Fatalf(`unable to marshal request: %s`, err)
+	} else {
+		request = events.APIGatewayProxyRequest{Body: string(result), Resource: `fake test resource`}
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if err := decodeTasks(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, int(quantity), len(output.Tasks))
+	}
+}
+
+func TestIndexTaskOffset(test *testing.T) {
+	//-- Shared Variables ----------
+	var input IndexRequest
+	var output indexOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var name = `Test API zero-limited list task`
+	var quantity = 10
+
+	var limit uint = 10
+	var offset uint = 5
+
+	//-- Pre-conditions ----------
+	deleteTasks(test)
+	for i := 0; i < quantity; i++ {
+		var item = task.Task{}
+		item.Name = fmt.Sprintf(`%s %d`, name, i)
+
+		insertTask(test, &item)
+	}
+
+	ctx = context.Background()
+
+	input = IndexRequest{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if result, err := json.Marshal(input); err != nil {
+		test.Fatalf(`unable to marshal request: %s`, err)
+	} else {
+		request = events.APIGatewayProxyRequest{Body: string(result), Resource: `fake test resource`}
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if err := decodeTasks(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, 5, len(output.Tasks))
+	}
+}
+
+func TestIndexTaskOverOffset(test *testing.T) {
+	//-- Shared Variables ----------
+	var input IndexRequest
+	var output indexOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var name = `Test API zero-limited list task`
+	var quantity = 10
+
+	var limit uint = 10
+	var offset uint = 10
+
+	//-- Pre-conditions ----------
+	deleteTasks(test)
+	for i := 0; i < quantity; i++ {
+		var item = task.Task{}
+		item.Name = fmt.Sprintf(`%s %d`, name, i)
+
+		insertTask(test, &item)
+	}
+
+	ctx = context.Background()
+
+	input = IndexRequest{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if result, err := json.Marshal(input); err != nil {
+		test.Fatalf(`unable to marshal request: %s`, err)
+	} else {
+		request = events.APIGatewayProxyRequest{Body: string(result), Resource: `fake test resource`}
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if err := decodeTasks(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, 0, len(output.Tasks))
+	}
+}
+
+func TestIndexTaskCursor(test *testing.T) {
+	//-- Shared Variables ----------
+	var input IndexRequest
+	var first, second indexOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var name = `Test API cursor list task`
+	var quantity = 10
+
+	var limit uint = 6
+
+	//-- Pre-conditions ----------
+	deleteTasks(test)
+	for i := 0; i < quantity; i++ {
+		var item = task.Task{}
+		item.Name = fmt.Sprintf(`%s %d`, name, i)
+
+		insertTask(test, &item)
+	}
+
+	ctx = context.Background()
+
+	input = IndexRequest{
+		Limit: limit,
+	}
+
+	if result, err := json.Marshal(input); err != nil {
+		test.Fatalf(`unable to marshal request: %s`, err)
+	} else if response, err := Index(ctx, events.APIGatewayProxyRequest{Body: string(result), Resource: `fake test resource`}); err != nil {
+		test.Fatalf(`unable to fetch the first page: %s`, err)
+	} else if err := decodeTasks(response.Body, &first); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	}
+
+	input = IndexRequest{
+		Limit:  limit,
+		Cursor: first.Next,
+	}
+
+	if result, err := json.Marshal(input); err != nil {
+		test.Fatalf(`unable to marshal request: %s`, err)
+	} else {
+		request = events.APIGatewayProxyRequest{Body: string(result), Resource: `fake test resource`}
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+	assert.Equal(test, 6, len(first.Tasks))
+	assert.NotEqual(test, ``, first.Next)
+
+	if err := decodeTasks(response.Body, &second); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, 4, len(second.Tasks))
+		assert.Equal(test, ``, second.Next)
+		assert.NotEqual(test, ``, second.Previous)
+	}
+}
+
+func TestIndexTaskCursorWithOffset(test *testing.T) {
+	//-- Shared Variables ----------
+	var input IndexRequest
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var limit uint = 10
+	var offset uint = 5
+	var cursor = task.Cursor{ID: 1}.Encode()
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	input = IndexRequest{
+		Limit:  limit,
+		Offset: offset,
+		Cursor: cursor,
+	}
+
+	if result, err := json.Marshal(input); err != nil {
+		test.Fatalf(`unable to marshal request: %s`, err)
+	} else {
+		request = events.APIGatewayProxyRequest{Body: string(result), Resource: `fake test resource`}
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestIndexTaskInvalidSort(test *testing.T) {
+	//-- Shared Variables ----------
+	var input IndexRequest
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var limit uint = 10
+	var sort = `details`
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	input = IndexRequest{
+		Limit: limit,
+		Sort:  sort,
+	}
+
+	if result, err := json.Marshal(input); err != nil {
+		test.Fatalf(`unable to marshal request: %s`, err)
+	} else {
+		request = events.APIGatewayProxyRequest{Body: string(result), Resource: `fake test resource`}
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestIndexTaskFilterSort(test *testing.T) {
+	//-- Shared Variables ----------
+	var input IndexRequest
+	var output indexOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var name = `Test API filtered task`
+	var quantity = 3
+
+	var limit uint = 10
+	var resolved = true
+	var resolvedAt = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	//-- Pre-conditions ----------
+	deleteTasks(test)
+	for i := 0; i < quantity; i++ {
+		var item = task.Task{}
+		item.Name = fmt.Sprintf(`%s %d`, name, i)
+		item.ResolvedAt = &resolvedAt
+
+		insertTask(test, &item)
+	}
+	insertTask(test, &task.Task{Name: `Test API unresolved task`})
+
+	ctx = context.Background()
+
+	input = IndexRequest{
+		Limit:    limit,
+		Resolved: &resolved,
+		Name:     `filtered`,
+		Sort:     `id`,
+		Order:    `desc`,
+	}
+
+	if result, err := json.Marshal(input); err != nil {
+		test.Fatalf(`unable to marshal request: %s`, err)
+	} else {
+		request = events.APIGatewayProxyRequest{Body: string(result), Resource: `fake test resource`}
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if err := decodeTasks(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.Equal(test, quantity, len(output.Tasks)) {
+		assert.True(test, output.Tasks[0].ID > output.Tasks[2].ID)
+	}
+}
+
+func TestIndexTaskQueryString(test *testing.T) {
+	//-- Shared Variables ----------
+	var output indexOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var name = `Test API query string task`
+	var quantity = 10
+
+	var limit = `4`
+	var order = `desc`
+
+	//-- Pre-conditions ----------
+	deleteTasks(test)
+	for i := 0; i < quantity; i++ {
+		var item = task.Task{}
+		item.Name = fmt.Sprintf(`%s %d`, name, i)
+
+		insertTask(test, &item)
+	}
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		QueryStringParameters:           map[string]string{`limit`: limit},
+		MultiValueQueryStringParameters: map[string][]string{`limit`: {limit}, `order`: {order}},
+		Resource:                        `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if err := decodeTasks(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.Equal(test, 4, len(output.Tasks)) {
+		assert.True(test, output.Tasks[0].ID > output.Tasks[3].ID)
+		assert.NotEqual(test, ``, output.Next)
+	}
+}
+
+func TestIndexTaskQueryStringMissingLimit(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{`offset`: `5`},
+		Resource:              `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestIndexTaskQueryStringInvalid(test *testing.T) {
+	//-- Shared Variables ----------
+	var output map[string][]interface{}
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var parameters = map[string][]string{
+		`limit`:        {`ten`},
+		`offset`:       {`-1`},
+		`resolved`:     {`maybe`},
+		`created_from`: {`yesterday`},
+	}
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		MultiValueQueryStringParameters: parameters,
+		Resource:                        `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusBadRequest, response.StatusCode)
+
+	if err := json.Unmarshal([]byte(response.Body), &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, len(parameters), len(output[`errors`]))
+	}
+}
+
+func TestIndexTaskQueryStringOutOfRange(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var limit = fmt.Sprintf(`%d`, maximumLimit+1)
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{`limit`: limit},
+		Resource:              `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestIndexTaskQueryStringRepeated(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		QueryStringParameters:           map[string]string{`limit`: `10`},
+		MultiValueQueryStringParameters: map[string][]string{`limit`: {`5`, `10`}},
+		Resource:                        `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusBadRequest, response.StatusCode)
+}
+
+//func TestReadTaskNotFound(test *testing.T) {
+//	//-- Shared Variables ----------
+//	var input IndexRequest
+//
+//	var request events.APIGatewayProxyRequest
+//	var response events.APIGatewayProxyResponse
+//
+//	var eventErr error
+//
+//	var ctx context.Context
+//
+//	//-- Test Parameters ----------
+//
+//	//-- Pre-conditions ----------
+//	ctx = context.Background()
+//
+//	input = IndexRequest{
+//		ID:  0,
+//	}
+//
+//	if result, err := json.Marshal(input); err != nil {
+//		test.Fatalf(`unable to marshal request: %s`, err)
+//	} else {
+//		request = events.APIGatewayProxyRequest{Body: string(result), Resource: `fake test resource`}
+//	}
+//
+//	//-- Action ----------
+//	response, eventErr = Index(ctx, request)
+//
+//	//-- Post-conditions ----------
+//	assert.Nil(test, eventErr)
+//	assert.Equal(test, http.StatusNotFound, response.StatusCode)
+//}
+
+func TestIndexTaskTrash(test *testing.T) {
+	//-- Shared Variables ----------
+	var output indexOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var live, trashed task.Task
+	var listed = make(map[uint]bool)
+
+	//-- Test Parameters ----------
+	var name = `Test API trash listing`
+
+	//-- Pre-conditions ----------
+	live = task.Task{Name: name}
+	trashed = task.Task{Name: name}
+	insertTask(test, &live)
+	insertTask(test, &trashed)
+
+	ctx = context.Background()
+
+	if _, err := Delete(ctx, events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, trashed.ID)}, Resource: `fake test resource`}); err != nil {
+		test.Fatalf(`unable to delete task: %s`, err)
+	}
+
+	request = events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{`limit`: `1000`, `deleted`: `only`, `sort`: `deleted_at`, `order`: `desc`},
+		Resource:              `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if err := decodeTasks(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.NotEmpty(test, output.Tasks) {
+		for _, item := range output.Tasks {
+			listed[item.ID] = true
+		}
+
+		assert.Equal(test, trashed.ID, output.Tasks[0].ID)
+		assert.True(test, listed[trashed.ID])
+		assert.False(test, listed[live.ID])
+	}
+}
+
+func TestIndexTaskInvalidDeleted(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var deleted = `forever`
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{`limit`: `10`, `deleted`: deleted},
+		Resource:              `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestIndexTaskOwnership(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+	var output indexOutput
+
+	var request events.APIGatewayProxyRequest
+	var own, other, admin events.APIGatewayProxyResponse
+
+	var ctx context.Context
+
+	var mine, theirs task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API index owned task`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	requireTokens(test, keys)
+
+	deleteTasks(test)
+
+	mine = task.Task{Name: name, OwnerID: `test owner`}
+	insertTask(test, &mine)
+	theirs = task.Task{Name: name, OwnerID: `test other`}
+	insertTask(test, &theirs)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{QueryStringParameters: map[string]string{`limit`: `10`}, Resource: `fake test resource`}
+
+	//-- Action ----------
+	request.Headers = bearer(test, keys, `test owner`)
+	own, _ = Index(ctx, request)
+
+	request.QueryStringParameters[`owner`] = `test other`
+	other, _ = Index(ctx, request)
+
+	request.Headers = bearer(test, keys, `test admin`, string(task.AdminRole))
+	admin, _ = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, http.StatusOK, own.StatusCode)
+	assert.Equal(test, http.StatusForbidden, other.StatusCode)
+	assert.Equal(test, http.StatusOK, admin.StatusCode)
+
+	if err := decodeTasks(own.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.Len(test, output.Tasks, 1) {
+		assert.Equal(test, mine.ID, output.Tasks[0].ID)
+	}
+
+	if err := decodeTasks(admin.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.Len(test, output.Tasks, 1) {
+		assert.Equal(test, theirs.ID, output.Tasks[0].ID)
+	}
+}
+
+func TestIndexTaskLinks(test *testing.T) {
+	//-- Shared Variables ----------
+	var output indexOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var name = `Test API linked task`
+	var quantity = 10
+
+	//-- Pre-conditions ----------
+	deleteTasks(test)
+	for i := 0; i < quantity; i++ {
+		var item = task.Task{}
+		item.Name = fmt.Sprintf(`%s %d`, name, i)
+
+		insertTask(test, &item)
+	}
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{`limit`: `4`, `offset`: `4`, `name`: name},
+		Headers:               map[string]string{`Accept`: document.MediaType},
+		Resource:              `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+	assert.Equal(test, document.MediaType, response.Headers[`Content-Type`])
+
+	if err := decodeTasks(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.Len(test, output.Tasks, 4) {
+		assert.Equal(test, fmt.Sprintf(`/tasks/%d`, output.Tasks[0].ID), output.Tasks[0].Links[`self`])
+
+		assert.Equal(test, `/tasks?limit=4&name=Test+API+linked+task&offset=4`, output.Links[`self`])
+		assert.Equal(test, `/tasks?limit=4&name=Test+API+linked+task&offset=8`, output.Links[`next`])
+		assert.Equal(test, `/tasks?limit=4&name=Test+API+linked+task`, output.Links[`prev`])
+
+		assert.Equal(test, map[string]interface{}{`limit`: float64(4), `offset`: float64(4), `count`: float64(4)}, output.Meta)
+	}
+}
+
+func TestIndexTaskNotAcceptable(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var accept = `text/html, application/vnd.api+json; ext="bulk"`
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{`limit`: `4`},
+		Headers:               map[string]string{`Accept`: accept},
+		Resource:              `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Index(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusNotAcceptable, response.StatusCode)
+}
+
+func TestIndexTaskFormats(test *testing.T) {
+	//-- Shared Variables ----------
+	var responses = make(map[string]events.APIGatewayProxyResponse)
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API formatted task`
+	var details = `Testing formatting a task`
+
+	//-- Pre-conditions ----------
+	deleteTasks(test)
+
+	subject = task.Task{Name: name, Details: &details}
+	insertTask(test, &subject)
+	insertTask(test, &task.Task{Name: name})
+
+	ctx = context.Background()
+
+	//-- Action ----------
+	for _, accept := range []string{formats.JSON, formats.CSV, formats.NDJSON, formats.XML} {
+		responses[accept], _ = Index(ctx, events.APIGatewayProxyRequest{
+			QueryStringParameters: map[string]string{`limit`: `1`},
+			Headers:               map[string]string{`Accept`: accept},
+			Resource:              `fake test resource`,
+		})
+	}
+
+	//-- Post-conditions ----------
+	for accept, response := range responses {
+		assert.Equal(test, http.StatusOK, response.StatusCode, accept)
+		assert.Equal(test, accept, response.Headers[`Content-Type`], accept)
+		assert.Equal(test, `Accept`, response.Headers[`Vary`], accept)
+		assert.Regexp(test, `^</tasks\?cursor=[^>]+&limit=1>; rel="next"$`, response.Headers[`Link`], accept)
+	}
+
+	var records []TaskRecord
+	if err := json.Unmarshal([]byte(responses[formats.JSON].Body), &records); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.Len(test, records, 1) {
+		assert.Equal(test, subject.ID, records[0].ID)
+		assert.Equal(test, details, *records[0].Details)
+	}
+
+	assert.Equal(test, fmt.Sprintf("id,name,details,resolved_at,owner_id,created_at,updated_at,deleted_at,version\n%d,%s,%s,,,%s,,,1\n", subject.ID, name, details, subject.CreatedAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)), responses[formats.CSV].Body)
+	assert.Equal(test, strings.TrimSuffix(responses[formats.JSON].Body[1:len(responses[formats.JSON].Body)-1], `,`)+"\n", responses[formats.NDJSON].Body)
+	assert.Contains(test, responses[formats.XML].Body, fmt.Sprintf(`<tasks><task><id>%d</id><name>%s</name><details>%s</details>`, subject.ID, name, details))
+}
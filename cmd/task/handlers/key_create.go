@@ -0,0 +1,73 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/pipeline"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// CreateKeyRequest names the scopes the new key is granted, a key without ExpiresAt never expires
+type CreateKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+//-- Event Handler -----------------------------------------------------------------------------------------------------
+// CreateKey issues an API key to the caller, the secret key is only ever returned by this response
+func CreateKey(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	//-- Shared variables ----------
+	var service apikey.Service
+
+	var request *CreateKeyRequest
+	var response *KeyResponse
+
+	return pipeline.Run(ctx, event, func() interface{} { return response },
+		//-- Authenticate ----------
+		authenticate(event, apikey.ManageKeys),
+
+		//-- Parse event ----------
+		pipeline.Parse(func(ctx context.Context) []*jsonapi.ErrorObject {
+			request = &CreateKeyRequest{}
+
+			if err := json.Unmarshal([]byte(event.Body), request); err != nil {
+				return pipeline.Fail(responses.MalformedRequestErr(err))
+			}
+
+			return nil
+		}),
+
+		//-- Connect Service ----------
+		connectKeys(&service),
+
+		//-- Action ---------
+		pipeline.Action(func(ctx context.Context) []*jsonapi.ErrorObject {
+			var subject = &apikey.Key{Name: request.Name, ExpiresAt: request.ExpiresAt}
+
+			for _, scope := range request.Scopes {
+				subject.Scopes = append(subject.Scopes, apikey.Scope(scope))
+			}
+
+			if secret, err := service.Create(ctx, subject); err == apikey.ErrForbidden {
+				return pipeline.Fail(responses.Forbidden(err))
+			} else if err != nil {
+				return pipeline.Fail(responses.UnprocessableEntryErr(err))
+			} else {
+				response = newKeyResponse(subject)
+				response.Key = secret
+			}
+
+			return nil
+		}),
+	)
+}
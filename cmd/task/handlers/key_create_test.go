@@ -0,0 +1,129 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/credentials"
+	"github.com/JustonDavies/go_serverless_api/pkg/auth/authtest"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+// createKey issues an API key through the event handler with the headers of its owner
+func createKey(test *testing.T, headers map[string]string, body string) KeyResponse {
+	var output KeyResponse
+
+	if response, err := CreateKey(context.Background(), events.APIGatewayProxyRequest{Headers: headers, Body: body, Resource: `fake test resource`}); err != nil {
+		test.Fatalf(`unable to create an API key: %s`, err)
+	} else if response.StatusCode != http.StatusOK {
+		test.Fatalf(`unable to create an API key: %d %s`, response.StatusCode, response.Body)
+	} else if err := json.Unmarshal([]byte(response.Body), &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	}
+
+	return output
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestCreateKey(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+	var output KeyResponse
+
+	var read, create events.APIGatewayProxyResponse
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var body = `{"name": "Test API read key", "scopes": ["tasks:read"]}`
+	var principal = `test key owner`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	requireTokens(test, keys)
+
+	ctx = context.Background()
+
+	//-- Action ----------
+	output = createKey(test, bearer(test, keys, principal), body)
+
+	read, _ = Index(ctx, events.APIGatewayProxyRequest{Headers: map[string]string{credentials.APIKeyHeader: output.Key}, QueryStringParameters: map[string]string{`limit`: `10`}, Resource: `fake test resource`})
+	create, _ = Create(ctx, events.APIGatewayProxyRequest{Headers: documentHeaders(map[string]string{credentials.APIKeyHeader: output.Key}), Body: taskDocument(test, ``, CreateRequest{Name: `Test API key task`}), Resource: `fake test resource`})
+
+	//-- Post-conditions ----------
+	assert.NotZero(test, output.ID)
+	assert.Equal(test, `Test API read key`, output.Name)
+	assert.Equal(test, []string{`tasks:read`}, output.Scopes)
+	assert.Equal(test, principal, output.OwnerID)
+	assert.Contains(test, output.Key, output.Prefix)
+
+	assert.Equal(test, http.StatusOK, read.StatusCode)
+	assert.Equal(test, http.StatusForbidden, create.StatusCode)
+}
+
+func TestCreateKeyInvalid(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var body = `{"name": "Test API manage key", "scopes": ["keys:manage"]}`
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{Body: body, Resource: `fake test resource`}
+
+	//-- Action ----------
+	response, eventErr = CreateKey(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusUnprocessableEntity, response.StatusCode)
+}
+
+func TestCreateKeyWithKey(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+	var issued KeyResponse
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var body = `{"name": "Test API write key", "scopes": ["tasks:read", "tasks:write"]}`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	requireTokens(test, keys)
+
+	issued = createKey(test, bearer(test, keys, `test key owner`), body)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{Headers: map[string]string{credentials.APIKeyHeader: issued.Key}, Body: body, Resource: `fake test resource`}
+
+	//-- Action ----------
+	response, eventErr = CreateKey(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusForbidden, response.StatusCode)
+}
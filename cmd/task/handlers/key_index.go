@@ -0,0 +1,54 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/pipeline"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type IndexKeysResponse struct {
+	Keys []*KeyResponse `json:"keys"`
+}
+
+//-- Event Handler -----------------------------------------------------------------------------------------------------
+// IndexKeys lists the API keys of the caller, or every key of their tenant for an admin, without their secrets
+func IndexKeys(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	//-- Shared variables ----------
+	var service apikey.Service
+
+	var response *IndexKeysResponse
+
+	return pipeline.Run(ctx, event, func() interface{} { return response },
+		//-- Authenticate ----------
+		authenticate(event, apikey.ManageKeys),
+
+		//-- Connect Service ----------
+		connectKeys(&service),
+
+		//-- Action ---------
+		pipeline.Action(func(ctx context.Context) []*jsonapi.ErrorObject {
+			if keys, err := service.List(ctx); err == apikey.ErrForbidden {
+				return pipeline.Fail(responses.Forbidden(err))
+			} else if err != nil {
+				return pipeline.Fail(responses.InternalServerErr(err))
+			} else {
+				response = &IndexKeysResponse{Keys: make([]*KeyResponse, 0, len(keys))}
+
+				for index := range keys {
+					response.Keys = append(response.Keys, newKeyResponse(&keys[index]))
+				}
+			}
+
+			return nil
+		}),
+	)
+}
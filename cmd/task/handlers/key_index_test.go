@@ -0,0 +1,66 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth/authtest"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestIndexKeys(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+	var output IndexKeysResponse
+
+	var own, other KeyResponse
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var body = `{"name": "Test API listed key", "scopes": ["tasks:read"]}`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	requireTokens(test, keys)
+
+	own = createKey(test, bearer(test, keys, `test index owner`), body)
+	other = createKey(test, bearer(test, keys, `test index other`), body)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{Headers: bearer(test, keys, `test index owner`), Resource: `fake test resource`}
+
+	//-- Action ----------
+	response, eventErr = IndexKeys(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+	assert.NotContains(test, response.Body, own.Key)
+
+	if err := json.Unmarshal([]byte(response.Body), &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.Len(test, output.Keys, 1) {
+		assert.Equal(test, own.ID, output.Keys[0].ID)
+		assert.Equal(test, own.Prefix, output.Keys[0].Prefix)
+		assert.Empty(test, output.Keys[0].Key)
+		assert.NotEqual(test, other.ID, output.Keys[0].ID)
+	}
+}
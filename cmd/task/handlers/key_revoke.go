@@ -0,0 +1,57 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/pipeline"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+
+//-- Event Handler -----------------------------------------------------------------------------------------------------
+// RevokeKey stops an API key from authenticating any further requests, a key which is already revoked is not found
+func RevokeKey(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	//-- Shared variables ----------
+	var subjectID uint
+	var service apikey.Service
+
+	var response *KeyResponse
+
+	return pipeline.Run(ctx, event, func() interface{} { return response },
+		//-- Authenticate ----------
+		authenticate(event, apikey.ManageKeys),
+
+		//-- Parse event ----------
+		pipeline.Parse(func(ctx context.Context) []*jsonapi.ErrorObject {
+			if err := parseID(event, &subjectID); err != nil {
+				return pipeline.Fail(err)
+			}
+
+			return nil
+		}),
+
+		//-- Connect Service ----------
+		connectKeys(&service),
+
+		//-- Action ---------
+		pipeline.Action(func(ctx context.Context) []*jsonapi.ErrorObject {
+			if result, err := service.Revoke(ctx, subjectID); err == apikey.ErrForbidden {
+				return pipeline.Fail(responses.Forbidden(err))
+			} else if err != nil {
+				return pipeline.Fail(responses.NotFound(err))
+			} else {
+				response = newKeyResponse(result)
+			}
+
+			return nil
+		}),
+	)
+}
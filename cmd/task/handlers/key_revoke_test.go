@@ -0,0 +1,101 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/credentials"
+	"github.com/JustonDavies/go_serverless_api/pkg/auth/authtest"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestRevokeKey(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+	var issued, output KeyResponse
+
+	var request events.APIGatewayProxyRequest
+	var response, again, used events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var body = `{"name": "Test API revoked key", "scopes": ["tasks:read"]}`
+	var principal = `test revoke owner`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	requireTokens(test, keys)
+
+	issued = createKey(test, bearer(test, keys, principal), body)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{Headers: bearer(test, keys, principal), PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, issued.ID)}, Resource: `fake test resource`}
+
+	//-- Action ----------
+	response, eventErr = RevokeKey(ctx, request)
+
+	again, _ = RevokeKey(ctx, request)
+	used, _ = Index(ctx, events.APIGatewayProxyRequest{Headers: map[string]string{credentials.APIKeyHeader: issued.Key}, QueryStringParameters: map[string]string{`limit`: `10`}, Resource: `fake test resource`})
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+	assert.Equal(test, http.StatusNotFound, again.StatusCode)
+	assert.Equal(test, http.StatusUnauthorized, used.StatusCode)
+
+	if err := json.Unmarshal([]byte(response.Body), &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, issued.ID, output.ID)
+		assert.NotNil(test, output.RevokedAt)
+	}
+}
+
+func TestRevokeKeyOtherOwner(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+	var issued KeyResponse
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var body = `{"name": "Test API foreign key", "scopes": ["tasks:read"]}`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	requireTokens(test, keys)
+
+	issued = createKey(test, bearer(test, keys, `test revoke owner`), body)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{Headers: bearer(test, keys, `test revoke other`), PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, issued.ID)}, Resource: `fake test resource`}
+
+	//-- Action ----------
+	response, eventErr = RevokeKey(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusNotFound, response.StatusCode)
+}
@@ -0,0 +1,85 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/logger"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/settings"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type MigrateResponse struct {
+	Success bool `json:"success"`
+}
+
+//-- Event Handler -----------------------------------------------------------------------------------------------------
+// Migrate is invoked directly rather than through API Gateway, so it does not run through the request pipeline. It
+// applies the task migrations and then those of the API keys kept beside the tasks, each versioned on its own.
+func Migrate() (events.APIGatewayProxyResponse, error) {
+	//-- Shared variables ----------
+	var start = time.Now()
+
+	var store task.Store
+
+	var response *MigrateResponse
+
+	//-- Connect Service ----------
+	{
+		if opened, err := task.OpenStore(settings.Connection()); err != nil {
+			slog.Error(`unable to open the store`, slog.String(`error`, err.Error()))
+			return responses.APIGatewayProxyError(responses.InternalServerErr(err))
+		} else {
+			store = opened
+		}
+
+		defer func() {
+			if err := store.Close(); err != nil {
+				slog.Error(`unable to close the store`, slog.String(`error`, err.Error()))
+			}
+		}()
+	}
+	slog.Debug(`stage finished`, slog.String(`stage`, `Service started`), logger.Latency(start))
+
+	//-- Action ---------
+	{
+		if err := store.Prepare(`up`, `file://pkg/services/task/migrations`); err != nil {
+			slog.Error(`unable to apply migrations`, slog.String(`source`, `task`), slog.String(`error`, err.Error()), logger.Latency(start))
+			return responses.APIGatewayProxyError(responses.InternalServerErr(err))
+		} else if keys, err := apikey.Attach(store); err != nil {
+			slog.Error(`unable to open the key store`, slog.String(`error`, err.Error()), logger.Latency(start))
+			return responses.APIGatewayProxyError(responses.InternalServerErr(err))
+		} else if err := keys.Prepare(`up`, `file://pkg/services/apikey/migrations`); err != nil {
+			slog.Error(`unable to apply migrations`, slog.String(`source`, `apikey`), slog.String(`error`, err.Error()), logger.Latency(start))
+			return responses.APIGatewayProxyError(responses.InternalServerErr(err))
+		}
+
+		response = &MigrateResponse{
+			Success: true,
+		}
+	}
+	slog.Debug(`stage finished`, slog.String(`stage`, `Action finished`), logger.Latency(start))
+
+	//-- Response ----------
+	{
+		if output, err := json.Marshal(response); err != nil {
+			return responses.APIGatewayProxyError(responses.InternalServerErr(err))
+		} else {
+			slog.Info(`migrations applied`, slog.Int(`bytes`, len(output)), logger.Latency(start))
+
+			return events.APIGatewayProxyResponse{
+				Body:       string(output),
+				StatusCode: http.StatusOK,
+			}, nil
+		}
+	}
+}
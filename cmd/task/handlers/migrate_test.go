@@ -1,5 +1,5 @@
 //-- Package Declaration -----------------------------------------------------------------------------------------------
-package main
+package handlers
 
 //-- Imports -----------------------------------------------------------------------------------------------------------
 import (
@@ -19,7 +19,7 @@ import (
 //-- Tests -------------------------------------------------------------------------------------------------------------
 func TestMigrate(test *testing.T) {
 	//-- Shared Variables ----------
-	var output Response
+	var output MigrateResponse
 
 	var response events.APIGatewayProxyResponse
 
@@ -30,7 +30,7 @@ func TestMigrate(test *testing.T) {
 	//-- Pre-conditions ----------
 
 	//-- Action ----------
-	response, eventErr = Handler()
+	response, eventErr = Migrate()
 
 	//-- Post-conditions ----------
 	assert.Nil(test, eventErr)
@@ -0,0 +1,141 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"mime"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/document"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/parameters"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/pipeline"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/pkg/patch"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+	"github.com/json-iterator/go"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+var (
+	// strict decodes a patched task, a patch which adds a member the task does not have is refused rather than ignored
+	strict = jsoniter.Config{EscapeHTML: true, SortMapKeys: true, ValidateJsonRawMessage: true, DisallowUnknownFields: true}.Froze()
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// PatchTarget is the document a patch is applied to, the attributes of a task a client may change. A missing value is
+// null rather than left out so it can be replaced or tested like any other member.
+type PatchTarget struct {
+	Name       string     `json:"name"`
+	Details    *string    `json:"details"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+}
+
+//-- Event Handler -----------------------------------------------------------------------------------------------------
+// Patch applies a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902) to a PatchTarget of the stored task, then
+// stores the result as Update would within the same transaction. A patch of anything but the PatchTarget members, or
+// one which leaves an invalid task, is unprocessable (422) and a failed test operation conflicts (409).
+func Patch(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	//-- Shared variables ----------
+	var subjectID uint
+	var subjectVersion uint
+	var service task.Service
+	var subjectTask *task.Task
+
+	var mediaType string
+	var request patch.Patch
+	var result pipeline.Result
+
+	return pipeline.Run(ctx, event, func() interface{} { return result },
+		//-- Authenticate ----------
+		authenticate(event, apikey.WriteTasks),
+
+		//-- Negotiate media type ----------
+		pipeline.Negotiate(func(ctx context.Context) []*jsonapi.ErrorObject {
+			var contentType, _ = parameters.Header(event, `Content-Type`)
+
+			if kind, _, err := mime.ParseMediaType(contentType); err != nil || kind != patch.MergeMediaType && kind != patch.JSONMediaType {
+				return pipeline.Fail(responses.UnsupportedMediaTypeErr(errors.New(fmt.Sprintf(`Content-Type - '%s' is not supported, use %s or %s`, contentType, patch.MergeMediaType, patch.JSONMediaType))))
+			} else {
+				mediaType = kind
+			}
+
+			if err := document.Negotiate(event, false); err != nil {
+				return pipeline.Fail(err)
+			}
+
+			return nil
+		}),
+
+		//-- Parse event ----------
+		pipeline.Parse(func(ctx context.Context) []*jsonapi.ErrorObject {
+			if err := parseID(event, &subjectID); err != nil {
+				return pipeline.Fail(err)
+			}
+
+			if parsed, err := patch.Parse(mediaType, []byte(event.Body)); err != nil {
+				return pipeline.Fail(responses.MalformedRequestErr(err))
+			} else {
+				request = parsed
+			}
+
+			if err := parseIfMatch(event, &subjectVersion); err != nil {
+				return pipeline.Fail(err)
+			}
+
+			return nil
+		}),
+
+		//-- Connect Service ----------
+		connect(&service),
+
+		//-- Action ---------
+		pipeline.Action(func(ctx context.Context) []*jsonapi.ErrorObject {
+			var err error
+
+			subjectTask, err = service.Patch(withActor(ctx, event), subjectID, subjectVersion, func(subject *task.Task) error {
+				return applyPatch(request, subject)
+			})
+
+			if err == task.ErrVersionConflict {
+				return pipeline.Fail(responses.PreconditionFailedErr(err))
+			} else if err == sql.ErrNoRows {
+				return pipeline.Fail(responses.NotFound(err))
+			} else if errors.Is(err, patch.ErrTestFailed) {
+				return pipeline.Fail(responses.ConflictErr(err))
+			} else if err != nil {
+				return pipeline.Fail(responses.UnprocessableEntryErr(err))
+			}
+
+			result = newTaskResult(event, subjectTask)
+			return nil
+		}),
+	)
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// applyPatch applies request to the PatchTarget of subject and copies the patched members back onto it
+func applyPatch(request patch.Patch, subject *task.Task) error {
+	var target PatchTarget
+	var stored = PatchTarget{Name: subject.Name, Details: subject.Details, ResolvedAt: subject.ResolvedAt}
+
+	if encoded, err := json.Marshal(stored); err != nil {
+		return err
+	} else if patched, err := request.Apply(encoded); err != nil {
+		return err
+	} else if err := strict.Unmarshal(patched, &target); err != nil {
+		return errors.New(fmt.Sprintf(`patch - the patched task is not valid, %s`, err))
+	}
+
+	subject.Name = target.Name
+	subject.Details = target.Details
+	subject.ResolvedAt = target.ResolvedAt
+
+	return nil
+}
@@ -0,0 +1,214 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/document"
+	"github.com/JustonDavies/go_serverless_api/pkg/patch"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+// patchHeaders adds the headers of a request which sends a patch of mediaType and accepts a document to headers
+func patchHeaders(mediaType string, headers map[string]string) map[string]string {
+	var merged = map[string]string{`Content-Type`: mediaType, `Accept`: document.MediaType}
+
+	for name, value := range headers {
+		merged[name] = value
+	}
+
+	return merged
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestPatchTaskMerge(test *testing.T) {
+	//-- Shared Variables ----------
+	var output taskOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API merge patch`
+	var details = `Testing details a patch leaves alone`
+	var resolvedAt = time.Date(2019, time.March, 4, 12, 30, 0, 0, time.UTC)
+
+	//-- Pre-conditions ----------
+	subject = task.Task{Name: name, Details: &details}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)},
+		Headers:        patchHeaders(patch.MergeMediaType, map[string]string{`If-Match`: `"1"`}),
+		Body:           `{"resolved_at": "2019-03-04T12:30:00Z"}`,
+		Resource:       `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Patch(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+	assert.Equal(test, `"2"`, response.Headers[`ETag`])
+
+	if err := decodeTask(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, subject.ID, output.ID)
+		assert.Equal(test, name, output.Name)
+		assert.Equal(test, &details, output.Details)
+		assert.NotNil(test, output.UpdatedAt)
+
+		if assert.NotNil(test, output.ResolvedAt) {
+			assert.True(test, resolvedAt.Equal(*output.ResolvedAt))
+		}
+	}
+}
+
+func TestPatchTaskJSONPatch(test *testing.T) {
+	//-- Shared Variables ----------
+	var output taskOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API json patch`
+	var details = `Testing details a patch removes`
+	var resolvedAt = time.Now()
+
+	var patchedName = `Test API json patched`
+
+	//-- Pre-conditions ----------
+	subject = task.Task{Name: name, Details: &details, ResolvedAt: &resolvedAt}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)},
+		Headers:        patchHeaders(patch.JSONMediaType, nil),
+		Body: `[
+			{"op": "test", "path": "/name", "value": "` + name + `"},
+			{"op": "replace", "path": "/name", "value": "` + patchedName + `"},
+			{"op": "replace", "path": "/resolved_at", "value": null},
+			{"op": "remove", "path": "/details"}
+		]`,
+		Resource: `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Patch(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if err := decodeTask(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, patchedName, output.Name)
+		assert.Nil(test, output.Details)
+		assert.Nil(test, output.ResolvedAt)
+		assert.Equal(test, uint(2), output.Version)
+	}
+}
+
+func TestPatchTaskInvalid(test *testing.T) {
+	//-- Shared Variables ----------
+	var statuses = make(map[string]int)
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API invalid patch`
+	var expected = map[string]int{
+		`document`:        http.StatusUnsupportedMediaType,
+		`not acceptable`:  http.StatusNotAcceptable,
+		`malformed`:       http.StatusBadRequest,
+		`bad operation`:   http.StatusBadRequest,
+		`stale`:           http.StatusPreconditionFailed,
+		`failed test`:     http.StatusConflict,
+		`missing path`:    http.StatusUnprocessableEntity,
+		`unknown member`:  http.StatusUnprocessableEntity,
+		`wrong type`:      http.StatusUnprocessableEntity,
+		`invalid name`:    http.StatusUnprocessableEntity,
+		`not found`:       http.StatusNotFound,
+		`unchanged check`: http.StatusOK,
+	}
+	var cases = map[string]struct {
+		headers map[string]string
+		body    string
+		missing bool
+	}{
+		`document`:        {documentHeaders(nil), `{"name": "Test API document patch"}`, false},
+		`not acceptable`:  {patchHeaders(patch.MergeMediaType, map[string]string{`Accept`: `text/html`}), `{}`, false},
+		`malformed`:       {patchHeaders(patch.MergeMediaType, nil), `{"name": `, false},
+		`bad operation`:   {patchHeaders(patch.JSONMediaType, nil), `[{"op": "rename", "path": "/name"}]`, false},
+		`stale`:           {patchHeaders(patch.MergeMediaType, map[string]string{`If-Match`: `"7"`}), `{}`, false},
+		`failed test`:     {patchHeaders(patch.JSONMediaType, nil), `[{"op": "test", "path": "/name", "value": "Another name"}]`, false},
+		`missing path`:    {patchHeaders(patch.JSONMediaType, nil), `[{"op": "remove", "path": "/owner_id"}]`, false},
+		`unknown member`:  {patchHeaders(patch.MergeMediaType, nil), `{"owner_id": "someone-else"}`, false},
+		`wrong type`:      {patchHeaders(patch.MergeMediaType, nil), `{"resolved_at": true}`, false},
+		`invalid name`:    {patchHeaders(patch.MergeMediaType, nil), `{"name": "~~~"}`, false},
+		`not found`:       {patchHeaders(patch.MergeMediaType, nil), `{}`, true},
+		`unchanged check`: {patchHeaders(patch.JSONMediaType, nil), `[{"op": "test", "path": "/name", "value": "` + name + `"}]`, false},
+	}
+
+	//-- Pre-conditions ----------
+	subject = task.Task{Name: name}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	//-- Action ----------
+	for label, parameters := range cases {
+		var id = subject.ID
+		if parameters.missing {
+			id = subject.ID + 1000
+		}
+
+		if response, err := Patch(ctx, events.APIGatewayProxyRequest{
+			PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, id)},
+			Headers:        parameters.headers,
+			Body:           parameters.body,
+			Resource:       `fake test resource`,
+		}); err != nil {
+			test.Fatalf(`unexpected error from handler: %s`, err)
+		} else {
+			statuses[label] = response.StatusCode
+		}
+	}
+
+	//-- Post-conditions ----------
+	assert.Equal(test, expected, statuses)
+}
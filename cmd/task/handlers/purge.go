@@ -0,0 +1,78 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/logger"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/JustonDavies/go_serverless_api/pkg/tracing"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// DefaultRetention is how long a deleted task stays in the trash when TASK_RETENTION is not set
+	DefaultRetention = 30 * 24 * time.Hour
+
+	// purgeActor is recorded in the history of every task the schedule purges
+	purgeActor = `scheduler`
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type PurgeResponse struct {
+	Purged    uint          `json:"purged"`
+	Retention time.Duration `json:"retention"`
+}
+
+//-- Event Handler -----------------------------------------------------------------------------------------------------
+// Purge is invoked on a schedule rather than through API Gateway, it permanently removes the tasks which have been in
+// the trash for longer than the retention period across every tenant. Errors are returned so the invocation is recorded
+// as a failure.
+func Purge(ctx context.Context, event events.CloudWatchEvent) (*PurgeResponse, error) {
+	//-- Shared variables ----------
+	var start = time.Now()
+	var retention = purgeRetention()
+
+	//-- Trace ----------
+	var span *tracing.Span
+	ctx, span = tracing.Start(ctx, `scheduled purge`, tracing.KindServer)
+	defer span.End()
+
+	span.SetAttribute(`aws.event_id`, event.ID)
+
+	//-- Connect Service ----------
+	if service, err := lifecycle.Service(ctx); err != nil {
+		slog.ErrorContext(ctx, `unable to open the store`, slog.String(`error`, err.Error()))
+		span.RecordError(err)
+		return nil, err
+	} else if purged, err := service.Purge(task.AcrossTenants(task.WithActor(ctx, purgeActor)), retention); err != nil {
+		slog.ErrorContext(ctx, `unable to purge the trash`, slog.String(`error`, err.Error()), logger.Latency(start))
+		span.RecordError(err)
+		return nil, err
+	} else {
+		slog.InfoContext(ctx, `trash purged`, slog.Uint64(`purged`, uint64(purged)), slog.Duration(`retention`, retention), logger.Latency(start))
+
+		return &PurgeResponse{
+			Purged:    purged,
+			Retention: retention,
+		}, nil
+	}
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// purgeRetention reads TASK_RETENTION from the environment, a missing or malformed value falls back to the default
+func purgeRetention() time.Duration {
+	if value := os.Getenv(`TASK_RETENTION`); len(value) == 0 {
+		return DefaultRetention
+	} else if parsed, err := time.ParseDuration(value); err != nil || parsed <= 0 {
+		slog.Warn(`ignoring malformed setting, a positive duration is required`, slog.String(`setting`, `TASK_RETENTION`), slog.String(`value`, value))
+		return DefaultRetention
+	} else {
+		return parsed
+	}
+}
@@ -0,0 +1,96 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestPurge(test *testing.T) {
+	//-- Shared Variables ----------
+	var output *PurgeResponse
+	var restoreResponse events.APIGatewayProxyResponse
+
+	var purgeErr error
+
+	var ctx context.Context
+
+	var trashed, live task.Task
+	var request events.APIGatewayProxyRequest
+
+	//-- Test Parameters ----------
+	var name = `Test API purge task`
+	var retention = time.Millisecond
+
+	//-- Pre-conditions ----------
+	trashed = task.Task{Name: name}
+	live = task.Task{Name: name}
+	insertTask(test, &trashed)
+	insertTask(test, &live)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, trashed.ID)}, Resource: `fake test resource`}
+
+	if _, err := Delete(ctx, request); err != nil {
+		test.Fatalf(`unable to delete task: %s`, err)
+	}
+	time.Sleep(10 * retention)
+
+	os.Setenv(`TASK_RETENTION`, retention.String())
+	defer os.Unsetenv(`TASK_RETENTION`)
+
+	//-- Action ----------
+	output, purgeErr = Purge(ctx, events.CloudWatchEvent{})
+	restoreResponse, _ = Restore(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, purgeErr)
+	assert.Equal(test, http.StatusNotFound, restoreResponse.StatusCode)
+
+	if assert.NotNil(test, output) {
+		assert.True(test, output.Purged > 0)
+		assert.Equal(test, retention, output.Retention)
+	}
+}
+
+func TestPurgeRetention(test *testing.T) {
+	//-- Shared Variables ----------
+	var retentions = make(map[string]time.Duration)
+
+	//-- Test Parameters ----------
+	var expected = map[string]time.Duration{
+		``:      DefaultRetention,
+		`48h`:   48 * time.Hour,
+		`-1h`:   DefaultRetention,
+		`a day`: DefaultRetention,
+	}
+
+	//-- Pre-conditions ----------
+	defer os.Unsetenv(`TASK_RETENTION`)
+
+	//-- Action ----------
+	for value := range expected {
+		os.Setenv(`TASK_RETENTION`, value)
+		retentions[value] = purgeRetention()
+	}
+
+	//-- Post-conditions ----------
+	assert.Equal(test, expected, retentions)
+}
@@ -0,0 +1,198 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth/authtest"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/JustonDavies/go_serverless_api/pkg/tracing"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestReadTask(test *testing.T) {
+	//-- Shared Variables ----------
+	var output taskOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API read task`
+	var details = `Testing reading a task through the event handler API`
+	var resolvedAt = time.Now()
+
+	//-- Pre-conditions ----------
+	subject = task.Task{
+		Name:       name,
+		Details:    &details,
+		ResolvedAt: &resolvedAt,
+	}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)}, Resource: `fake test resource`}
+
+	//-- Action ----------
+	response, eventErr = Read(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+	assert.Equal(test, `"1"`, response.Headers[`ETag`])
+
+	if err := decodeTask(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, subject.ID, output.ID)
+		assert.Equal(test, subject.Name, output.Name)
+		assert.Equal(test, uint(1), output.Version)
+		assert.Equal(test, *subject.Details, *output.Details)
+		assert.Equal(test, subject.ResolvedAt.Unix(), output.ResolvedAt.Unix())
+		assert.Equal(test, subject.CreatedAt.Unix(), output.CreatedAt.Unix())
+		assert.Equal(test, subject.UpdatedAt, output.UpdatedAt) //It hasn't been updated yet so these should be nil
+	}
+}
+
+func TestReadTaskNotFound(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, 0)}, Resource: `fake test resource`}
+
+	//-- Action ----------
+	response, eventErr = Read(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusNotFound, response.StatusCode)
+}
+
+func TestReadTaskTraceparent(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var ctx context.Context
+
+	var subject task.Task
+	var exporter *tracing.MemoryExporter
+	var spans = make(map[string]tracing.SpanData)
+
+	//-- Test Parameters ----------
+	var name = `Test API traced read task`
+	var remote, _ = tracing.ParseTraceparent(`00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01`)
+
+	//-- Pre-conditions ----------
+	subject = task.Task{Name: name}
+	insertTask(test, &subject)
+
+	exporter = tracing.NewMemoryExporter()
+
+	var previous = tracing.Default()
+	tracing.SetDefault(tracing.NewTracer(serviceName, exporter))
+	defer tracing.SetDefault(previous)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		HTTPMethod:     http.MethodGet,
+		PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)},
+		Headers:        map[string]string{`Traceparent`: remote.Traceparent()},
+		Resource:       TaskResource,
+	}
+
+	//-- Action ----------
+	response, _ = Read(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	for _, span := range exporter.Spans() {
+		assert.Equal(test, remote.TraceID, span.Context.TraceID, span.Name)
+		spans[span.Name] = span
+	}
+
+	var server = spans[`GET /tasks/{id}`]
+	assert.Equal(test, tracing.KindServer, server.Kind)
+	assert.Equal(test, remote.SpanID, server.Parent)
+	assert.Equal(test, http.StatusOK, server.Attributes[`http.response.status_code`])
+
+	for _, stage := range []string{`Event parsed`, `Service started`, `Action finished`, `Response encoded`} {
+		assert.Equal(test, server.Context.SpanID, spans[stage].Parent, stage)
+	}
+
+	assert.Equal(test, spans[`Action finished`].Context.SpanID, spans[`task read`].Parent)
+	assert.Equal(test, subject.ID, spans[`task read`].Attributes[`task.id`])
+}
+
+func TestReadTaskOwnership(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+
+	var request events.APIGatewayProxyRequest
+	var owner, other, admin events.APIGatewayProxyResponse
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API owned task`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	requireTokens(test, keys)
+
+	subject = task.Task{Name: name, OwnerID: `test owner`}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)}, Resource: `fake test resource`}
+
+	//-- Action ----------
+	request.Headers = bearer(test, keys, `test owner`)
+	owner, _ = Read(ctx, request)
+
+	request.Headers = bearer(test, keys, `test member`, string(task.MemberRole))
+	other, _ = Read(ctx, request)
+
+	request.Headers = bearer(test, keys, `test admin`, string(task.AdminRole))
+	admin, _ = Read(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, http.StatusOK, owner.StatusCode)
+	assert.Equal(test, http.StatusNotFound, other.StatusCode)
+	assert.Equal(test, http.StatusOK, admin.StatusCode)
+}
@@ -1,13 +1,15 @@
 //-- Package Declaration -----------------------------------------------------------------------------------------------
-package main
+package handlers
 
 //-- Imports -----------------------------------------------------------------------------------------------------------
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"testing"
 	"time"
 
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/stretchr/testify/assert"
 )
@@ -19,58 +21,56 @@ import (
 //-- Helpers -----------------------------------------------------------------------------------------------------------
 
 //-- Tests -------------------------------------------------------------------------------------------------------------
-func TestCreateTask(test *testing.T) {
+func TestReopenTask(test *testing.T) {
 	//-- Shared Variables ----------
-	var input Request
-	var output Response
+	var output, repeatedOutput taskOutput
 
 	var request events.APIGatewayProxyRequest
-	var response events.APIGatewayProxyResponse
+	var response, repeatedResponse events.APIGatewayProxyResponse
 
-	var eventErr error
+	var eventErr, repeatedErr error
 
 	var ctx context.Context
 
+	var subject task.Task
+
 	//-- Test Parameters ----------
-	var name = `Test API create task`
-	var details = `Testing creating a task through the event handler API`
+	var name = `Test API reopen task`
 	var resolvedAt = time.Now()
 
 	//-- Pre-conditions ----------
-	ctx = context.Background()
+	subject = task.Task{Name: name, ResolvedAt: &resolvedAt}
+	insertTask(test, &subject)
 
-	input = Request{
-		Name:       name,
-		Details:    &details,
-		ResolvedAt: &resolvedAt,
-	}
+	ctx = context.Background()
 
-	if result, err := json.Marshal(input); err != nil {
-		test.Fatalf(`unable to marshal request: %s`, err)
-	} else {
-		request = events.APIGatewayProxyRequest{Body: string(result), Resource: `fake test resource`}
-	}
+	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)}, Resource: `fake test resource`}
 
 	//-- Action ----------
-	response, eventErr = Handler(ctx, request)
+	response, eventErr = Reopen(ctx, request)
+	repeatedResponse, repeatedErr = Reopen(ctx, request)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, eventErr)
+	assert.Nil(test, repeatedErr)
 	assert.Equal(test, http.StatusOK, response.StatusCode)
+	assert.Equal(test, http.StatusOK, repeatedResponse.StatusCode)
+	assert.Equal(test, `"2"`, response.Headers[`ETag`])
+	assert.Equal(test, `"2"`, repeatedResponse.Headers[`ETag`])
 
-	if err := json.Unmarshal([]byte(response.Body), &output); err != nil {
+	if err := decodeTask(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if err := decodeTask(repeatedResponse.Body, &repeatedOutput); err != nil {
 		test.Fatalf(`unable to marshal response: %s`, err)
 	} else {
-		assert.NotEqual(test, uint(0), output.ID)
-		assert.NotEqual(test, time.Time{}, output.CreatedAt)
-		assert.Nil(test, output.UpdatedAt)
+		assert.Equal(test, subject.ID, output.ID)
+		assert.Nil(test, output.ResolvedAt)
+		assert.Nil(test, repeatedOutput.ResolvedAt)
 	}
 }
 
-func TestCreateTaskInvalid(test *testing.T) {
+func TestReopenTaskNotFound(test *testing.T) {
 	//-- Shared Variables ----------
-	var input Request
-
 	var request events.APIGatewayProxyRequest
 	var response events.APIGatewayProxyResponse
 
@@ -79,29 +79,16 @@ func TestCreateTaskInvalid(test *testing.T) {
 	var ctx context.Context
 
 	//-- Test Parameters ----------
-	var name = `Test API create invalid task ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~`
-	var details = `Testing creating am invalid task through the event handler API`
-	var resolvedAt = time.Now()
 
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	input = Request{
-		Name:       name,
-		Details:    &details,
-		ResolvedAt: &resolvedAt,
-	}
-
-	if result, err := json.Marshal(input); err != nil {
-		test.Fatalf(`unable to marshal request: %s`, err)
-	} else {
-		request = events.APIGatewayProxyRequest{Body: string(result), Resource: `fake test resource`}
-	}
+	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: `100000`}, Resource: `fake test resource`}
 
 	//-- Action ----------
-	response, eventErr = Handler(ctx, request)
+	response, eventErr = Reopen(ctx, request)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, eventErr)
-	assert.Equal(test, http.StatusUnprocessableEntity, response.StatusCode)
+	assert.Equal(test, http.StatusNotFound, response.StatusCode)
 }
@@ -0,0 +1,66 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/pipeline"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+
+//-- Event Handler -----------------------------------------------------------------------------------------------------
+// Resolve marks a task resolved at the time the service receives the request, a task which is already resolved keeps
+// the time it was resolved and the request conflicts (409)
+func Resolve(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	//-- Shared variables ----------
+	var subjectID uint
+	var service task.Service
+
+	var result pipeline.Result
+
+	return pipeline.Run(ctx, event, func() interface{} { return result },
+		//-- Authenticate ----------
+		authenticate(event, apikey.WriteTasks),
+
+		//-- Negotiate media type ----------
+		negotiate(event, false),
+
+		//-- Parse event ----------
+		pipeline.Parse(func(ctx context.Context) []*jsonapi.ErrorObject {
+			if err := parseID(event, &subjectID); err != nil {
+				return pipeline.Fail(err)
+			}
+
+			return nil
+		}),
+
+		//-- Connect Service ----------
+		connect(&service),
+
+		//-- Action ---------
+		pipeline.Action(func(ctx context.Context) []*jsonapi.ErrorObject {
+			if subject, err := service.Resolve(withActor(ctx, event), subjectID); errors.Is(err, task.ErrAlreadyResolved) {
+				return pipeline.Fail(responses.ConflictErr(err))
+			} else if err == sql.ErrNoRows {
+				return pipeline.Fail(responses.NotFound(err))
+			} else if err != nil {
+				return pipeline.Fail(responses.InternalServerErr(err))
+			} else {
+				result = newTaskResult(event, subject)
+			}
+
+			return nil
+		}),
+	)
+}
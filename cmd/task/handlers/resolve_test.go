@@ -0,0 +1,134 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestResolveTask(test *testing.T) {
+	//-- Shared Variables ----------
+	var output taskOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+	var before, after time.Time
+
+	//-- Test Parameters ----------
+	var name = `Test API resolve task`
+
+	//-- Pre-conditions ----------
+	subject = task.Task{Name: name}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)}, Resource: `fake test resource`}
+
+	//-- Action ----------
+	before = time.Now().Truncate(time.Microsecond)
+	response, eventErr = Resolve(ctx, request)
+	after = time.Now()
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+	assert.Equal(test, `"2"`, response.Headers[`ETag`])
+
+	if err := decodeTask(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.NotNil(test, output.ResolvedAt) {
+		assert.Equal(test, subject.ID, output.ID)
+		assert.False(test, output.ResolvedAt.Before(before))
+		assert.False(test, output.ResolvedAt.After(after))
+		assert.Equal(test, time.UTC, output.ResolvedAt.Location())
+	}
+}
+
+func TestResolveTaskResolved(test *testing.T) {
+	//-- Shared Variables ----------
+	var payload jsonapi.ErrorsPayload
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API resolve resolved task`
+	var resolvedAt = time.Date(2019, time.January, 1, 0, 0, 1, 0, time.UTC)
+
+	//-- Pre-conditions ----------
+	subject = task.Task{Name: name, ResolvedAt: &resolvedAt}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)}, Resource: `fake test resource`}
+
+	//-- Action ----------
+	response, eventErr = Resolve(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusConflict, response.StatusCode)
+
+	if err := json.Unmarshal([]byte(response.Body), &payload); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if assert.Equal(test, 1, len(payload.Errors)) && assert.NotNil(test, payload.Errors[0].Meta) {
+		var message = fmt.Sprint((*payload.Errors[0].Meta)[`error`])
+
+		assert.Contains(test, message, `already resolved`)
+		assert.Contains(test, message, `2019-01-01T00:00:01Z`)
+	}
+}
+
+func TestResolveTaskNotFound(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: `100000`}, Resource: `fake test resource`}
+
+	//-- Action ----------
+	response, eventErr = Resolve(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusNotFound, response.StatusCode)
+}
@@ -1,14 +1,12 @@
 //-- Package Declaration -----------------------------------------------------------------------------------------------
-package main
+package handlers
 
 //-- Imports -----------------------------------------------------------------------------------------------------------
 import (
 	"context"
 	"fmt"
 	"net/http"
-	"os"
 	"testing"
-	"time"
 
 	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
 	"github.com/aws/aws-lambda-go/events"
@@ -20,37 +18,14 @@ import (
 //-- Decorators --------------------------------------------------------------------------------------------------------
 
 //-- Helpers -----------------------------------------------------------------------------------------------------------
-func insertTask(test *testing.T, input *task.Task) {
-	var ctx context.Context
-	var store task.Store
-	var service task.Service
-
-	ctx = context.Background()
-
-	store = task.NewPostgresStore()
-	if err := store.Open(os.Getenv(`DATABASE_CONNECTION_PARAMETERS`)); err != nil {
-		test.Fatalf(`an unexpected error occured while opening the database: %s`, err)
-	}
-
-	service = task.NewService(nil, store)
-	defer func() {
-		if err := service.Shutdown(); err != nil {
-			test.Fatalf(`an unrecoverable error has occured while tring to shutdown the service: %s`, err)
-		}
-	}()
-
-	if err := service.Create(ctx, input); err != nil {
-		test.Fatalf(`an unexpected error occured while opening the database: %s`, err)
-	}
-}
 
 //-- Tests -------------------------------------------------------------------------------------------------------------
-func TestReadTask(test *testing.T) {
+func TestRestoreTask(test *testing.T) {
 	//-- Shared Variables ----------
-	var output Response
+	var output taskOutput
 
 	var request events.APIGatewayProxyRequest
-	var response events.APIGatewayProxyResponse
+	var response, readResponse events.APIGatewayProxyResponse
 
 	var eventErr error
 
@@ -59,42 +34,40 @@ func TestReadTask(test *testing.T) {
 	var subject task.Task
 
 	//-- Test Parameters ----------
-	var name = `Test API read task`
-	var details = `Testing reading a task through the event handler API`
-	var resolvedAt = time.Now()
+	var name = `Test API restore task`
 
 	//-- Pre-conditions ----------
-	subject = task.Task{
-		Name:       name,
-		Details:    &details,
-		ResolvedAt: &resolvedAt,
-	}
+	subject = task.Task{Name: name}
 	insertTask(test, &subject)
 
 	ctx = context.Background()
 
 	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)}, Resource: `fake test resource`}
 
+	if _, err := Delete(ctx, request); err != nil {
+		test.Fatalf(`unable to delete task: %s`, err)
+	}
+
 	//-- Action ----------
-	response, eventErr = Handler(ctx, request)
+	response, eventErr = Restore(ctx, request)
+	readResponse, _ = Read(ctx, request)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, eventErr)
 	assert.Equal(test, http.StatusOK, response.StatusCode)
+	assert.Equal(test, `"1"`, response.Headers[`ETag`])
+	assert.Equal(test, http.StatusOK, readResponse.StatusCode)
 
-	if err := json.Unmarshal([]byte(response.Body), &output); err != nil {
+	if err := decodeTask(response.Body, &output); err != nil {
 		test.Fatalf(`unable to marshal response: %s`, err)
 	} else {
 		assert.Equal(test, subject.ID, output.ID)
 		assert.Equal(test, subject.Name, output.Name)
-		assert.Equal(test, *subject.Details, *output.Details)
-		assert.Equal(test, subject.ResolvedAt.Unix(), output.ResolvedAt.Unix())
-		assert.Equal(test, subject.CreatedAt.Unix(), output.CreatedAt.Unix())
-		assert.Equal(test, subject.UpdatedAt, output.UpdatedAt) //It hasn't been updated yet so these should be nil
+		assert.Nil(test, output.DeletedAt)
 	}
 }
 
-func TestReadTaskNotFound(test *testing.T) {
+func TestRestoreTaskNotDeleted(test *testing.T) {
 	//-- Shared Variables ----------
 	var request events.APIGatewayProxyRequest
 	var response events.APIGatewayProxyResponse
@@ -103,15 +76,21 @@ func TestReadTaskNotFound(test *testing.T) {
 
 	var ctx context.Context
 
+	var subject task.Task
+
 	//-- Test Parameters ----------
+	var name = `Test API restore live task`
 
 	//-- Pre-conditions ----------
+	subject = task.Task{Name: name}
+	insertTask(test, &subject)
+
 	ctx = context.Background()
 
-	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, 0)}, Resource: `fake test resource`}
+	request = events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)}, Resource: `fake test resource`}
 
 	//-- Action ----------
-	response, eventErr = Handler(ctx, request)
+	response, eventErr = Restore(ctx, request)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, eventErr)
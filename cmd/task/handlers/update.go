@@ -0,0 +1,98 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/document"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/pipeline"
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/responses"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/jsonapi"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// UpdateRequest holds the attributes which replace those of the task, the id of the task is a member of the resource
+// object and must match the path
+type UpdateRequest struct {
+	Name       string     `json:"name"`
+	Details    *string    `json:"details,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+//-- Event Handler -----------------------------------------------------------------------------------------------------
+func Update(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	//-- Shared variables ----------
+	var subjectID uint
+	var subjectVersion uint
+	var service task.Service
+	var subjectTask *task.Task
+
+	var request *UpdateRequest
+	var result pipeline.Result
+
+	return pipeline.Run(ctx, event, func() interface{} { return result },
+		//-- Authenticate ----------
+		authenticate(event, apikey.WriteTasks),
+
+		//-- Negotiate media type ----------
+		negotiate(event, true),
+
+		//-- Parse event ----------
+		pipeline.Parse(func(ctx context.Context) []*jsonapi.ErrorObject {
+			request = &UpdateRequest{}
+
+			if err := parseID(event, &subjectID); err != nil {
+				return pipeline.Fail(err)
+			}
+
+			if id, err := document.Decode(event.Body, TaskType, request); err != nil {
+				return pipeline.Fail(err)
+			} else if len(id) == 0 {
+				return pipeline.Fail(responses.MalformedRequestErr(errors.New(`data.id - the id of the task is required`)))
+			} else if id != strconv.FormatUint(uint64(subjectID), 10) {
+				return pipeline.Fail(responses.ConflictErr(errors.New(`data.id - id mismatch`)))
+			}
+
+			if err := parseIfMatch(event, &subjectVersion); err != nil {
+				return pipeline.Fail(err)
+			}
+
+			return nil
+		}),
+
+		//-- Connect Service ----------
+		connect(&service),
+
+		//-- Action ---------
+		pipeline.Action(func(ctx context.Context) []*jsonapi.ErrorObject {
+			subjectTask = &task.Task{
+				ID:         subjectID,
+				Name:       request.Name,
+				Details:    request.Details,
+				ResolvedAt: request.ResolvedAt,
+				Version:    subjectVersion,
+			}
+
+			if err := service.Update(withActor(ctx, event), subjectTask); err == task.ErrVersionConflict {
+				return pipeline.Fail(responses.PreconditionFailedErr(err))
+			} else if err == sql.ErrNoRows {
+				return pipeline.Fail(responses.NotFound(err))
+			} else if err != nil {
+				return pipeline.Fail(responses.UnprocessableEntryErr(err))
+			}
+
+			result = newTaskResult(event, subjectTask)
+			return nil
+		}),
+	)
+}
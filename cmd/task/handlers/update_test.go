@@ -0,0 +1,439 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package handlers
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth/authtest"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestUpdateTask(test *testing.T) {
+	//-- Shared Variables ----------
+	var input UpdateRequest
+	var output taskOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API update task`
+	var details = `Testing updating a task through the event handler API`
+	var resolvedAt = time.Now()
+
+	var updatedName = `Test API updated task name`
+
+	//-- Pre-conditions ----------
+	subject = task.Task{
+		Name:       name,
+		Details:    &details,
+		ResolvedAt: &resolvedAt,
+	}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	input = UpdateRequest{
+		Name:       updatedName,
+		Details:    subject.Details,
+		ResolvedAt: subject.ResolvedAt,
+	}
+
+	request = events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)},
+		Headers:        documentHeaders(nil),
+		Body:           taskDocument(test, fmt.Sprint(subject.ID), input),
+		Resource:       `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Update(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	if err := decodeTask(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, subject.ID, output.ID)
+		assert.Equal(test, updatedName, output.Name)
+		assert.NotNil(test, output.UpdatedAt)
+	}
+}
+
+func TestUpdateTaskStoredFields(test *testing.T) {
+	//-- Shared Variables ----------
+	var output, readOutput taskOutput
+
+	var request events.APIGatewayProxyRequest
+	var response, readResponse events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API update stored fields`
+	var owner = `test stored fields owner`
+
+	var updatedName = `Test API updated stored fields`
+
+	//-- Pre-conditions ----------
+	subject = task.Task{Name: name, OwnerID: owner}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)},
+		Headers:        documentHeaders(nil),
+		Body:           taskDocument(test, fmt.Sprint(subject.ID), UpdateRequest{Name: updatedName}),
+		Resource:       `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Update(ctx, request)
+	readResponse, _ = Read(ctx, events.APIGatewayProxyRequest{PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)}, Resource: `fake test resource`})
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+	assert.Equal(test, readResponse.Headers[`ETag`], response.Headers[`ETag`])
+
+	if err := decodeTask(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else if err := decodeTask(readResponse.Body, &readOutput); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.False(test, output.CreatedAt.IsZero())
+		assert.Equal(test, readOutput.CreatedAt, output.CreatedAt)
+		assert.Equal(test, owner, output.OwnerID)
+		assert.Equal(test, readOutput.OwnerID, output.OwnerID)
+		assert.Equal(test, readOutput.UpdatedAt, output.UpdatedAt)
+		assert.Equal(test, readOutput.Version, output.Version)
+	}
+}
+
+func TestUpdateTaskIfMatch(test *testing.T) {
+	//-- Shared Variables ----------
+	var output taskOutput
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API conditional update`
+	var updatedName = `Test API conditionally updated`
+
+	//-- Pre-conditions ----------
+	subject = task.Task{Name: name}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)},
+		Headers:        documentHeaders(map[string]string{`if-match`: `"1"`}),
+		Body:           taskDocument(test, fmt.Sprint(subject.ID), UpdateRequest{Name: updatedName}),
+		Resource:       `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Update(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+	assert.Equal(test, `"2"`, response.Headers[`ETag`])
+
+	if err := decodeTask(response.Body, &output); err != nil {
+		test.Fatalf(`unable to marshal response: %s`, err)
+	} else {
+		assert.Equal(test, updatedName, output.Name)
+		assert.Equal(test, uint(2), output.Version)
+	}
+}
+
+func TestUpdateTaskIfMatchStale(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API stale update`
+	var updatedName = `Test API lost update`
+
+	//-- Pre-conditions ----------
+	subject = task.Task{Name: name}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)},
+		Headers:        documentHeaders(map[string]string{`If-Match`: `"7"`}),
+		Body:           taskDocument(test, fmt.Sprint(subject.ID), UpdateRequest{Name: updatedName}),
+		Resource:       `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Update(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusPreconditionFailed, response.StatusCode)
+}
+
+func TestUpdateTaskIfMatchInvalid(test *testing.T) {
+	//-- Shared Variables ----------
+	var responses = make(map[string]int)
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API invalid precondition`
+	var expected = map[string]int{
+		`W/"1"`:    http.StatusPreconditionFailed,
+		`"other"`:  http.StatusPreconditionFailed,
+		`"1", "2"`: http.StatusBadRequest,
+		`1`:        http.StatusBadRequest,
+		`*`:        http.StatusOK,
+	}
+
+	//-- Pre-conditions ----------
+	subject = task.Task{Name: name}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	//-- Action ----------
+	for header := range expected {
+		if response, err := Update(ctx, events.APIGatewayProxyRequest{
+			PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)},
+			Headers:        documentHeaders(map[string]string{`If-Match`: header}),
+			Body:           taskDocument(test, fmt.Sprint(subject.ID), UpdateRequest{Name: name}),
+			Resource:       `fake test resource`,
+		}); err != nil {
+			test.Fatalf(`unexpected error from handler: %s`, err)
+		} else {
+			responses[header] = response.StatusCode
+		}
+	}
+
+	//-- Post-conditions ----------
+	assert.Equal(test, expected, responses)
+}
+
+func TestUpdateTaskInvalid(test *testing.T) {
+	//-- Shared Variables ----------
+	var input UpdateRequest
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API update task`
+	var details = `Testing updating a task through the event handler API`
+	var resolvedAt = time.Now()
+
+	var updatedName = `Test API invalid updated task name ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~`
+
+	//-- Pre-conditions ----------
+	subject = task.Task{
+		Name:       name,
+		Details:    &details,
+		ResolvedAt: &resolvedAt,
+	}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	input = UpdateRequest{
+		Name:       updatedName,
+		Details:    subject.Details,
+		ResolvedAt: subject.ResolvedAt,
+	}
+
+	request = events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)},
+		Headers:        documentHeaders(nil),
+		Body:           taskDocument(test, fmt.Sprint(subject.ID), input),
+		Resource:       `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Update(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusUnprocessableEntity, response.StatusCode)
+}
+
+func TestUpdateTaskMismatchID(test *testing.T) {
+	//-- Shared Variables ----------
+	var input UpdateRequest
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API update task`
+	var details = `Testing updating a task through the event handler API`
+	var resolvedAt = time.Now()
+
+	var updatedName = `Test API updated task name`
+
+	//-- Pre-conditions ----------
+	subject = task.Task{
+		Name:       name,
+		Details:    &details,
+		ResolvedAt: &resolvedAt,
+	}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	input = UpdateRequest{
+		Name:       updatedName,
+		Details:    subject.Details,
+		ResolvedAt: subject.ResolvedAt,
+	}
+
+	request = events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID+1)},
+		Headers:        documentHeaders(nil),
+		Body:           taskDocument(test, fmt.Sprint(subject.ID), input),
+		Resource:       `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Update(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusConflict, response.StatusCode)
+}
+
+func TestUpdateTaskOwnership(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API update owned task`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	requireTokens(test, keys)
+
+	subject = task.Task{Name: name, OwnerID: `test owner`}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		Headers:        documentHeaders(bearer(test, keys, `test member`)),
+		PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)},
+		Body:           taskDocument(test, fmt.Sprint(subject.ID), UpdateRequest{Name: `Test API update stolen task`}),
+		Resource:       `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Update(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusNotFound, response.StatusCode)
+}
+
+func TestUpdateTaskMissingID(test *testing.T) {
+	//-- Shared Variables ----------
+	var request events.APIGatewayProxyRequest
+	var response events.APIGatewayProxyResponse
+
+	var eventErr error
+
+	var ctx context.Context
+
+	var subject task.Task
+
+	//-- Test Parameters ----------
+	var name = `Test API update task without an id`
+
+	//-- Pre-conditions ----------
+	subject = task.Task{Name: name}
+	insertTask(test, &subject)
+
+	ctx = context.Background()
+
+	request = events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{`id`: fmt.Sprintf(`%d`, subject.ID)},
+		Headers:        documentHeaders(nil),
+		Body:           taskDocument(test, ``, UpdateRequest{Name: name}),
+		Resource:       `fake test resource`,
+	}
+
+	//-- Action ----------
+	response, eventErr = Update(ctx, request)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, eventErr)
+	assert.Equal(test, http.StatusBadRequest, response.StatusCode)
+}
@@ -0,0 +1,21 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package main
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/JustonDavies/go_serverless_api/cmd/shared/server"
+	"github.com/JustonDavies/go_serverless_api/cmd/task/handlers"
+)
+
+//-- Main --------------------------------------------------------------------------------------------------------------
+func main() {
+	var address = flag.String(`address`, `127.0.0.1:8080`, `the host:port to serve the task API on`)
+	flag.Parse()
+
+	log.Printf(`Serving the task API on http://%s`, *address)
+	log.Fatal(http.ListenAndServe(*address, server.New(handlers.NewRouter())))
+}
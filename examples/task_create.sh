@@ -7,15 +7,20 @@ command -v curl >/dev/null 2>&2 || { echo >&2 "curl is required but is not avail
 #-- Variables ----------------------------------------------------------------------------------------------------------
 path=/tasks
 method=POST
-server=`cat scripts/api/_configuration.json | jq -r '.api_url'`
+server=${API_URL:-`cat examples/_configuration.json | jq -r '.api_url'`}
+token=${API_TOKEN:-}
+media=application/vnd.api+json
 
-input=@./scripts/api/data/task_create.json
+input=@./examples/data/task_create.json
 
 #-- Pre-conditions -----------------------------------------------------------------------------------------------------
 
 #-- Action -------------------------------------------------------------------------------------------------------------
 curl -X $method                                      \
      --verbose                                       \
+     --header "Authorization: Bearer $token"         \
+     --header "Content-Type: $media"                 \
+     --header "Accept: $media"                       \
      --data $input                                   \
      $server$path
 
@@ -7,16 +7,21 @@ command -v curl >/dev/null 2>&2 || { echo >&2 "curl is required but is not avail
 #-- Variables ----------------------------------------------------------------------------------------------------------
 path=/tasks
 method=GET
-server=`cat scripts/api/_configuration.json | jq -r '.api_url'`
+server=${API_URL:-`cat examples/_configuration.json | jq -r '.api_url'`}
+token=${API_TOKEN:-}
+media=application/vnd.api+json
 
-input=@./scripts/api/data/task_index.json
+limit=10
 
 #-- Pre-conditions -----------------------------------------------------------------------------------------------------
 
 #-- Action -------------------------------------------------------------------------------------------------------------
 curl -X $method                                      \
      --verbose                                       \
-     --data $input                                   \
+     --header "Authorization: Bearer $token"         \
+     --header "Accept: $media"                       \
+     --get                                           \
+     --data-urlencode "limit=$limit"                 \
      $server$path
 
 #-- Post-Conditions ----------------------------------------------------------------------------------------------------
\ No newline at end of file
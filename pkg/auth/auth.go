@@ -0,0 +1,144 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package auth verifies JWT bearer tokens signed with HS256 or RS256 against the keys of a JWKS document and carries the
+// resulting Principal through the context of a request.
+package auth
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// DefaultLeeway is the clock skew allowed when checking exp and nbf if Parameters does not set one
+	DefaultLeeway = 30 * time.Second
+
+	bearerScheme = `bearer`
+)
+
+var (
+	// ErrUnauthenticated is wrapped by every error caused by the token itself, any other error means the token could not
+	// be checked at all
+	ErrUnauthenticated = errors.New(`unauthenticated`)
+
+	ErrMissingToken  = fmt.Errorf(`%w: a bearer token is required`, ErrUnauthenticated)
+	ErrMalformed     = fmt.Errorf(`%w: the token is not a well formed JWT`, ErrUnauthenticated)
+	ErrAlgorithm     = fmt.Errorf(`%w: the token is not signed with HS256 or RS256 by a matching key`, ErrUnauthenticated)
+	ErrUnknownKey    = fmt.Errorf(`%w: the token is signed by an unknown key`, ErrUnauthenticated)
+	ErrSignature     = fmt.Errorf(`%w: the token signature is invalid`, ErrUnauthenticated)
+	ErrExpired       = fmt.Errorf(`%w: the token has expired or has no expiry`, ErrUnauthenticated)
+	ErrNotYetValid   = fmt.Errorf(`%w: the token is not valid yet`, ErrUnauthenticated)
+	ErrIssuer        = fmt.Errorf(`%w: the token was issued by an untrusted issuer`, ErrUnauthenticated)
+	ErrAudience      = fmt.Errorf(`%w: the token was not issued for this audience`, ErrUnauthenticated)
+	ErrSubject       = fmt.Errorf(`%w: the token does not name a subject`, ErrUnauthenticated)
+	ErrNotConfigured = errors.New(`authentication is enabled but no JWKS source is configured`)
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Parameters configures an Authenticator. JWKS is the path of a file or an http(s) URL serving the keys, Issuer and
+// Audience are only checked when they are set. Disabled accepts every request without a Principal.
+type Parameters struct {
+	JWKS     string
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+	Disabled bool
+}
+
+// Principal is the verified identity behind a request, Roles are read from the roles claim of its token and Tenant from
+// its tenant_id claim. Scopes limits the operations the principal may perform, nil leaves them unlimited.
+type Principal struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	Roles     []string
+	Tenant    string
+	Scopes    []string
+	ExpiresAt time.Time
+	Claims    map[string]interface{}
+}
+
+// Authenticator turns the Authorization header of a request into a Principal, a nil Principal without an error means the
+// request is allowed anonymously
+type Authenticator interface {
+	Authenticate(ctx context.Context, authorization string) (*Principal, error)
+}
+
+type anonymousAuthenticator struct{}
+
+type principalKey struct{}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// NewAuthenticator returns the Authenticator described by parameters, a JWKS file is read immediately so a bad file is
+// reported at start up while a JWKS URL is fetched on first use
+func NewAuthenticator(parameters Parameters) (Authenticator, error) {
+	if parameters.Disabled {
+		return anonymousAuthenticator{}, nil
+	} else if len(parameters.JWKS) == 0 {
+		return nil, ErrNotConfigured
+	}
+
+	return NewVerifier(parameters)
+}
+
+// WithPrincipal returns a copy of ctx which carries principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the Principal carried by ctx, or nil for an anonymous request
+func PrincipalFrom(ctx context.Context) *Principal {
+	if principal, ok := ctx.Value(principalKey{}).(*Principal); ok {
+		return principal
+	}
+	return nil
+}
+
+// BearerToken extracts the token from an Authorization header using the Bearer scheme
+func BearerToken(authorization string) (string, error) {
+	var fields = strings.Fields(authorization)
+
+	if len(fields) != 2 || !strings.EqualFold(fields[0], bearerScheme) {
+		return ``, ErrMissingToken
+	}
+
+	return fields[1], nil
+}
+
+func (authenticator anonymousAuthenticator) Authenticate(ctx context.Context, authorization string) (*Principal, error) {
+	return nil, nil
+}
+
+// HasRole reports whether principal was granted role
+func (principal Principal) HasRole(role string) bool {
+	for _, granted := range principal.Roles {
+		if granted == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Permits reports whether principal may perform operations requiring scope
+func (principal Principal) Permits(scope string) bool {
+	if principal.Scopes == nil {
+		return true
+	}
+
+	for _, granted := range principal.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (principal Principal) String() string {
+	return fmt.Sprintf(`{Subject: %s, Issuer: %s, Audience: %v, Roles: %v, Tenant: %s, Scopes: %v, ExpiresAt: %s}`, principal.Subject, principal.Issuer, principal.Audience, principal.Roles, principal.Tenant, principal.Scopes, principal.ExpiresAt)
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
@@ -0,0 +1,298 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package auth
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth/authtest"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+func newTestVerifier(test *testing.T, keys *authtest.Keys) *Verifier {
+	if verifier, err := NewVerifier(Parameters{JWKS: keys.Path, Issuer: authtest.Issuer, Audience: authtest.Audience}); err != nil {
+		test.Fatalf(`unable to create the verifier: %s`, err)
+		return nil
+	} else {
+		return verifier
+	}
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestVerifyAlgorithms(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+	var verifier *Verifier
+
+	//-- Test Parameters ----------
+	var subject = `test subject`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	verifier = newTestVerifier(test, keys)
+
+	//-- Action ----------
+
+	//-- Post-conditions ----------
+	for _, token := range []string{keys.HS256(test, authtest.Valid(subject)), keys.RS256(test, authtest.Valid(subject))} {
+		if principal, err := verifier.Verify(context.Background(), token); assert.Nil(test, err) {
+			assert.Equal(test, subject, principal.Subject)
+			assert.Equal(test, authtest.Issuer, principal.Issuer)
+			assert.Equal(test, []string{authtest.Audience}, principal.Audience)
+			assert.Equal(test, subject, principal.Claims[`sub`])
+			assert.False(test, principal.ExpiresAt.IsZero())
+		}
+	}
+}
+
+func TestVerifyClaims(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+	var verifier *Verifier
+
+	//-- Test Parameters ----------
+	var now = time.Now()
+	var cases = map[string]struct {
+		change func(claims authtest.Claims)
+		err    error
+	}{
+		`expired`:          {func(claims authtest.Claims) { claims[`exp`] = now.Add(-time.Hour).Unix() }, ErrExpired},
+		`no expiry`:        {func(claims authtest.Claims) { delete(claims, `exp`) }, ErrExpired},
+		`within leeway`:    {func(claims authtest.Claims) { claims[`exp`] = now.Add(-DefaultLeeway / 2).Unix() }, nil},
+		`not yet valid`:    {func(claims authtest.Claims) { claims[`nbf`] = now.Add(time.Hour).Unix() }, ErrNotYetValid},
+		`wrong issuer`:     {func(claims authtest.Claims) { claims[`iss`] = `https://elsewhere.test` }, ErrIssuer},
+		`wrong audience`:   {func(claims authtest.Claims) { claims[`aud`] = `another-service` }, ErrAudience},
+		`audience list`:    {func(claims authtest.Claims) { claims[`aud`] = []string{`another-service`, authtest.Audience} }, nil},
+		`malformed expiry`: {func(claims authtest.Claims) { claims[`exp`] = `tomorrow` }, ErrMalformed},
+		`no subject`:       {func(claims authtest.Claims) { delete(claims, `sub`) }, ErrSubject},
+		`single role`:      {func(claims authtest.Claims) { claims[`roles`] = `admin` }, nil},
+		`malformed tenant`: {func(claims authtest.Claims) { claims[`tenant_id`] = 7 }, ErrMalformed},
+		`malformed roles`:  {func(claims authtest.Claims) { claims[`roles`] = 7 }, ErrMalformed},
+	}
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	verifier = newTestVerifier(test, keys)
+
+	//-- Action ----------
+
+	//-- Post-conditions ----------
+	for name, testCase := range cases {
+		var claims = authtest.Valid(`test subject`)
+		testCase.change(claims)
+
+		var _, err = verifier.Verify(context.Background(), keys.HS256(test, claims))
+		assert.Equal(test, testCase.err, err, name)
+	}
+}
+
+func TestVerifyRejectsForgeries(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys, others *authtest.Keys
+	var verifier *Verifier
+
+	//-- Test Parameters ----------
+	var claims = authtest.Valid(`test subject`)
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	others = authtest.NewKeys(test)
+	verifier = newTestVerifier(test, keys)
+
+	var valid = keys.HS256(test, claims)
+	var parts = strings.Split(valid, `.`)
+
+	var cases = map[string]struct {
+		token string
+		err   error
+	}{
+		`other secret`:  {others.HS256(test, claims), ErrSignature},
+		`other key`:     {others.RS256(test, claims), ErrSignature},
+		`alg none`:      {`eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0.` + parts[1] + `.`, ErrAlgorithm},
+		`alg swapped`:   {`eyJhbGciOiJSUzI1NiIsImtpZCI6InRlc3QtaG1hYyJ9.` + parts[1] + `.` + parts[2], ErrUnknownKey},
+		`unknown kid`:   {`eyJhbGciOiJIUzI1NiIsImtpZCI6Im1pc3NpbmcifQ.` + parts[1] + `.` + parts[2], ErrUnknownKey},
+		`two segments`:  {parts[0] + `.` + parts[1], ErrMalformed},
+		`bad encoding`:  {parts[0] + `.!!!.` + parts[2], ErrMalformed},
+		`tampered body`: {parts[0] + `.` + strings.TrimSuffix(parts[1], parts[1][len(parts[1])-2:]) + `.` + parts[2], nil},
+	}
+
+	//-- Action ----------
+
+	//-- Post-conditions ----------
+	for name, testCase := range cases {
+		var principal, err = verifier.Verify(context.Background(), testCase.token)
+
+		assert.Nil(test, principal, name)
+		if testCase.err != nil {
+			assert.Equal(test, testCase.err, err, name)
+		}
+		assert.True(test, errors.Is(err, ErrUnauthenticated), name)
+	}
+}
+
+func TestAuthenticate(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+	var authenticator Authenticator
+	var createErr error
+
+	//-- Test Parameters ----------
+	var subject = `test subject`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+	authenticator, createErr = NewAuthenticator(Parameters{JWKS: keys.Path, Audience: authtest.Audience})
+
+	//-- Action ----------
+	var claims = authtest.Valid(subject)
+	claims[`roles`] = []string{`member`, `admin`}
+	claims[`tenant_id`] = `test-tenant`
+
+	var principal, err = authenticator.Authenticate(context.Background(), `bearer `+keys.RS256(test, claims))
+	var _, missingErr = authenticator.Authenticate(context.Background(), ``)
+	var _, schemeErr = authenticator.Authenticate(context.Background(), `Basic dXNlcjpwYXNz`)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, createErr)
+	assert.Nil(test, err)
+	assert.Equal(test, subject, principal.Subject)
+	assert.True(test, principal.HasRole(`admin`))
+	assert.False(test, principal.HasRole(`auditor`))
+	assert.Equal(test, `test-tenant`, principal.Tenant)
+	assert.Equal(test, ErrMissingToken, missingErr)
+	assert.Equal(test, ErrMissingToken, schemeErr)
+}
+
+func TestNewAuthenticatorConfiguration(test *testing.T) {
+	//-- Shared Variables ----------
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	var disabled, disabledErr = NewAuthenticator(Parameters{Disabled: true})
+	var _, missingErr = NewAuthenticator(Parameters{})
+	var _, fileErr = NewAuthenticator(Parameters{JWKS: `/does/not/exist.json`})
+
+	//-- Post-conditions ----------
+	assert.Nil(test, disabledErr)
+	if principal, err := disabled.Authenticate(context.Background(), ``); assert.Nil(test, err) {
+		assert.Nil(test, principal)
+	}
+
+	assert.Equal(test, ErrNotConfigured, missingErr)
+	assert.NotNil(test, fileErr)
+}
+
+func TestRemoteKeys(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+	var server *httptest.Server
+	var fetches int32
+	var verifier *Verifier
+
+	//-- Test Parameters ----------
+	var subject = `test subject`
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+
+	server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		_, _ = writer.Write(keys.JWKS())
+	}))
+	defer server.Close()
+
+	if created, err := NewVerifier(Parameters{JWKS: server.URL}); err != nil {
+		test.Fatalf(`unable to create the verifier: %s`, err)
+	} else {
+		verifier = created
+	}
+
+	//-- Action ----------
+	var first, firstErr = verifier.Verify(context.Background(), keys.RS256(test, authtest.Valid(subject)))
+	var second, secondErr = verifier.Verify(context.Background(), keys.HS256(test, authtest.Valid(subject)))
+
+	//-- Post-conditions ----------
+	assert.Nil(test, firstErr)
+	assert.Nil(test, secondErr)
+	assert.Equal(test, subject, first.Subject)
+	assert.Equal(test, subject, second.Subject)
+	assert.Equal(test, int32(1), atomic.LoadInt32(&fetches))
+}
+
+func TestRemoteKeysUnavailable(test *testing.T) {
+	//-- Shared Variables ----------
+	var keys *authtest.Keys
+	var server *httptest.Server
+	var verifier *Verifier
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	keys = authtest.NewKeys(test)
+
+	server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	verifier, _ = NewVerifier(Parameters{JWKS: server.URL})
+
+	//-- Action ----------
+	var _, err = verifier.Verify(context.Background(), keys.HS256(test, authtest.Valid(`test subject`)))
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, err)
+	assert.False(test, errors.Is(err, ErrUnauthenticated))
+}
+
+func TestPrincipalContext(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var principal = &Principal{Subject: `test subject`}
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	//-- Action ----------
+	var carried = WithPrincipal(ctx, principal)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, PrincipalFrom(ctx))
+	assert.Equal(test, principal, PrincipalFrom(carried))
+}
+
+func TestPrincipalPermits(test *testing.T) {
+	//-- Shared Variables ----------
+
+	//-- Test Parameters ----------
+	var unlimited = Principal{Subject: `test subject`}
+	var limited = Principal{Subject: `test subject`, Scopes: []string{`tasks:read`}}
+	var none = Principal{Subject: `test subject`, Scopes: []string{}}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+
+	//-- Post-conditions ----------
+	assert.True(test, unlimited.Permits(`tasks:write`))
+	assert.True(test, limited.Permits(`tasks:read`))
+	assert.False(test, limited.Permits(`tasks:write`))
+	assert.False(test, none.Permits(`tasks:read`))
+}
@@ -0,0 +1,126 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package authtest issues signed JWTs and writes the JWKS document which verifies them, so tests can exercise
+// authentication without an identity provider.
+package authtest
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	HMACKeyID = `test-hmac`
+	RSAKeyID  = `test-rsa`
+
+	Issuer   = `https://issuer.test`
+	Audience = `task-service-test`
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Claims are the claims of an issued token, they are encoded exactly as given
+type Claims map[string]interface{}
+
+// Keys holds an HS256 secret and an RS256 key pair, along with the path of a JWKS file which publishes both
+type Keys struct {
+	Secret  []byte
+	Private *rsa.PrivateKey
+	Path    string
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// NewKeys generates fresh keys and writes their JWKS document into a temporary directory of test
+func NewKeys(test *testing.T) *Keys {
+	var keys = &Keys{Secret: make([]byte, 32)}
+
+	if _, err := rand.Read(keys.Secret); err != nil {
+		test.Fatalf(`unable to generate a secret: %s`, err)
+	} else if private, err := rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		test.Fatalf(`unable to generate an RSA key: %s`, err)
+	} else {
+		keys.Private = private
+	}
+
+	keys.Path = filepath.Join(test.TempDir(), `jwks.json`)
+	if err := os.WriteFile(keys.Path, keys.JWKS(), 0600); err != nil {
+		test.Fatalf(`unable to write the key set: %s`, err)
+	}
+
+	return keys
+}
+
+// JWKS returns the document publishing both keys
+func (keys *Keys) JWKS() []byte {
+	var document, _ = json.Marshal(map[string]interface{}{
+		`keys`: []map[string]string{
+			{`kty`: `oct`, `kid`: HMACKeyID, `alg`: `HS256`, `use`: `sig`, `k`: encode(keys.Secret)},
+			{`kty`: `RSA`, `kid`: RSAKeyID, `alg`: `RS256`, `use`: `sig`, `n`: encode(keys.Private.N.Bytes()), `e`: encode(big.NewInt(int64(keys.Private.E)).Bytes())},
+		},
+	})
+
+	return document
+}
+
+// HS256 signs claims with the secret
+func (keys *Keys) HS256(test *testing.T, claims Claims) string {
+	var input = signingInput(test, map[string]string{`alg`: `HS256`, `typ`: `JWT`, `kid`: HMACKeyID}, claims)
+	var mac = hmac.New(sha256.New, keys.Secret)
+	mac.Write([]byte(input))
+
+	return input + `.` + encode(mac.Sum(nil))
+}
+
+// RS256 signs claims with the private key
+func (keys *Keys) RS256(test *testing.T, claims Claims) string {
+	var input = signingInput(test, map[string]string{`alg`: `RS256`, `typ`: `JWT`, `kid`: RSAKeyID}, claims)
+	var digest = sha256.Sum256([]byte(input))
+
+	if signature, err := rsa.SignPKCS1v15(rand.Reader, keys.Private, crypto.SHA256, digest[:]); err != nil {
+		test.Fatalf(`unable to sign the token: %s`, err)
+		return ``
+	} else {
+		return input + `.` + encode(signature)
+	}
+}
+
+// Valid returns claims for subject which pass every check made against Issuer and Audience for the next hour
+func Valid(subject string) Claims {
+	var now = time.Now()
+
+	return Claims{
+		`sub`: subject,
+		`iss`: Issuer,
+		`aud`: Audience,
+		`iat`: now.Unix(),
+		`nbf`: now.Unix(),
+		`exp`: now.Add(time.Hour).Unix(),
+	}
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+func signingInput(test *testing.T, header map[string]string, claims Claims) string {
+	if encodedHeader, err := json.Marshal(header); err != nil {
+		test.Fatalf(`unable to encode the header: %s`, err)
+		return ``
+	} else if encodedClaims, err := json.Marshal(claims); err != nil {
+		test.Fatalf(`unable to encode the claims: %s`, err)
+		return ``
+	} else {
+		return encode(encodedHeader) + `.` + encode(encodedClaims)
+	}
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
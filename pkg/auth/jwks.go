@@ -0,0 +1,215 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package auth
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// minimumRSABits rejects RSA keys too short to be trusted
+	minimumRSABits = 2048
+
+	// keySetTTL is how long a fetched JWKS is used before it is fetched again
+	keySetTTL = 15 * time.Minute
+
+	// refreshInterval limits how often a token naming an unknown key can cause the JWKS to be fetched early
+	refreshInterval = time.Minute
+
+	// maximumKeySetBytes bounds the size of a JWKS document
+	maximumKeySetBytes = 1 << 20
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// jsonWebKey is a single verification key of a JWKS document, secret is set for HS256 keys and public for RS256 keys
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+	Secret    string `json:"k"`
+
+	secret []byte
+	public *rsa.PublicKey
+}
+
+type keySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// keySource finds the key which signed a token by its kid, or the only key for the algorithm when the token has no kid
+type keySource interface {
+	key(ctx context.Context, id string, algorithm string) (*jsonWebKey, error)
+}
+
+// staticKeys serves the keys of a JWKS file, read once when the Verifier is created
+type staticKeys struct {
+	keys []jsonWebKey
+}
+
+// remoteKeys serves the keys of a JWKS URL, fetched on first use and cached for keySetTTL
+type remoteKeys struct {
+	url    string
+	client *http.Client
+
+	lock    *sync.Mutex
+	keys    []jsonWebKey
+	fetched time.Time
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+func newKeySource(location string) (keySource, error) {
+	if strings.HasPrefix(location, `https://`) || strings.HasPrefix(location, `http://`) {
+		return &remoteKeys{url: location, client: &http.Client{Timeout: 5 * time.Second}, lock: &sync.Mutex{}}, nil
+	}
+
+	if file, err := os.Open(strings.TrimPrefix(location, `file://`)); err != nil {
+		return nil, err
+	} else {
+		defer file.Close()
+
+		if keys, err := parseKeySet(file); err != nil {
+			return nil, err
+		} else {
+			return staticKeys{keys: keys}, nil
+		}
+	}
+}
+
+// parseKeySet reads a JWKS document, keys of other types or for other uses are skipped rather than rejected
+func parseKeySet(reader io.Reader) ([]jsonWebKey, error) {
+	var document keySet
+	var keys = make([]jsonWebKey, 0)
+
+	if err := json.NewDecoder(io.LimitReader(reader, maximumKeySetBytes)).Decode(&document); err != nil {
+		return nil, errors.New(fmt.Sprintf(`jwks - the key set is malformed: %s`, err))
+	}
+
+	for _, key := range document.Keys {
+		if len(key.Use) > 0 && key.Use != `sig` {
+			continue
+		}
+
+		switch key.KeyType {
+		case `oct`:
+			if secret, err := base64.RawURLEncoding.DecodeString(key.Secret); err != nil || len(secret) < 32 {
+				return nil, errors.New(fmt.Sprintf(`jwks - the secret of key '%s' must be base64url encoded and at least 256 bits`, key.KeyID))
+			} else {
+				key.secret = secret
+			}
+		case `RSA`:
+			if modulus, err := base64.RawURLEncoding.DecodeString(key.Modulus); err != nil {
+				return nil, errors.New(fmt.Sprintf(`jwks - the modulus of key '%s' is malformed`, key.KeyID))
+			} else if exponent, err := base64.RawURLEncoding.DecodeString(key.Exponent); err != nil || len(exponent) == 0 || len(exponent) > 4 {
+				return nil, errors.New(fmt.Sprintf(`jwks - the exponent of key '%s' is malformed`, key.KeyID))
+			} else {
+				key.public = &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: int(new(big.Int).SetBytes(exponent).Int64())}
+			}
+
+			if key.public.N.BitLen() < minimumRSABits {
+				return nil, errors.New(fmt.Sprintf(`jwks - key '%s' must be at least %d bits`, key.KeyID, minimumRSABits))
+			}
+		default:
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// find picks the key for a token from keys, a key must suit algorithm by its type and by its alg when it declares one
+func find(keys []jsonWebKey, id string, algorithm string) (*jsonWebKey, error) {
+	var candidates = make([]jsonWebKey, 0, 1)
+
+	for _, key := range keys {
+		if len(id) > 0 && key.KeyID != id {
+			continue
+		} else if !key.suits(algorithm) {
+			continue
+		}
+		candidates = append(candidates, key)
+	}
+
+	if len(candidates) != 1 {
+		return nil, ErrUnknownKey
+	}
+
+	return &candidates[0], nil
+}
+
+func (key jsonWebKey) suits(algorithm string) bool {
+	if len(key.Algorithm) > 0 && key.Algorithm != algorithm {
+		return false
+	}
+
+	switch algorithm {
+	case HS256:
+		return key.secret != nil
+	case RS256:
+		return key.public != nil
+	}
+	return false
+}
+
+func (source staticKeys) key(ctx context.Context, id string, algorithm string) (*jsonWebKey, error) {
+	return find(source.keys, id, algorithm)
+}
+
+// key fetches the key set when the cache is empty or stale, and early when no cached key matches so rotated keys are
+// picked up, a failed fetch keeps serving the keys already cached
+func (source *remoteKeys) key(ctx context.Context, id string, algorithm string) (*jsonWebKey, error) {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+
+	var age = time.Since(source.fetched)
+
+	if source.keys == nil || age > keySetTTL {
+		if err := source.fetch(ctx); err != nil && source.keys == nil {
+			return nil, err
+		}
+	} else if _, err := find(source.keys, id, algorithm); err != nil && age > refreshInterval {
+		_ = source.fetch(ctx)
+	}
+
+	return find(source.keys, id, algorithm)
+}
+
+func (source *remoteKeys) fetch(ctx context.Context) error {
+	if request, err := http.NewRequestWithContext(ctx, http.MethodGet, source.url, nil); err != nil {
+		return err
+	} else if response, err := source.client.Do(request); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			return errors.New(fmt.Sprintf(`jwks - fetching '%s' responded %d`, source.url, response.StatusCode))
+		} else if keys, err := parseKeySet(response.Body); err != nil {
+			return err
+		} else {
+			source.keys = keys
+			source.fetched = time.Now()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package auth
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"strings"
+	"time"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	HS256 = `HS256`
+	RS256 = `RS256`
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Verifier checks the signature, lifetime, issuer and audience of JWTs against the keys of a JWKS document
+type Verifier struct {
+	keys     keySource
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// claims are the registered claims the Verifier checks along with the roles granted and the tenant named, every claim
+// is also kept in Principal.Claims
+type claims struct {
+	Subject   string       `json:"sub"`
+	Issuer    string       `json:"iss"`
+	Audience  audience     `json:"aud"`
+	ExpiresAt *numericDate `json:"exp"`
+	NotBefore *numericDate `json:"nbf"`
+	Roles     audience     `json:"roles"`
+	Tenant    string       `json:"tenant_id"`
+}
+
+// audience accepts the single string or the array of strings the aud and roles claims may hold
+type audience []string
+
+// numericDate is a JWT timestamp in seconds since the epoch, which may have a fractional part
+type numericDate float64
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+func NewVerifier(parameters Parameters) (*Verifier, error) {
+	var verifier = &Verifier{
+		issuer:   parameters.Issuer,
+		audience: parameters.Audience,
+		leeway:   parameters.Leeway,
+		now:      time.Now,
+	}
+
+	if verifier.leeway == 0 {
+		verifier.leeway = DefaultLeeway
+	}
+
+	if source, err := newKeySource(parameters.JWKS); err != nil {
+		return nil, err
+	} else {
+		verifier.keys = source
+	}
+
+	return verifier, nil
+}
+
+// Authenticate verifies the bearer token of authorization
+func (verifier *Verifier) Authenticate(ctx context.Context, authorization string) (*Principal, error) {
+	if token, err := BearerToken(authorization); err != nil {
+		return nil, err
+	} else {
+		return verifier.Verify(ctx, token)
+	}
+}
+
+// Verify checks token and returns the Principal it names. The algorithm is taken from the key as well as the token, so
+// a token can never be checked with a key meant for another algorithm.
+func (verifier *Verifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	var parts = strings.Split(token, `.`)
+	var tokenHeader header
+	var registered claims
+	var everything map[string]interface{}
+
+	//-- Decode ----------
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	} else if err := decodeSegment(parts[0], &tokenHeader); err != nil {
+		return nil, err
+	} else if err := decodeSegment(parts[1], &registered); err != nil {
+		return nil, err
+	} else if err := decodeSegment(parts[1], &everything); err != nil {
+		return nil, err
+	}
+
+	//-- Signature ----------
+	if signature, err := base64.RawURLEncoding.DecodeString(parts[2]); err != nil {
+		return nil, ErrMalformed
+	} else if tokenHeader.Algorithm != HS256 && tokenHeader.Algorithm != RS256 {
+		return nil, ErrAlgorithm
+	} else if key, err := verifier.keys.key(ctx, tokenHeader.KeyID, tokenHeader.Algorithm); err != nil {
+		return nil, err
+	} else if err := key.verify(tokenHeader.Algorithm, []byte(parts[0]+`.`+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	//-- Claims ----------
+	var now = verifier.now()
+
+	if registered.ExpiresAt == nil || now.After(registered.ExpiresAt.time().Add(verifier.leeway)) {
+		return nil, ErrExpired
+	} else if registered.NotBefore != nil && now.Before(registered.NotBefore.time().Add(-verifier.leeway)) {
+		return nil, ErrNotYetValid
+	} else if len(verifier.issuer) > 0 && registered.Issuer != verifier.issuer {
+		return nil, ErrIssuer
+	} else if len(verifier.audience) > 0 && !registered.Audience.contains(verifier.audience) {
+		return nil, ErrAudience
+	} else if len(registered.Subject) == 0 {
+		return nil, ErrSubject
+	}
+
+	return &Principal{
+		Subject:   registered.Subject,
+		Issuer:    registered.Issuer,
+		Audience:  registered.Audience,
+		Roles:     registered.Roles,
+		Tenant:    registered.Tenant,
+		ExpiresAt: registered.ExpiresAt.time(),
+		Claims:    everything,
+	}, nil
+}
+
+func (target *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	var many []string
+
+	if err := json.Unmarshal(data, &single); err == nil {
+		*target = audience{single}
+		return nil
+	} else if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+
+	*target = many
+	return nil
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// decodeSegment reads a base64url encoded JSON segment of a token into target, rejecting trailing data
+func decodeSegment(segment string, target interface{}) error {
+	if data, err := base64.RawURLEncoding.DecodeString(segment); err != nil {
+		return ErrMalformed
+	} else if decoder := json.NewDecoder(bytes.NewReader(data)); decoder.Decode(target) != nil || decoder.More() {
+		return ErrMalformed
+	}
+	return nil
+}
+
+func (key jsonWebKey) verify(algorithm string, input []byte, signature []byte) error {
+	switch algorithm {
+	case HS256:
+		var mac = hmac.New(sha256.New, key.secret)
+		mac.Write(input)
+
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return ErrSignature
+		}
+	case RS256:
+		var digest = sha256.Sum256(input)
+
+		if err := rsa.VerifyPKCS1v15(key.public, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrSignature
+		}
+	default:
+		return ErrAlgorithm
+	}
+
+	return nil
+}
+
+func (values audience) contains(value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (date numericDate) time() time.Time {
+	var seconds, fraction = math.Modf(float64(date))
+	return time.Unix(int64(seconds), int64(fraction*float64(time.Second)))
+}
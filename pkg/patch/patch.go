@@ -0,0 +1,481 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package patch applies the two JSON patch formats a client may send in place of a whole resource, a JSON Merge Patch
+// (RFC 7396) which overlays the document with an object of changes and a JSON Patch (RFC 6902) which runs a list of
+// operations against it. Both work on the encoded document, so the caller decides which members a patch can reach by
+// choosing what to encode.
+package patch
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	MergeMediaType = `application/merge-patch+json`
+	JSONMediaType  = `application/json-patch+json`
+
+	// endToken names the position after the last element of an array, only an add may target it
+	endToken = `-`
+)
+
+var (
+	ErrUnsupported = errors.New(`the media type is not a supported patch format`)
+	ErrMalformed   = errors.New(`the patch is malformed`)
+	ErrPath        = errors.New(`the patch can not be applied to the document`)
+	ErrTestFailed  = errors.New(`a test operation of the patch failed`)
+
+	// escape and unescape encode and decode the characters of a JSON Pointer reference token, ~1 before ~0 as RFC 6901
+	// requires
+	escape   = strings.NewReplacer(`~`, `~0`, `/`, `~1`)
+	unescape = strings.NewReplacer(`~1`, `/`, `~0`, `~`)
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Patch is a parsed patch which can be applied to any number of documents, the document given to Apply is not changed
+type Patch interface {
+	Apply(document []byte) ([]byte, error)
+}
+
+// mergePatch is a JSON Merge Patch, the decoded value overlaid on the document
+type mergePatch struct {
+	value interface{}
+}
+
+// jsonPatch is a JSON Patch, the operations are run in order and the first to fail abandons the patch
+type jsonPatch []operation
+
+// operation is a single JSON Patch operation, from is only set for a move or copy and value for an add, replace or test
+type operation struct {
+	op    string
+	path  pointer
+	from  pointer
+	value interface{}
+}
+
+// pointer is a parsed JSON Pointer (RFC 6901), the reference tokens in order and empty for the whole document
+type pointer []string
+
+// encodedOperation is a JSON Patch operation as sent, a missing value is told apart from a null one by its length
+type encodedOperation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// Parse reads body as a patch of mediaType, MergeMediaType or JSONMediaType. A patch which is not valid JSON, or a JSON
+// Patch with an unknown operation or a missing or malformed member, is ErrMalformed and other media types are
+// ErrUnsupported.
+func Parse(mediaType string, body []byte) (Patch, error) {
+	switch mediaType {
+	case MergeMediaType:
+		if value, err := decode(body); err != nil {
+			return nil, fmt.Errorf(`%w: %s`, ErrMalformed, err)
+		} else {
+			return mergePatch{value: value}, nil
+		}
+	case JSONMediaType:
+		return parseOperations(body)
+	default:
+		return nil, fmt.Errorf(`%w: '%s'`, ErrUnsupported, mediaType)
+	}
+}
+
+// Apply overlays document with the patch, a null member removes the member it names and any other value replaces it
+// except for an object, which is merged into the member in the same way
+func (patch mergePatch) Apply(document []byte) ([]byte, error) {
+	if target, err := decode(document); err != nil {
+		return nil, err
+	} else {
+		return json.Marshal(merge(target, patch.value))
+	}
+}
+
+// Apply runs every operation against document, an operation naming a location the document does not have is ErrPath
+// and a test which does not match is ErrTestFailed
+func (patch jsonPatch) Apply(document []byte) ([]byte, error) {
+	var target, err = decode(document)
+	if err != nil {
+		return nil, err
+	}
+
+	for index, operation := range patch {
+		if target, err = operation.apply(target); err != nil {
+			return nil, fmt.Errorf(`operation %d (%s) - %w`, index, operation.op, err)
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// decode reads a single JSON value, keeping numbers as json.Number so they are written back exactly as they were read
+func decode(data []byte) (interface{}, error) {
+	var value interface{}
+	var decoder = json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	} else if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New(`unexpected data after the JSON value`)
+	}
+
+	return value, nil
+}
+
+// parseOperations reads and checks every operation of a JSON Patch
+func parseOperations(body []byte) (Patch, error) {
+	var encoded []encodedOperation
+	var patch = make(jsonPatch, 0)
+
+	if err := json.Unmarshal(body, &encoded); err != nil {
+		return nil, fmt.Errorf(`%w: a JSON Patch is an array of operation objects, %s`, ErrMalformed, err)
+	}
+
+	for index, item := range encoded {
+		var parsed = operation{op: item.Op}
+		var err error
+
+		if item.Path == nil {
+			return nil, fmt.Errorf(`%w: operation %d (%s) - path is required`, ErrMalformed, index, item.Op)
+		} else if parsed.path, err = parsePointer(*item.Path); err != nil {
+			return nil, fmt.Errorf(`%w: operation %d (%s) - path %s`, ErrMalformed, index, item.Op, err)
+		}
+
+		switch item.Op {
+		case `add`, `replace`, `test`:
+			if len(item.Value) == 0 {
+				return nil, fmt.Errorf(`%w: operation %d (%s) - value is required`, ErrMalformed, index, item.Op)
+			} else if parsed.value, err = decode(item.Value); err != nil {
+				return nil, fmt.Errorf(`%w: operation %d (%s) - value %s`, ErrMalformed, index, item.Op, err)
+			}
+		case `move`, `copy`:
+			if item.From == nil {
+				return nil, fmt.Errorf(`%w: operation %d (%s) - from is required`, ErrMalformed, index, item.Op)
+			} else if parsed.from, err = parsePointer(*item.From); err != nil {
+				return nil, fmt.Errorf(`%w: operation %d (%s) - from %s`, ErrMalformed, index, item.Op, err)
+			} else if item.Op == `move` && parsed.from.contains(parsed.path) {
+				return nil, fmt.Errorf(`%w: operation %d (move) - a value can not be moved into itself`, ErrMalformed, index)
+			}
+		case `remove`:
+		default:
+			return nil, fmt.Errorf(`%w: operation %d - '%s' is not an operation`, ErrMalformed, index, item.Op)
+		}
+
+		patch = append(patch, parsed)
+	}
+
+	return patch, nil
+}
+
+// parsePointer reads a JSON Pointer, the empty string names the whole document and anything else starts with a slash
+func parsePointer(path string) (pointer, error) {
+	if len(path) == 0 {
+		return pointer{}, nil
+	} else if !strings.HasPrefix(path, `/`) {
+		return nil, errors.New(fmt.Sprintf(`'%s' is not a JSON Pointer, it must start with a slash`, path))
+	}
+
+	var tokens = strings.Split(path[1:], `/`)
+	for index, token := range tokens {
+		if strings.Count(token, `~`) != strings.Count(token, `~0`)+strings.Count(token, `~1`) {
+			return nil, errors.New(fmt.Sprintf(`'%s' holds a ~ which is not escaped as ~0`, path))
+		}
+		tokens[index] = unescape.Replace(token)
+	}
+
+	return tokens, nil
+}
+
+// contains reports whether other is a location inside the one named by path, rather than path itself
+func (path pointer) contains(other pointer) bool {
+	if len(other) <= len(path) {
+		return false
+	}
+
+	for index, token := range path {
+		if other[index] != token {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String is the JSON Pointer which names path
+func (path pointer) String() string {
+	var output strings.Builder
+
+	for _, token := range path {
+		output.WriteString(`/` + escape.Replace(token))
+	}
+
+	return output.String()
+}
+
+// merge is target with patch overlaid as RFC 7396 describes, objects of target are changed in place
+func merge(target interface{}, patch interface{}) interface{} {
+	var changes, ok = patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	var object, isObject = target.(map[string]interface{})
+	if !isObject {
+		object = make(map[string]interface{})
+	}
+
+	for name, value := range changes {
+		if value == nil {
+			delete(object, name)
+		} else {
+			object[name] = merge(object[name], value)
+		}
+	}
+
+	return object
+}
+
+// apply runs the operation against document and returns the changed document
+func (operation operation) apply(document interface{}) (interface{}, error) {
+	switch operation.op {
+	case `add`:
+		return add(document, operation.path, clone(operation.value))
+	case `remove`:
+		var result, _, err = remove(document, operation.path)
+		return result, err
+	case `replace`:
+		if _, err := get(document, operation.path); err != nil {
+			return nil, err
+		} else if len(operation.path) == 0 {
+			return clone(operation.value), nil
+		} else if result, _, err := remove(document, operation.path); err != nil {
+			return nil, err
+		} else {
+			return add(result, operation.path, clone(operation.value))
+		}
+	case `move`:
+		if result, value, err := remove(document, operation.from); err != nil {
+			return nil, err
+		} else {
+			return add(result, operation.path, value)
+		}
+	case `copy`:
+		if value, err := get(document, operation.from); err != nil {
+			return nil, err
+		} else {
+			return add(document, operation.path, clone(value))
+		}
+	case `test`:
+		if value, err := get(document, operation.path); err != nil {
+			return nil, err
+		} else if !equal(value, operation.value) {
+			return nil, fmt.Errorf(`%w: the value at '%s' does not match`, ErrTestFailed, operation.path)
+		}
+		return document, nil
+	default:
+		return nil, fmt.Errorf(`%w: '%s' is not an operation`, ErrMalformed, operation.op)
+	}
+}
+
+// get is the value path names in document
+func get(document interface{}, path pointer) (interface{}, error) {
+	var value = document
+
+	for depth, token := range path {
+		switch container := value.(type) {
+		case map[string]interface{}:
+			var member, ok = container[token]
+			if !ok {
+				return nil, fmt.Errorf(`%w: '%s' does not exist`, ErrPath, path[:depth+1])
+			}
+			value = member
+		case []interface{}:
+			var index, err = indexOf(token, len(container)-1)
+			if err != nil {
+				return nil, fmt.Errorf(`%w: '%s' %s`, ErrPath, path[:depth+1], err)
+			}
+			value = container[index]
+		default:
+			return nil, fmt.Errorf(`%w: '%s' is not an object or array`, ErrPath, path[:depth])
+		}
+	}
+
+	return value, nil
+}
+
+// add puts value at path, a new member of an object or an element inserted into an array, the whole document when
+// path is empty. The object or array holding it must exist.
+func add(document interface{}, path pointer, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return edit(document, path, func(container interface{}, token string) (interface{}, error) {
+		switch container := container.(type) {
+		case map[string]interface{}:
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			var index = len(container)
+			if token != endToken {
+				var err error
+				if index, err = indexOf(token, len(container)); err != nil {
+					return nil, fmt.Errorf(`%w: '%s' %s`, ErrPath, path, err)
+				}
+			}
+
+			var inserted = make([]interface{}, 0, len(container)+1)
+			inserted = append(inserted, container[:index]...)
+			inserted = append(inserted, value)
+			return append(inserted, container[index:]...), nil
+		default:
+			return nil, fmt.Errorf(`%w: '%s' is not an object or array`, ErrPath, path[:len(path)-1])
+		}
+	})
+}
+
+// remove takes the value at path out of document and returns both, the whole document can not be removed
+func remove(document interface{}, path pointer) (interface{}, interface{}, error) {
+	var removed interface{}
+
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf(`%w: the whole document can not be removed`, ErrPath)
+	}
+
+	var result, err = edit(document, path, func(container interface{}, token string) (interface{}, error) {
+		switch container := container.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if removed, ok = container[token]; !ok {
+				return nil, fmt.Errorf(`%w: '%s' does not exist`, ErrPath, path)
+			}
+			delete(container, token)
+			return container, nil
+		case []interface{}:
+			var index, err = indexOf(token, len(container)-1)
+			if err != nil {
+				return nil, fmt.Errorf(`%w: '%s' %s`, ErrPath, path, err)
+			}
+			removed = container[index]
+			return append(container[:index:index], container[index+1:]...), nil
+		default:
+			return nil, fmt.Errorf(`%w: '%s' is not an object or array`, ErrPath, path[:len(path)-1])
+		}
+	})
+
+	return result, removed, err
+}
+
+// edit finds the object or array holding the last token of path, every one on the way must exist, and puts what change
+// returns for it back in its place
+func edit(document interface{}, path pointer, change func(container interface{}, token string) (interface{}, error)) (interface{}, error) {
+	var holder = path[:len(path)-1]
+
+	if container, err := get(document, holder); err != nil {
+		return nil, err
+	} else if changed, err := change(container, path[len(path)-1]); err != nil {
+		return nil, err
+	} else {
+		return set(document, holder, changed), nil
+	}
+}
+
+// set replaces the value at path, which must exist, with value and returns the document
+func set(document interface{}, path pointer, value interface{}) interface{} {
+	if len(path) == 0 {
+		return value
+	}
+
+	var token = path[len(path)-1]
+	var holder, _ = get(document, path[:len(path)-1])
+
+	switch holder := holder.(type) {
+	case map[string]interface{}:
+		holder[token] = value
+	case []interface{}:
+		var index, _ = indexOf(token, len(holder)-1)
+		holder[index] = value
+	}
+
+	return document
+}
+
+// indexOf reads token as an array index no greater than limit, without a sign or leading zeros as RFC 6901 requires
+func indexOf(token string, limit int) (int, error) {
+	if len(token) == 0 || len(token) > 1 && token[0] == '0' || strings.TrimLeft(token, `0123456789`) != `` {
+		return 0, errors.New(fmt.Sprintf(`'%s' is not an array index`, token))
+	} else if index, err := strconv.Atoi(token); err != nil || index > limit {
+		return 0, errors.New(`is beyond the end of the array`)
+	} else {
+		return index, nil
+	}
+}
+
+// clone deep copies a decoded value so operations never share objects or arrays between locations
+func clone(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		var copied = make(map[string]interface{}, len(value))
+		for name, member := range value {
+			copied[name] = clone(member)
+		}
+		return copied
+	case []interface{}:
+		var copied = make([]interface{}, len(value))
+		for index, element := range value {
+			copied[index] = clone(element)
+		}
+		return copied
+	default:
+		return value
+	}
+}
+
+// equal compares decoded values as RFC 6902 tests them, numbers by their value and objects regardless of member order
+func equal(left interface{}, right interface{}) bool {
+	switch left := left.(type) {
+	case map[string]interface{}:
+		var other, ok = right.(map[string]interface{})
+		if !ok || len(left) != len(other) {
+			return false
+		}
+		for name, member := range left {
+			if value, ok := other[name]; !ok || !equal(member, value) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		var other, ok = right.([]interface{})
+		if !ok || len(left) != len(other) {
+			return false
+		}
+		for index := range left {
+			if !equal(left[index], other[index]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		var other, ok = right.(json.Number)
+		if !ok {
+			return false
+		}
+		var a, aOk = new(big.Rat).SetString(string(left))
+		var b, bOk = new(big.Rat).SetString(string(other))
+		return aOk && bOk && a.Cmp(b) == 0
+	default:
+		return left == right
+	}
+}
@@ -0,0 +1,195 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package patch
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+// applied parses body as a patch of mediaType and applies it to document
+func applied(mediaType string, document string, body string) (string, error) {
+	if patch, err := Parse(mediaType, []byte(body)); err != nil {
+		return ``, err
+	} else if result, err := patch.Apply([]byte(document)); err != nil {
+		return ``, err
+	} else {
+		return string(result), nil
+	}
+}
+
+// kind names the sentinel error err wraps, empty when there is no error
+func kind(err error) string {
+	for _, sentinel := range []error{ErrUnsupported, ErrMalformed, ErrPath, ErrTestFailed} {
+		if errors.Is(err, sentinel) {
+			return sentinel.Error()
+		}
+	}
+
+	if err != nil {
+		return err.Error()
+	}
+	return ``
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestMerge(test *testing.T) {
+	//-- Shared Variables ----------
+	var results = make(map[string]string)
+
+	//-- Test Parameters ----------
+	var cases = map[string][3]string{
+		`replace`:       {`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		`add`:           {`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		`remove`:        {`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		`array`:         {`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		`into value`:    {`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		`nested`:        {`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		`nested null`:   {`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		`new object`:    {`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		`replace whole`: {`["a","b"]`, `{"a":"b"}`, `{"a":"b"}`},
+		`not an object`: {`{"a":"foo"}`, `"bar"`, `"bar"`},
+		`exact numbers`: {`{"a":1.50}`, `{"b":10000000000000000001}`, `{"a":1.50,"b":10000000000000000001}`},
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	for name, parameters := range cases {
+		if result, err := applied(MergeMediaType, parameters[0], parameters[1]); err != nil {
+			test.Fatalf(`unexpected error when merging %s: %s`, name, err)
+		} else {
+			results[name] = result
+		}
+	}
+
+	//-- Post-conditions ----------
+	for name, parameters := range cases {
+		assert.JSONEq(test, parameters[2], results[name], name)
+	}
+}
+
+func TestApply(test *testing.T) {
+	//-- Shared Variables ----------
+	var results = make(map[string]string)
+
+	//-- Test Parameters ----------
+	var cases = map[string][3]string{
+		`add member`:      {`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		`add element`:     {`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		`add end`:         {`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc"]}]`, `{"foo":["bar",["abc"]]}`},
+		`add null`:        {`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":null}]`, `{"baz":null,"foo":"bar"}`},
+		`add root`:        {`{"foo":"bar"}`, `[{"op":"add","path":"","value":[1]}]`, `[1]`},
+		`remove member`:   {`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		`remove element`:  {`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		`replace`:         {`{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		`replace element`: {`{"foo":[1,2,3]}`, `[{"op":"replace","path":"/foo/2","value":4}]`, `{"foo":[1,2,4]}`},
+		`move member`:     {`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		`move element`:    {`{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		`copy`:            {`{"foo":{"bar":1}}`, `[{"op":"copy","from":"/foo","path":"/baz"},{"op":"add","path":"/baz/qux","value":2}]`, `{"foo":{"bar":1},"baz":{"bar":1,"qux":2}}`},
+		`test`:            {`{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2.0}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		`escaped`:         {`{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10},{"op":"remove","path":"/~1"}]`, `{"~1":10}`},
+		`in order`:        {`{"a":1}`, `[{"op":"remove","path":"/a"},{"op":"add","path":"/a","value":[]},{"op":"add","path":"/a/0","value":2}]`, `{"a":[2]}`},
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	for name, parameters := range cases {
+		if result, err := applied(JSONMediaType, parameters[0], parameters[1]); err != nil {
+			test.Fatalf(`unexpected error when applying %s: %s`, name, err)
+		} else {
+			results[name] = result
+		}
+	}
+
+	//-- Post-conditions ----------
+	for name, parameters := range cases {
+		assert.JSONEq(test, parameters[2], results[name], name)
+	}
+}
+
+func TestApplyInvalid(test *testing.T) {
+	//-- Shared Variables ----------
+	var outcomes = make(map[string]string)
+
+	//-- Test Parameters ----------
+	var document = `{"foo":["bar","baz"],"qux":{"corge":"grault"}}`
+	var expected = map[string]string{
+		`missing member`:    ErrPath.Error(),
+		`missing parent`:    ErrPath.Error(),
+		`index beyond end`:  ErrPath.Error(),
+		`leading zero`:      ErrPath.Error(),
+		`end not added`:     ErrPath.Error(),
+		`through a value`:   ErrPath.Error(),
+		`replace missing`:   ErrPath.Error(),
+		`remove root`:       ErrPath.Error(),
+		`test mismatch`:     ErrTestFailed.Error(),
+		`test type`:         ErrTestFailed.Error(),
+		`unknown operation`: ErrMalformed.Error(),
+		`missing value`:     ErrMalformed.Error(),
+		`missing from`:      ErrMalformed.Error(),
+		`missing path`:      ErrMalformed.Error(),
+		`relative path`:     ErrMalformed.Error(),
+		`bad escape`:        ErrMalformed.Error(),
+		`into itself`:       ErrMalformed.Error(),
+		`not an array`:      ErrMalformed.Error(),
+		`not json`:          ErrMalformed.Error(),
+	}
+	var bodies = map[string]string{
+		`missing member`:    `[{"op":"remove","path":"/baz"}]`,
+		`missing parent`:    `[{"op":"add","path":"/baz/qux","value":1}]`,
+		`index beyond end`:  `[{"op":"add","path":"/foo/3","value":1}]`,
+		`leading zero`:      `[{"op":"replace","path":"/foo/01","value":1}]`,
+		`end not added`:     `[{"op":"replace","path":"/foo/-","value":1}]`,
+		`through a value`:   `[{"op":"add","path":"/qux/corge/grault","value":1}]`,
+		`replace missing`:   `[{"op":"replace","path":"/baz","value":1}]`,
+		`remove root`:       `[{"op":"remove","path":""}]`,
+		`test mismatch`:     `[{"op":"test","path":"/qux/corge","value":"garply"}]`,
+		`test type`:         `[{"op":"test","path":"/foo/0","value":["bar"]}]`,
+		`unknown operation`: `[{"op":"merge","path":"/foo","value":1}]`,
+		`missing value`:     `[{"op":"add","path":"/baz"}]`,
+		`missing from`:      `[{"op":"copy","path":"/baz"}]`,
+		`missing path`:      `[{"op":"remove"}]`,
+		`relative path`:     `[{"op":"remove","path":"foo"}]`,
+		`bad escape`:        `[{"op":"remove","path":"/~2"}]`,
+		`into itself`:       `[{"op":"move","from":"/qux","path":"/qux/corge"}]`,
+		`not an array`:      `{"op":"remove","path":"/foo"}`,
+		`not json`:          `[{"op":`,
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	for name, body := range bodies {
+		var _, err = applied(JSONMediaType, document, body)
+		outcomes[name] = kind(err)
+	}
+
+	//-- Post-conditions ----------
+	assert.Equal(test, expected, outcomes)
+}
+
+func TestParseInvalid(test *testing.T) {
+	//-- Shared Variables ----------
+	var mergeErr, unsupportedErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	_, mergeErr = Parse(MergeMediaType, []byte(`{"a": 1} {"b": 2}`))
+	_, unsupportedErr = Parse(`application/json`, []byte(`{"a": 1}`))
+
+	//-- Post-conditions ----------
+	assert.True(test, errors.Is(mergeErr, ErrMalformed))
+	assert.True(test, errors.Is(unsupportedErr, ErrUnsupported))
+}
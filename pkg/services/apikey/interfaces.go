@@ -0,0 +1,48 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package apikey
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"time"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+type Service interface {
+	// Create issues key to the caller within their tenant and returns the secret key, which is never available again
+	Create(ctx context.Context, key *Key) (string, error)
+	// List fetches the keys the caller may manage, oldest first, including revoked and expired keys
+	List(ctx context.Context) ([]Key, error)
+	// Revoke stops the key with id from authenticating any further requests and returns it
+	Revoke(ctx context.Context, id uint) (*Key, error)
+
+	// Verify returns the active key matching secret and records that it was used
+	Verify(ctx context.Context, secret string) (*Key, error)
+}
+
+// Store is the persistence contract behind a Service. List and Revoke are confined to the task.ScopeFrom ctx, so keys
+// of other tenants, and for a member those of other owners, are not found. Lookup reaches every tenant as the key
+// itself names its tenant. Keys which do not exist are reported with sql.ErrNoRows.
+type Store interface {
+	// Open connects the store using an implementation specific set of options (e.g. a connection string)
+	Open(options string) error
+	// Close releases any resources held by the store
+	Close() error
+	// Ping checks the store can still be reached
+	Ping(ctx context.Context) error
+	// Prepare runs a schema operation (`up` or `down`) using an implementation specific parameter. The schema of keys is
+	// versioned apart from the task schema it sits beside, so either can be migrated without the other.
+	Prepare(option string, parameter string) error
+
+	// Insert persists a new key within the tenant of ctx, assigning its ID, TenantID and CreatedAt
+	Insert(ctx context.Context, key *Key) error
+	// List fetches every key within scope ordered by ID
+	List(ctx context.Context) ([]Key, error)
+	// Revoke assigns the RevokedAt of a key within scope and returns it, a key which is already revoked is not found
+	Revoke(ctx context.Context, id uint) (*Key, error)
+
+	// Lookup fetches the key with prefix
+	Lookup(ctx context.Context, prefix string) (*Key, error)
+	// Touch records that the key with id was last used at the given time
+	Touch(ctx context.Context, id uint, at time.Time) error
+}
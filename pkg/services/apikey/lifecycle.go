@@ -0,0 +1,65 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package apikey
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"sync"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Lifecycle serves keys from the database of a task.Lifecycle for the life of the process. Keys share the connection
+// pool of the task store, which the task.Lifecycle opens, checks and reopens, and a task store which is not kept in a
+// database (see task.Pooled) is paired with a memory store.
+type Lifecycle struct {
+	mutex sync.Mutex
+
+	tasks *task.Lifecycle
+
+	shared  task.Store
+	service Service
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+func NewLifecycle(tasks *task.Lifecycle) *Lifecycle {
+	return &Lifecycle{tasks: tasks}
+}
+
+// Service returns the shared service, attached again whenever the task.Lifecycle has replaced its store
+func (lifecycle *Lifecycle) Service(ctx context.Context) (Service, error) {
+	lifecycle.mutex.Lock()
+	defer lifecycle.mutex.Unlock()
+
+	if shared, err := lifecycle.tasks.Store(ctx); err != nil {
+		return nil, err
+	} else if shared != lifecycle.shared {
+		if store, err := Attach(shared); err != nil {
+			return nil, err
+		} else {
+			lifecycle.shared = shared
+			lifecycle.service = NewService(store)
+		}
+	}
+
+	return lifecycle.service, nil
+}
+
+// Attach builds the key store kept alongside shared, the pool it borrows stays owned by shared
+func Attach(shared task.Store) (Store, error) {
+	if pooled, ok := shared.(task.Pooled); ok {
+		return &postgresStore{database: pooled.Database()}, nil
+	}
+
+	var store = new(memoryStore)
+	if err := store.Open(``); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
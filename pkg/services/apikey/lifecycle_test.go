@@ -0,0 +1,103 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package apikey
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"testing"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestLifecycleReuse(test *testing.T) {
+	//-- Shared Variables ----------
+	var tasks *task.Lifecycle
+	var lifecycle *Lifecycle
+	var first, second Service
+	var serviceErr error
+
+	//-- Test Parameters ----------
+	var parameters = task.ConnectionParameters{Driver: task.MemoryDriver, HealthCheckInterval: -1}
+
+	//-- Pre-conditions ----------
+	tasks = task.NewLifecycle(parameters, nil)
+	defer tasks.Close()
+
+	lifecycle = NewLifecycle(tasks)
+
+	if service, err := lifecycle.Service(context.Background()); err != nil {
+		test.Fatalf(`unexpected error when opening the service: %s`, err)
+	} else {
+		first = service
+	}
+
+	//-- Action ----------
+	second, serviceErr = lifecycle.Service(context.Background())
+
+	//-- Post-conditions ----------
+	assert.Nil(test, serviceErr)
+	assert.True(test, first.(keyService).store == second.(keyService).store)
+}
+
+func TestLifecycleReattach(test *testing.T) {
+	//-- Shared Variables ----------
+	var tasks *task.Lifecycle
+	var lifecycle *Lifecycle
+	var shared, reopened task.Store
+	var service Service
+	var serviceErr error
+
+	//-- Test Parameters ----------
+	var parameters = task.ConnectionParameters{Driver: task.MemoryDriver, HealthCheckInterval: -1}
+
+	//-- Pre-conditions ----------
+	tasks = task.NewLifecycle(parameters, nil)
+	defer tasks.Close()
+
+	lifecycle = NewLifecycle(tasks)
+
+	if _, err := lifecycle.Service(context.Background()); err != nil {
+		test.Fatalf(`unexpected error when opening the service: %s`, err)
+	}
+	shared = lifecycle.shared
+
+	tasks.Reset()
+
+	//-- Action ----------
+	service, serviceErr = lifecycle.Service(context.Background())
+	reopened, _ = tasks.Store(context.Background())
+
+	//-- Post-conditions ----------
+	assert.Nil(test, serviceErr)
+	assert.NotNil(test, service)
+	assert.False(test, shared == lifecycle.shared)
+	assert.True(test, reopened == lifecycle.shared)
+}
+
+func TestLifecycleUnknownDriver(test *testing.T) {
+	//-- Shared Variables ----------
+	var lifecycle *Lifecycle
+	var service Service
+	var serviceErr error
+
+	//-- Test Parameters ----------
+	var parameters = task.ConnectionParameters{Driver: `carrier pigeon`}
+
+	//-- Pre-conditions ----------
+	lifecycle = NewLifecycle(task.NewLifecycle(parameters, nil))
+
+	//-- Action ----------
+	service, serviceErr = lifecycle.Service(context.Background())
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, serviceErr)
+	assert.Nil(test, service)
+}
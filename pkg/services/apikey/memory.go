@@ -0,0 +1,233 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package apikey
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+var (
+	errMemoryStoreClosed = errors.New(`memory store was not initialized or in a connected state`)
+	errDuplicatePrefix   = errors.New(`an API key with the same prefix already exists`)
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type memoryStore struct {
+	mutex    sync.RWMutex
+	sequence uint
+	keys     map[uint]Key
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+func NewMemoryStore() Store {
+	return new(memoryStore)
+}
+
+func (store *memoryStore) Open(options string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.keys == nil {
+		store.keys = make(map[uint]Key)
+	}
+
+	return nil
+}
+
+func (store *memoryStore) Close() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.keys == nil {
+		return errMemoryStoreClosed
+	}
+
+	store.keys = nil
+	store.sequence = 0
+
+	return nil
+}
+
+func (store *memoryStore) Ping(ctx context.Context) error {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	if store.keys == nil {
+		return errMemoryStoreClosed
+	}
+	return nil
+}
+
+func (store *memoryStore) Prepare(option string, parameter string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.keys == nil {
+		return errMemoryStoreClosed
+	}
+
+	switch option {
+	case `up`:
+		return nil
+	case `down`:
+		store.keys = make(map[uint]Key)
+		store.sequence = 0
+		return nil
+	default:
+		return errors.New(`no valid option provided, unable to prepare`)
+	}
+}
+
+func (store *memoryStore) Insert(ctx context.Context, key *Key) error {
+	//-- Sanitize & validate ---------
+	key.TenantID = task.TenantFrom(ctx)
+
+	if err := key.Sanitize(); err != nil {
+		return err
+	} else if err := key.Validate(); err != nil {
+		return err
+	}
+
+	//-- Insert ----------
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.keys == nil {
+		return errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, existing := range store.keys {
+		if existing.Prefix == key.Prefix {
+			return errDuplicatePrefix
+		}
+	}
+
+	store.sequence++
+
+	key.ID = store.sequence
+	key.CreatedAt = time.Now().UTC()
+
+	store.keys[key.ID] = cloneKey(*key)
+
+	return nil
+}
+
+func (store *memoryStore) List(ctx context.Context) ([]Key, error) {
+	//-- Common variables ----------
+	var keys = make([]Key, 0)
+	var scope = task.ScopeFrom(ctx)
+
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	if store.keys == nil {
+		return nil, errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, key := range store.keys {
+		if scope.Reaches(key.TenantID, key.OwnerID) {
+			keys = append(keys, cloneKey(key))
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+
+	return keys, nil
+}
+
+func (store *memoryStore) Revoke(ctx context.Context, id uint) (*Key, error) {
+	//-- Common variables ----------
+	var timestamp = time.Now().UTC()
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.keys == nil {
+		return nil, errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if existing, ok := store.keys[id]; !ok || existing.RevokedAt != nil || !task.ScopeFrom(ctx).Reaches(existing.TenantID, existing.OwnerID) {
+		return nil, sql.ErrNoRows
+	} else {
+		existing.RevokedAt = &timestamp
+		store.keys[id] = cloneKey(existing)
+
+		var key = cloneKey(existing)
+		return &key, nil
+	}
+}
+
+func (store *memoryStore) Lookup(ctx context.Context, prefix string) (*Key, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	if store.keys == nil {
+		return nil, errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, existing := range store.keys {
+		if existing.Prefix == prefix {
+			var key = cloneKey(existing)
+			return &key, nil
+		}
+	}
+
+	return nil, sql.ErrNoRows
+}
+
+func (store *memoryStore) Touch(ctx context.Context, id uint, at time.Time) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.keys == nil {
+		return errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if existing, ok := store.keys[id]; !ok {
+		return sql.ErrNoRows
+	} else {
+		var lastUsedAt = at.UTC()
+		existing.LastUsedAt = &lastUsedAt
+		store.keys[id] = cloneKey(existing)
+	}
+
+	return nil
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+func cloneKey(key Key) Key {
+	var clone = key
+
+	//-- Match the database's microsecond timestamp resolution ----------
+	clone.CreatedAt = key.CreatedAt.Truncate(time.Microsecond)
+
+	clone.Scopes = append([]Scope(nil), key.Scopes...)
+	clone.Roles = append([]string(nil), key.Roles...)
+
+	for _, field := range []**time.Time{&clone.ExpiresAt, &clone.LastUsedAt, &clone.RevokedAt} {
+		if *field != nil {
+			var value = (*field).Truncate(time.Microsecond)
+			*field = &value
+		}
+	}
+
+	return clone
+}
@@ -0,0 +1,25 @@
+CREATE SEQUENCE IF NOT EXISTS api_keys_id_seq
+  AS INTEGER
+  MAXVALUE 2147483647;
+
+-- Only the SHA-256 hash of each key is kept, the key itself is shown once when it is created
+CREATE TABLE IF NOT EXISTS api_keys
+(
+  id           INTEGER DEFAULT nextval('api_keys_id_seq'::regclass) NOT NULL CONSTRAINT api_keys_pkey PRIMARY KEY ,
+
+  name         VARCHAR(50) NOT NULL,
+  prefix       VARCHAR(16) NOT NULL CONSTRAINT api_keys_prefix_key UNIQUE,
+  hash         VARCHAR(64) NOT NULL,
+  scopes       VARCHAR(32)[] NOT NULL,
+  roles        VARCHAR(255)[] DEFAULT '{}' NOT NULL,
+
+  owner_id     VARCHAR(255) DEFAULT '' NOT NULL,
+  tenant_id    VARCHAR(255) DEFAULT 'default' NOT NULL,
+
+  expires_at   TIMESTAMP WITH TIME ZONE,
+  created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+  last_used_at TIMESTAMP WITH TIME ZONE,
+  revoked_at   TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_tenant_owner_id ON api_keys (tenant_id, owner_id, id);
@@ -0,0 +1,183 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package apikey issues, lists, revokes and verifies the API keys which machine to machine integrations use in place of
+// a bearer token. Only a SHA-256 hash of each key is stored, the key itself is returned once when it is created.
+package apikey
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// ReadTasks allows a key to read, list and follow the history of tasks
+	ReadTasks Scope = `tasks:read`
+	// WriteTasks allows a key to create, update, delete and restore tasks
+	WriteTasks Scope = `tasks:write`
+	// ManageKeys allows the caller to create, list and revoke API keys. Every bearer token holds it while it can never be
+	// granted to an API key, so one key can not mint another.
+	ManageKeys Scope = `keys:manage`
+
+	// Issuer is the issuer of the Principal of every API key, which tells it apart from a bearer token
+	Issuer = `api-key`
+)
+
+var (
+	// grantable lists the scopes an API key may be granted
+	grantable = map[Scope]bool{ReadTasks: true, WriteTasks: true}
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Scope names a set of operations a Principal may perform
+type Scope string
+
+// Key is an API key as it is stored. Prefix is the public part of the key which identifies it in listings and lookups,
+// Hash the hex encoded SHA-256 of the whole key. A Key acts for OwnerID with the Roles they held when it was created.
+type Key struct {
+	//-- Primary Key ----------
+	ID uint
+
+	//-- User Variables ----------
+	Name      string
+	Scopes    []Scope
+	ExpiresAt *time.Time
+
+	//-- System Variables ----------
+	Prefix string
+	Hash   string
+	Roles  []string
+
+	//-- Relations ----------
+	OwnerID  string
+	TenantID string
+
+	//-- Automated fields (Timestamps) ----------
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+func (key Key) String() string {
+	var expiresAt, lastUsedAt, revokedAt = `<nil>`, `<nil>`, `<nil>`
+
+	if key.ExpiresAt != nil {
+		expiresAt = key.ExpiresAt.String()
+	}
+	if key.LastUsedAt != nil {
+		lastUsedAt = key.LastUsedAt.String()
+	}
+	if key.RevokedAt != nil {
+		revokedAt = key.RevokedAt.String()
+	}
+
+	return fmt.Sprintf(`{ID: %d, Name: %s, Prefix: %s, Scopes: %v, Roles: %v, OwnerID: %s, TenantID: %s, ExpiresAt: %s, CreatedAt: %s, LastUsedAt: %s, RevokedAt: %s}`, key.ID, key.Name, key.Prefix, key.Scopes, key.Roles, key.OwnerID, key.TenantID, expiresAt, key.CreatedAt, lastUsedAt, revokedAt)
+}
+
+// Active reports whether key may still be used at now, it is neither revoked nor expired
+func (key Key) Active(now time.Time) bool {
+	return key.RevokedAt == nil && (key.ExpiresAt == nil || now.Before(*key.ExpiresAt))
+}
+
+// Principal is the identity a request authenticated with key acts as, limited to the scopes of key
+func (key Key) Principal() *auth.Principal {
+	var principal = &auth.Principal{
+		Subject: key.OwnerID,
+		Issuer:  Issuer,
+		Roles:   key.Roles,
+		Tenant:  key.TenantID,
+		Scopes:  make([]string, 0, len(key.Scopes)),
+		Claims:  map[string]interface{}{`key_id`: key.ID, `key_prefix`: key.Prefix},
+	}
+
+	for _, scope := range key.Scopes {
+		principal.Scopes = append(principal.Scopes, string(scope))
+	}
+
+	if key.ExpiresAt != nil {
+		principal.ExpiresAt = *key.ExpiresAt
+	}
+
+	return principal
+}
+
+func (key *Key) Sanitize() error {
+	var seen = make(map[Scope]bool)
+	var scopes = make([]Scope, 0, len(key.Scopes))
+
+	for _, scope := range key.Scopes {
+		if scope = Scope(strings.TrimSpace(string(scope))); !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	key.Scopes = scopes
+
+	if key.ExpiresAt != nil {
+		*key.ExpiresAt = key.ExpiresAt.UTC()
+	}
+
+	key.CreatedAt = key.CreatedAt.UTC()
+
+	return nil
+}
+
+func (key Key) Validate() error {
+	if err := key.validateName(); err != nil {
+		return err
+	}
+
+	if err := key.validateScopes(); err != nil {
+		return err
+	}
+
+	if err := key.validateExpiresAt(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+func (key Key) validateName() error {
+	//-- Common variables ----------
+	var validPattern = regexp.MustCompile(`\A[a-zA-Z0-9 \-:]{1,50}\z`)
+
+	//-- Check for pattern adherence ----------
+	if !validPattern.MatchString(key.Name) {
+		return errors.New(fmt.Sprintf(`validation - Name '%s' must be comprised only of letters, numbers, spaces and hyphens/colons and may not be empty and may not exceed 50 characters`, key.Name))
+	}
+
+	return nil
+}
+
+func (key Key) validateScopes() error {
+	//-- Check for presence ----------
+	if len(key.Scopes) == 0 {
+		return errors.New(`validation - Scopes must grant at least one of tasks:read or tasks:write`)
+	}
+
+	//-- Check every scope may be granted ----------
+	for _, scope := range key.Scopes {
+		if !grantable[scope] {
+			return errors.New(fmt.Sprintf(`validation - Scope '%s' can not be granted to an API key, use tasks:read or tasks:write`, scope))
+		}
+	}
+
+	return nil
+}
+
+func (key Key) validateExpiresAt() error {
+	//-- Check for non-sensical value ----------
+	if key.ID == 0 && key.ExpiresAt != nil && !key.ExpiresAt.After(time.Now()) {
+		return errors.New(fmt.Sprintf(`validation - ExpiresAt '%s' must be in the future`, *key.ExpiresAt))
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package apikey
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestKeyValidate(test *testing.T) {
+	//-- Shared Variables ----------
+	var past, future = time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	//-- Test Parameters ----------
+	var cases = []struct {
+		name  string
+		key   Key
+		valid bool
+	}{
+		{name: `read`, key: Key{Name: `ci: read`, Scopes: []Scope{ReadTasks}}, valid: true},
+		{name: `read and write`, key: Key{Name: `ci-write`, Scopes: []Scope{ReadTasks, WriteTasks}, ExpiresAt: &future}, valid: true},
+		{name: `empty name`, key: Key{Scopes: []Scope{ReadTasks}}},
+		{name: `no scopes`, key: Key{Name: `ci`}},
+		{name: `unknown scope`, key: Key{Name: `ci`, Scopes: []Scope{`tasks:admin`}}},
+		{name: `manage keys`, key: Key{Name: `ci`, Scopes: []Scope{ManageKeys}}},
+		{name: `already expired`, key: Key{Name: `ci`, Scopes: []Scope{ReadTasks}, ExpiresAt: &past}},
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+
+	//-- Post-conditions ----------
+	for _, item := range cases {
+		if item.valid {
+			assert.Nil(test, item.key.Validate(), item.name)
+		} else {
+			assert.NotNil(test, item.key.Validate(), item.name)
+		}
+	}
+}
+
+func TestKeyPrincipal(test *testing.T) {
+	//-- Shared Variables ----------
+	var key Key
+
+	//-- Test Parameters ----------
+	var expiresAt = time.Now().Add(time.Hour)
+
+	//-- Pre-conditions ----------
+	key = Key{ID: 7, Prefix: `0123456789abcdef`, Scopes: []Scope{ReadTasks, ReadTasks, ` tasks:write `}, Roles: []string{`member`}, OwnerID: `user-1`, TenantID: `tenant-a`, ExpiresAt: &expiresAt}
+
+	//-- Action ----------
+	_ = key.Sanitize()
+
+	//-- Post-conditions ----------
+	var principal = key.Principal()
+
+	assert.Equal(test, []Scope{ReadTasks, WriteTasks}, key.Scopes)
+	assert.Equal(test, `user-1`, principal.Subject)
+	assert.Equal(test, Issuer, principal.Issuer)
+	assert.Equal(test, `tenant-a`, principal.Tenant)
+	assert.Equal(test, []string{`member`}, principal.Roles)
+	assert.True(test, principal.Permits(string(ReadTasks)))
+	assert.True(test, principal.Permits(string(WriteTasks)))
+	assert.False(test, principal.Permits(string(ManageKeys)))
+	assert.Equal(test, expiresAt.Unix(), principal.ExpiresAt.Unix())
+
+	assert.True(test, key.Active(time.Now()))
+	assert.False(test, key.Active(expiresAt))
+}
@@ -0,0 +1,69 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package apikey
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"regexp"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// secretScheme starts every key so leaked keys are easy to recognise and scan for
+	secretScheme = `tk`
+
+	prefixBytes = 8
+	secretBytes = 32
+)
+
+var (
+	// secretPattern matches a whole key, capturing its prefix
+	secretPattern = regexp.MustCompile(`\Atk_([0-9a-f]{16})_[0-9a-f]{64}\z`)
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// IsKey reports whether value is shaped like an API key, which tells a key sent with the bearer scheme apart from a JWT
+func IsKey(value string) bool {
+	return secretPattern.MatchString(value)
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// newSecret generates a key in the form tk_<prefix>_<secret> from a random prefix and secret, it returns the key along
+// with its prefix and hash
+func newSecret() (string, string, string, error) {
+	var prefix = make([]byte, prefixBytes)
+	var secret = make([]byte, secretBytes)
+
+	if _, err := rand.Read(prefix); err != nil {
+		return ``, ``, ``, err
+	} else if _, err := rand.Read(secret); err != nil {
+		return ``, ``, ``, err
+	}
+
+	var key = secretScheme + `_` + hex.EncodeToString(prefix) + `_` + hex.EncodeToString(secret)
+	return key, hex.EncodeToString(prefix), hashSecret(key), nil
+}
+
+// parseSecret returns the prefix of key, or ErrInvalidKey when key is not shaped like one
+func parseSecret(key string) (string, error) {
+	if match := secretPattern.FindStringSubmatch(key); match != nil {
+		return match[1], nil
+	}
+	return ``, ErrInvalidKey
+}
+
+// hashSecret is the hex encoded SHA-256 of key, keys are long and random so a slow password hash adds nothing
+func hashSecret(key string) string {
+	var sum = sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// matchSecret compares key against hash in constant time
+func matchSecret(key string, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashSecret(key)), []byte(hash)) == 1
+}
@@ -0,0 +1,120 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package apikey
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// touchInterval is how stale LastUsedAt may become before Verify records a use, so a busy key is not written on
+	// every request
+	touchInterval = time.Minute
+)
+
+var (
+	ErrInvalidKey = fmt.Errorf(`%w: the API key is not valid`, auth.ErrUnauthenticated)
+	ErrRevokedKey = fmt.Errorf(`%w: the API key has been revoked`, auth.ErrUnauthenticated)
+	ErrExpiredKey = fmt.Errorf(`%w: the API key has expired`, auth.ErrUnauthenticated)
+
+	ErrForbidden = errors.New(`the caller may not manage API keys`)
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type keyService struct {
+	store Store
+	now   func() time.Time
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+func NewService(store Store) Service {
+	return keyService{store: store, now: time.Now}
+}
+
+// Create issues key to the caller with the roles they hold, so the key can never reach more than its owner
+func (service keyService) Create(ctx context.Context, key *Key) (string, error) {
+	if err := permit(ctx); err != nil {
+		return ``, err
+	} else if secret, prefix, hash, err := newSecret(); err != nil {
+		return ``, err
+	} else {
+		key.Prefix, key.Hash = prefix, hash
+		key.OwnerID, key.Roles = ``, nil
+		key.LastUsedAt, key.RevokedAt = nil, nil
+
+		if principal := auth.PrincipalFrom(ctx); principal != nil {
+			key.OwnerID, key.Roles = principal.Subject, principal.Roles
+		}
+
+		if err := service.store.Insert(ctx, key); err != nil {
+			return ``, err
+		}
+
+		return secret, nil
+	}
+}
+
+func (service keyService) List(ctx context.Context) ([]Key, error) {
+	if err := permit(ctx); err != nil {
+		return nil, err
+	} else if keys, err := service.store.List(ctx); err != nil {
+		return nil, err
+	} else {
+		return keys, nil
+	}
+}
+
+func (service keyService) Revoke(ctx context.Context, id uint) (*Key, error) {
+	if err := permit(ctx); err != nil {
+		return nil, err
+	} else if key, err := service.store.Revoke(ctx, id); err != nil {
+		return nil, err
+	} else {
+		return key, nil
+	}
+}
+
+// Verify checks secret against the stored hash of the key it names. Every malformed or unknown key is reported as
+// ErrInvalidKey, so a caller learns nothing about which keys exist.
+func (service keyService) Verify(ctx context.Context, secret string) (*Key, error) {
+	var now = service.now().UTC()
+
+	if prefix, err := parseSecret(secret); err != nil {
+		return nil, err
+	} else if key, err := service.store.Lookup(ctx, prefix); errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidKey
+	} else if err != nil {
+		return nil, err
+	} else if !matchSecret(secret, key.Hash) {
+		return nil, ErrInvalidKey
+	} else if key.RevokedAt != nil {
+		return nil, ErrRevokedKey
+	} else if !key.Active(now) {
+		return nil, ErrExpiredKey
+	} else {
+		if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= touchInterval {
+			if err := service.store.Touch(ctx, key.ID, now); err != nil {
+				return nil, err
+			}
+			key.LastUsedAt = &now
+		}
+
+		return key, nil
+	}
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// permit rejects a caller which does not hold ManageKeys, which is every caller authenticated with an API key
+func permit(ctx context.Context) error {
+	if principal := auth.PrincipalFrom(ctx); principal != nil && !principal.Permits(string(ManageKeys)) {
+		return ErrForbidden
+	}
+	return nil
+}
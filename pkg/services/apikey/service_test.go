@@ -0,0 +1,174 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package apikey
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+// newMemoryService returns a service backed by an open memory store
+func newMemoryService(test *testing.T) keyService {
+	var store = NewMemoryStore()
+
+	if err := store.Open(``); err != nil {
+		test.Fatalf(`unable to open the memory store: %s`, err)
+	}
+
+	return keyService{store: store, now: time.Now}
+}
+
+// withPrincipal returns a context authenticated as subject of tenant holding roles
+func withPrincipal(subject string, tenant string, roles ...string) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Subject: subject, Tenant: tenant, Roles: roles})
+}
+
+// tamper changes the last character of secret, so it still parses but no longer matches its hash
+func tamper(secret string) string {
+	if strings.HasSuffix(secret, `0`) {
+		return secret[:len(secret)-1] + `1`
+	}
+	return secret[:len(secret)-1] + `0`
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestServiceCreateVerify(test *testing.T) {
+	//-- Shared Variables ----------
+	var service keyService
+	var key *Key
+
+	var secret string
+	var createErr, verifyErr error
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var input = &Key{Name: `ci`, Scopes: []Scope{ReadTasks}}
+
+	//-- Pre-conditions ----------
+	service = newMemoryService(test)
+	ctx = withPrincipal(`user-1`, `tenant-a`, string(task.MemberRole))
+
+	//-- Action ----------
+	secret, createErr = service.Create(ctx, input)
+	key, verifyErr = service.Verify(context.Background(), secret)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, createErr)
+	assert.Nil(test, verifyErr)
+
+	assert.True(test, strings.HasPrefix(secret, `tk_`+input.Prefix+`_`))
+	assert.NotContains(test, input.Hash, secret)
+	assert.Equal(test, `user-1`, input.OwnerID)
+	assert.Equal(test, `tenant-a`, input.TenantID)
+	assert.Equal(test, []string{string(task.MemberRole)}, input.Roles)
+
+	if assert.NotNil(test, key) {
+		assert.Equal(test, input.ID, key.ID)
+		assert.NotNil(test, key.LastUsedAt)
+	}
+}
+
+func TestServiceVerifyRejected(test *testing.T) {
+	//-- Shared Variables ----------
+	var service keyService
+	var revoked, expired, active *Key
+
+	var revokedSecret, expiredSecret, activeSecret string
+
+	var ctx context.Context
+
+	//-- Test Parameters ----------
+	var later = time.Now().Add(time.Hour)
+
+	//-- Pre-conditions ----------
+	service = newMemoryService(test)
+	ctx = withPrincipal(`user-1`, `tenant-a`)
+
+	revoked = &Key{Name: `revoked`, Scopes: []Scope{ReadTasks}}
+	expired = &Key{Name: `expired`, Scopes: []Scope{ReadTasks}, ExpiresAt: &later}
+	active = &Key{Name: `active`, Scopes: []Scope{ReadTasks}}
+
+	revokedSecret, _ = service.Create(ctx, revoked)
+	expiredSecret, _ = service.Create(ctx, expired)
+	activeSecret, _ = service.Create(ctx, active)
+
+	if _, err := service.Revoke(ctx, revoked.ID); err != nil {
+		test.Fatalf(`unable to revoke the key: %s`, err)
+	}
+
+	//-- Action ----------
+	service.now = func() time.Time { return later.Add(time.Minute) }
+
+	//-- Post-conditions ----------
+	for secret, expected := range map[string]error{
+		revokedSecret:        ErrRevokedKey,
+		expiredSecret:        ErrExpiredKey,
+		tamper(activeSecret): ErrInvalidKey,
+		`tk_ffffffffffffffff_` + strings.Repeat(`0`, 64): ErrInvalidKey,
+		`not an api key`: ErrInvalidKey,
+	} {
+		var _, err = service.Verify(context.Background(), secret)
+
+		assert.Equal(test, expected, err, secret)
+		assert.True(test, errors.Is(err, auth.ErrUnauthenticated), secret)
+	}
+}
+
+func TestServiceScope(test *testing.T) {
+	//-- Shared Variables ----------
+	var service keyService
+	var own, other, foreign *Key
+
+	var ownList, adminList []Key
+	var revokeErr, foreignErr, keyErr, listErr error
+
+	//-- Test Parameters ----------
+	var member = withPrincipal(`user-1`, `tenant-a`, string(task.MemberRole))
+	var admin = withPrincipal(`admin-1`, `tenant-a`, string(task.AdminRole))
+
+	//-- Pre-conditions ----------
+	service = newMemoryService(test)
+
+	own = &Key{Name: `own`, Scopes: []Scope{ReadTasks}}
+	other = &Key{Name: `other`, Scopes: []Scope{ReadTasks}}
+	foreign = &Key{Name: `foreign`, Scopes: []Scope{ReadTasks}}
+
+	_, _ = service.Create(member, own)
+	_, _ = service.Create(withPrincipal(`user-2`, `tenant-a`, string(task.MemberRole)), other)
+	_, _ = service.Create(withPrincipal(`user-3`, `tenant-b`, string(task.AdminRole)), foreign)
+
+	//-- Action ----------
+	ownList, _ = service.List(member)
+	adminList, _ = service.List(admin)
+
+	_, revokeErr = service.Revoke(member, other.ID)
+	_, foreignErr = service.Revoke(admin, foreign.ID)
+
+	_, keyErr = service.Create(auth.WithPrincipal(context.Background(), own.Principal()), &Key{Name: `minted`, Scopes: []Scope{ReadTasks}})
+	_, listErr = service.List(auth.WithPrincipal(context.Background(), own.Principal()))
+
+	//-- Post-conditions ----------
+	if assert.Len(test, ownList, 1) {
+		assert.Equal(test, own.ID, ownList[0].ID)
+	}
+	assert.Len(test, adminList, 2)
+
+	assert.Equal(test, sql.ErrNoRows, revokeErr)
+	assert.Equal(test, sql.ErrNoRows, foreignErr)
+	assert.Equal(test, ErrForbidden, keyErr)
+	assert.Equal(test, ErrForbidden, listErr)
+}
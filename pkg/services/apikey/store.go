@@ -0,0 +1,207 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package apikey
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/lib/pq"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// keyColumns is the column order every query scans into a Key with keyFields
+	keyColumns = `id, name, prefix, hash, scopes, roles, owner_id, tenant_id, expires_at, created_at, last_used_at, revoked_at`
+
+	// migrationsTable records the version of the key schema, the task schema keeps its version in the default table
+	migrationsTable = `api_key_migrations`
+)
+
+var (
+	// queryMap holds every statement run against the api_keys table, created by the migrations of this package
+	queryMap = map[string]string{
+		`insertKey`: `INSERT INTO api_keys(name, prefix, hash, scopes, roles, owner_id, tenant_id, expires_at, created_at) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		`listKeys`:  `SELECT ` + keyColumns + ` FROM api_keys WHERE ($1::VARCHAR IS NULL OR tenant_id = $1::VARCHAR) AND ($2::VARCHAR IS NULL OR owner_id = $2::VARCHAR) ORDER BY id ASC`,
+		`revokeKey`: `UPDATE api_keys SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL AND ($3::VARCHAR IS NULL OR tenant_id = $3::VARCHAR) AND ($4::VARCHAR IS NULL OR owner_id = $4::VARCHAR) RETURNING ` + keyColumns,
+		`lookupKey`: `SELECT ` + keyColumns + ` FROM api_keys WHERE prefix = $1 LIMIT 1`,
+		`touchKey`:  `UPDATE api_keys SET last_used_at = $2 WHERE id = $1`,
+	}
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type postgresStore struct {
+	database *sql.DB
+}
+
+// row is anything a single Key can be scanned from
+type row interface {
+	Scan(destinations ...interface{}) error
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+func NewPostgresStore() Store {
+	return new(postgresStore)
+}
+
+func (store *postgresStore) Open(options string) error {
+	if db, err := sql.Open(`postgres`, options); err != nil {
+		return err
+	} else if err := db.Ping(); err != nil {
+		return err
+	} else {
+		store.database = db
+	}
+
+	return nil
+}
+
+func (store *postgresStore) Close() error {
+	if store.database == nil {
+		return errors.New(`database was not initialized or in a connected state`)
+	} else if err := store.database.Close(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (store *postgresStore) Ping(ctx context.Context) error {
+	if store.database == nil {
+		return errors.New(`database was not initialized or in a connected state`)
+	}
+	return store.database.PingContext(ctx)
+}
+
+func (store *postgresStore) Prepare(option string, parameter string) error {
+	if option != `up` && option != `down` {
+		return errors.New(`no valid option provided, unable to prepare`)
+	} else if store.database == nil {
+		return errors.New(`database was not initialized or in a connected state`)
+	} else if driver, err := postgres.WithInstance(store.database, &postgres.Config{MigrationsTable: migrationsTable}); err != nil {
+		return err
+	} else if migration, err := migrate.NewWithDatabaseInstance(parameter, `postgres`, driver); err != nil {
+		return err
+	} else if option == `down` {
+		return migration.Down()
+	} else if err := migration.Up(); err != nil && err != migrate.ErrNoChange {
+		return err
+	}
+
+	return nil
+}
+
+func (store *postgresStore) Insert(ctx context.Context, key *Key) error {
+	//-- Common variables ----------
+	var id int
+	var timestamp = time.Now().UTC()
+
+	//-- Sanitize & validate ---------
+	key.TenantID = task.TenantFrom(ctx)
+
+	if err := key.Sanitize(); err != nil {
+		return err
+	} else if err := key.Validate(); err != nil {
+		return err
+	}
+
+	//-- Insert ----------
+	if err := store.database.QueryRowContext(ctx, queryMap[`insertKey`], key.Name, key.Prefix, key.Hash, pq.Array(scopeStrings(key.Scopes)), pq.Array(roleStrings(key.Roles)), key.OwnerID, key.TenantID, key.ExpiresAt, timestamp).Scan(&id); err != nil {
+		return err
+	}
+
+	key.ID = uint(id)
+	key.CreatedAt = timestamp
+
+	return nil
+}
+
+func (store *postgresStore) List(ctx context.Context) ([]Key, error) {
+	//-- Common variables ----------
+	var keys = make([]Key, 0)
+	var scope = task.ScopeFrom(ctx)
+
+	//-- Query ----------
+	var results, err = store.database.QueryContext(ctx, queryMap[`listKeys`], scope.TenantParameter(), scope.OwnerParameter())
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	for results.Next() {
+		if key, err := scanKey(results); err != nil {
+			return nil, err
+		} else {
+			keys = append(keys, *key)
+		}
+	}
+
+	if err := results.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (store *postgresStore) Revoke(ctx context.Context, id uint) (*Key, error) {
+	var timestamp = time.Now().UTC()
+	var scope = task.ScopeFrom(ctx)
+
+	return scanKey(store.database.QueryRowContext(ctx, queryMap[`revokeKey`], id, timestamp, scope.TenantParameter(), scope.OwnerParameter()))
+}
+
+func (store *postgresStore) Lookup(ctx context.Context, prefix string) (*Key, error) {
+	return scanKey(store.database.QueryRowContext(ctx, queryMap[`lookupKey`], prefix))
+}
+
+func (store *postgresStore) Touch(ctx context.Context, id uint, at time.Time) error {
+	if result, err := store.database.ExecContext(ctx, queryMap[`touchKey`], id, at.UTC()); err != nil {
+		return err
+	} else if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// scanKey reads a row selected with keyColumns
+func scanKey(source row) (*Key, error) {
+	var key = new(Key)
+	var scopes, roles []string
+
+	if err := source.Scan(&key.ID, &key.Name, &key.Prefix, &key.Hash, pq.Array(&scopes), pq.Array(&roles), &key.OwnerID, &key.TenantID, &key.ExpiresAt, &key.CreatedAt, &key.LastUsedAt, &key.RevokedAt); err != nil {
+		return nil, err
+	}
+
+	for _, scope := range scopes {
+		key.Scopes = append(key.Scopes, Scope(scope))
+	}
+	key.Roles = roles
+
+	return key, nil
+}
+
+func scopeStrings(scopes []Scope) []string {
+	var output = make([]string, 0, len(scopes))
+
+	for _, scope := range scopes {
+		output = append(output, string(scope))
+	}
+
+	return output
+}
+
+func roleStrings(roles []string) []string {
+	if roles == nil {
+		return []string{}
+	}
+	return roles
+}
@@ -0,0 +1,513 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package storetest provides a conformance suite which any apikey.Store implementation can run to prove it behaves the
+// same way as the stores shipped with the API key service.
+package storetest
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/apikey"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+var (
+	checks = []check{
+		{`Insert`, testInsert},
+		{`InsertInvalid`, testInsertInvalid},
+		{`InsertDuplicatePrefix`, testInsertDuplicatePrefix},
+		{`List`, testList},
+		{`Revoke`, testRevoke},
+		{`RevokeTwice`, testRevokeTwice},
+		{`Lookup`, testLookup},
+		{`LookupNotFound`, testLookupNotFound},
+		{`Touch`, testTouch},
+		{`TouchNotFound`, testTouchNotFound},
+		{`HashedSecret`, testHashedSecret},
+		{`TenantList`, testTenantList},
+		{`TenantRevoke`, testTenantRevoke},
+		{`TenantLookup`, testTenantLookup},
+		{`OwnerList`, testOwnerList},
+		{`OwnerRevoke`, testOwnerRevoke},
+	}
+
+	// sequence numbers the keys the suite inserts, so each has a prefix of its own
+	sequence uint
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Factory returns an opened store with no existing keys. The suite calls it once per check and closes the store itself
+// when the check completes.
+type Factory func(test *testing.T) apikey.Store
+
+type check struct {
+	name string
+	run  func(test *testing.T, store apikey.Store)
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// Run executes every conformance check as a sub-test against a fresh store built by factory.
+func Run(test *testing.T, factory Factory) {
+	for _, item := range checks {
+		var item = item
+
+		test.Run(item.name, func(test *testing.T) {
+			var store = factory(test)
+			if store == nil {
+				test.Fatal(`the store factory returned a nil store`)
+			}
+
+			defer func() {
+				if err := store.Close(); err != nil {
+					test.Errorf(`an unexpected error occurred while closing the store: %s`, err)
+				}
+			}()
+
+			item.run(test, store)
+		})
+	}
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// newValidKey returns a key which passes validation, its prefix and hash are placeholders no secret matches
+func newValidKey(name string, owner string) *apikey.Key {
+	sequence++
+
+	return &apikey.Key{
+		Name:    name,
+		Scopes:  []apikey.Scope{apikey.ReadTasks},
+		Prefix:  fmt.Sprintf(`%016x`, sequence),
+		Hash:    strings.Repeat(`0`, 48) + fmt.Sprintf(`%016x`, sequence),
+		Roles:   []string{string(task.MemberRole)},
+		OwnerID: owner,
+	}
+}
+
+// as returns a context of tenant carrying a principal for subject holding roles
+func as(tenant string, subject string, roles ...string) context.Context {
+	return task.WithTenant(auth.WithPrincipal(context.Background(), &auth.Principal{Subject: subject, Tenant: tenant, Roles: roles}), tenant)
+}
+
+// within returns a context of an admin of tenant, which reaches every key of the tenant
+func within(tenant string) context.Context {
+	return as(tenant, `conformance-admin`, string(task.AdminRole))
+}
+
+func insertKey(test *testing.T, store apikey.Store, ctx context.Context, name string, owner string) *apikey.Key {
+	var key = newValidKey(name, owner)
+
+	if err := store.Insert(ctx, key); err != nil {
+		test.Fatalf(`unexpected error when inserting record: %s`, err)
+	}
+
+	return key
+}
+
+// tamper changes the last character of secret, so it still parses but no longer matches its hash
+func tamper(secret string) string {
+	if strings.HasSuffix(secret, `0`) {
+		return secret[:len(secret)-1] + `1`
+	}
+	return secret[:len(secret)-1] + `0`
+}
+
+func ids(keys []apikey.Key) []uint {
+	var output = make([]uint, 0, len(keys))
+
+	for _, key := range keys {
+		output = append(output, key.ID)
+	}
+
+	return output
+}
+
+func keyIDs(keys ...*apikey.Key) []uint {
+	var output = make([]uint, 0, len(keys))
+
+	for _, key := range keys {
+		output = append(output, key.ID)
+	}
+
+	return output
+}
+
+//-- Insert Checks -----------------------------------------------------------------------------------------------------
+func testInsert(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var key *apikey.Key
+	var insertErr error
+
+	//-- Test Parameters ----------
+	var tenant = `conformance-mine`
+
+	//-- Pre-conditions ----------
+	key = newValidKey(`Testing insert`, `conformance-owner`)
+	key.Scopes = []apikey.Scope{apikey.ReadTasks, apikey.WriteTasks}
+
+	//-- Action ----------
+	insertErr = store.Insert(within(tenant), key)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, insertErr)
+	assert.NotZero(test, key.ID)
+	assert.Equal(test, tenant, key.TenantID)
+	assert.False(test, key.CreatedAt.IsZero())
+}
+
+func testInsertInvalid(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var key *apikey.Key
+	var insertErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	key = newValidKey(`Testing insert invalid`, `conformance-owner`)
+	key.Scopes = []apikey.Scope{apikey.ManageKeys}
+
+	//-- Action ----------
+	insertErr = store.Insert(within(`conformance-mine`), key)
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, insertErr)
+	assert.Zero(test, key.ID)
+}
+
+func testInsertDuplicatePrefix(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var original, duplicate *apikey.Key
+	var insertErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	original = insertKey(test, store, within(`conformance-mine`), `Testing duplicate prefix`, `conformance-owner`)
+
+	duplicate = newValidKey(`Testing duplicate prefix`, `conformance-owner`)
+	duplicate.Prefix = original.Prefix
+
+	//-- Action ----------
+	insertErr = store.Insert(within(`conformance-mine`), duplicate)
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, insertErr)
+}
+
+//-- List & Revoke Checks ----------------------------------------------------------------------------------------------
+func testList(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var inserted []*apikey.Key
+	var listed []apikey.Key
+	var listErr error
+
+	//-- Test Parameters ----------
+	var ctx = within(`conformance-mine`)
+
+	//-- Pre-conditions ----------
+	for index := 0; index < 3; index++ {
+		inserted = append(inserted, insertKey(test, store, ctx, fmt.Sprintf(`Testing list %d`, index), `conformance-owner`))
+	}
+
+	//-- Action ----------
+	listed, listErr = store.List(ctx)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.Equal(test, keyIDs(inserted...), ids(listed))
+
+	if assert.Equal(test, 3, len(listed)) {
+		assert.Equal(test, inserted[0].Prefix, listed[0].Prefix)
+		assert.Equal(test, inserted[0].Hash, listed[0].Hash)
+		assert.Equal(test, inserted[0].Scopes, listed[0].Scopes)
+		assert.Equal(test, inserted[0].Roles, listed[0].Roles)
+	}
+}
+
+func testRevoke(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var key, revoked *apikey.Key
+	var revokeErr error
+
+	//-- Test Parameters ----------
+	var ctx = within(`conformance-mine`)
+
+	//-- Pre-conditions ----------
+	key = insertKey(test, store, ctx, `Testing revoke`, `conformance-owner`)
+
+	//-- Action ----------
+	revoked, revokeErr = store.Revoke(ctx, key.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, revokeErr)
+
+	if assert.NotNil(test, revoked) {
+		assert.Equal(test, key.ID, revoked.ID)
+		assert.NotNil(test, revoked.RevokedAt)
+	}
+}
+
+func testRevokeTwice(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var key *apikey.Key
+	var firstErr, secondErr error
+
+	//-- Test Parameters ----------
+	var ctx = within(`conformance-mine`)
+
+	//-- Pre-conditions ----------
+	key = insertKey(test, store, ctx, `Testing revoke twice`, `conformance-owner`)
+
+	//-- Action ----------
+	_, firstErr = store.Revoke(ctx, key.ID)
+	_, secondErr = store.Revoke(ctx, key.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, firstErr)
+	assert.Equal(test, sql.ErrNoRows, secondErr)
+}
+
+//-- Lookup & Touch Checks ---------------------------------------------------------------------------------------------
+func testLookup(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var key, found *apikey.Key
+	var lookupErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	key = insertKey(test, store, within(`conformance-mine`), `Testing lookup`, `conformance-owner`)
+
+	//-- Action ----------
+	found, lookupErr = store.Lookup(context.Background(), key.Prefix)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, lookupErr)
+
+	if assert.NotNil(test, found) {
+		assert.Equal(test, key.ID, found.ID)
+		assert.Equal(test, key.Hash, found.Hash)
+		assert.Equal(test, key.OwnerID, found.OwnerID)
+		assert.Equal(test, key.TenantID, found.TenantID)
+	}
+}
+
+func testLookupNotFound(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var found *apikey.Key
+	var lookupErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	insertKey(test, store, within(`conformance-mine`), `Testing lookup not found`, `conformance-owner`)
+
+	//-- Action ----------
+	found, lookupErr = store.Lookup(context.Background(), `ffffffffffffffff`)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, sql.ErrNoRows, lookupErr)
+	assert.Nil(test, found)
+}
+
+func testTouch(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var key, found *apikey.Key
+	var touchErr error
+
+	//-- Test Parameters ----------
+	var at = time.Date(2019, time.March, 4, 12, 30, 0, 0, time.UTC)
+
+	//-- Pre-conditions ----------
+	key = insertKey(test, store, within(`conformance-mine`), `Testing touch`, `conformance-owner`)
+
+	//-- Action ----------
+	touchErr = store.Touch(context.Background(), key.ID, at)
+	found, _ = store.Lookup(context.Background(), key.Prefix)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, touchErr)
+
+	if assert.NotNil(test, found) && assert.NotNil(test, found.LastUsedAt) {
+		assert.True(test, at.Equal(*found.LastUsedAt))
+	}
+}
+
+func testTouchNotFound(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var key *apikey.Key
+	var touchErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	key = insertKey(test, store, within(`conformance-mine`), `Testing touch not found`, `conformance-owner`)
+
+	//-- Action ----------
+	touchErr = store.Touch(context.Background(), key.ID+1000, time.Now())
+
+	//-- Post-conditions ----------
+	assert.Equal(test, sql.ErrNoRows, touchErr)
+}
+
+// testHashedSecret issues a key through a service so the store holds what the service really writes, only the hash of
+// the secret, and the secret is verified against that hash when it is looked up again
+func testHashedSecret(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var service apikey.Service
+	var listed []apikey.Key
+	var verified *apikey.Key
+
+	var secret string
+	var createErr, verifyErr, tamperedErr error
+
+	//-- Test Parameters ----------
+	var ctx = within(`conformance-mine`)
+
+	//-- Pre-conditions ----------
+	service = apikey.NewService(store)
+	secret, createErr = service.Create(ctx, &apikey.Key{Name: `Testing hashed secret`, Scopes: []apikey.Scope{apikey.ReadTasks}})
+
+	//-- Action ----------
+	listed, _ = store.List(ctx)
+	verified, verifyErr = service.Verify(context.Background(), secret)
+	_, tamperedErr = service.Verify(context.Background(), tamper(secret))
+
+	//-- Post-conditions ----------
+	assert.Nil(test, createErr)
+	assert.Nil(test, verifyErr)
+	assert.Equal(test, apikey.ErrInvalidKey, tamperedErr)
+
+	if assert.Equal(test, 1, len(listed)) {
+		assert.NotContains(test, listed[0].Hash, secret)
+		assert.NotContains(test, secret, listed[0].Hash)
+		assert.Contains(test, secret, listed[0].Prefix)
+	}
+
+	if assert.NotNil(test, verified) {
+		assert.Equal(test, listed[0].ID, verified.ID)
+		assert.Equal(test, `conformance-mine`, verified.TenantID)
+	}
+}
+
+//-- Tenant & Owner Checks ---------------------------------------------------------------------------------------------
+func testTenantList(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var mine []*apikey.Key
+	var theirs *apikey.Key
+	var listed []apikey.Key
+	var listErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing tenant list`
+
+	//-- Pre-conditions ----------
+	mine = append(mine, insertKey(test, store, within(`conformance-mine`), name, `conformance-owner`))
+	theirs = insertKey(test, store, within(`conformance-theirs`), name, `conformance-owner`)
+	mine = append(mine, insertKey(test, store, within(`conformance-mine`), name, `conformance-owner`))
+
+	//-- Action ----------
+	listed, listErr = store.List(within(`conformance-mine`))
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.Equal(test, keyIDs(mine...), ids(listed))
+	assert.NotContains(test, ids(listed), theirs.ID)
+}
+
+func testTenantRevoke(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var theirs, found *apikey.Key
+	var revokeErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	theirs = insertKey(test, store, within(`conformance-theirs`), `Testing tenant revoke`, `conformance-owner`)
+
+	//-- Action ----------
+	_, revokeErr = store.Revoke(within(`conformance-mine`), theirs.ID)
+	found, _ = store.Lookup(context.Background(), theirs.Prefix)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, sql.ErrNoRows, revokeErr)
+
+	if assert.NotNil(test, found) {
+		assert.Nil(test, found.RevokedAt)
+	}
+}
+
+func testTenantLookup(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var theirs, found *apikey.Key
+	var lookupErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	theirs = insertKey(test, store, within(`conformance-theirs`), `Testing tenant lookup`, `conformance-owner`)
+
+	//-- Action ----------
+	found, lookupErr = store.Lookup(within(`conformance-mine`), theirs.Prefix)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, lookupErr)
+
+	if assert.NotNil(test, found) {
+		assert.Equal(test, `conformance-theirs`, found.TenantID)
+	}
+}
+
+func testOwnerList(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var mine, theirs *apikey.Key
+	var memberKeys, adminKeys []apikey.Key
+	var memberErr, adminErr error
+
+	//-- Test Parameters ----------
+	var tenant = `conformance-mine`
+	var member = as(tenant, `conformance-member`, string(task.MemberRole))
+
+	//-- Pre-conditions ----------
+	mine = insertKey(test, store, member, `Testing owner list`, `conformance-member`)
+	theirs = insertKey(test, store, within(tenant), `Testing owner list`, `conformance-other`)
+
+	//-- Action ----------
+	memberKeys, memberErr = store.List(member)
+	adminKeys, adminErr = store.List(within(tenant))
+
+	//-- Post-conditions ----------
+	assert.Nil(test, memberErr)
+	assert.Nil(test, adminErr)
+	assert.Equal(test, keyIDs(mine), ids(memberKeys))
+	assert.Equal(test, keyIDs(mine, theirs), ids(adminKeys))
+}
+
+func testOwnerRevoke(test *testing.T, store apikey.Store) {
+	//-- Shared Variables ----------
+	var mine, theirs *apikey.Key
+	var mineErr, theirsErr error
+
+	//-- Test Parameters ----------
+	var tenant = `conformance-mine`
+	var member = as(tenant, `conformance-member`, string(task.MemberRole))
+
+	//-- Pre-conditions ----------
+	mine = insertKey(test, store, member, `Testing owner revoke`, `conformance-member`)
+	theirs = insertKey(test, store, within(tenant), `Testing owner revoke`, `conformance-other`)
+
+	//-- Action ----------
+	_, mineErr = store.Revoke(member, mine.ID)
+	_, theirsErr = store.Revoke(member, theirs.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, mineErr)
+	assert.Equal(test, sql.ErrNoRows, theirsErr)
+}
@@ -0,0 +1,83 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"errors"
+	"fmt"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// MaxBatchSize is the most items a single bulk call may carry, larger imports are split into several batches
+	MaxBatchSize = 100
+
+	// Atomic stores every item of a batch or none of them, the first item to fail rolls back the others
+	Atomic BatchMode = `atomic`
+	// PerItem stores every item of a batch which succeeds and reports the failure of each item which does not
+	PerItem BatchMode = `per_item`
+)
+
+var (
+	ErrBatchAborted     = errors.New(`the item was not stored because another item of the atomic batch failed`)
+	ErrBatchTooLarge    = errors.New(fmt.Sprintf(`a batch may carry at most %d items`, MaxBatchSize))
+	ErrInvalidBatchMode = errors.New(fmt.Sprintf(`the batch mode must be '%s' or '%s'`, Atomic, PerItem))
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// BatchMode chooses whether a bulk call stores its items all together (Atomic) or one by one (PerItem)
+type BatchMode string
+
+// BatchResult is the outcome of the item at Index of a batch, Task is the Task as it was stored and is nil when Err
+// is set
+type BatchResult struct {
+	Index int
+	Task  *Task
+	Err   error
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// Validate rejects a mode other than Atomic or PerItem
+func (mode BatchMode) Validate() error {
+	if mode != Atomic && mode != PerItem {
+		return ErrInvalidBatchMode
+	}
+	return nil
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// checkBatch rejects a batch of size items which is too large or names an unknown mode
+func checkBatch(size int, mode BatchMode) error {
+	if err := mode.Validate(); err != nil {
+		return err
+	} else if size > MaxBatchSize {
+		return ErrBatchTooLarge
+	}
+	return nil
+}
+
+// aborted is the outcome of an atomic batch of size items rolled back because the item at failed returned err, every
+// other item is reported as ErrBatchAborted
+func aborted(size int, failed int, err error) []BatchResult {
+	var results = make([]BatchResult, size)
+
+	for index := range results {
+		results[index] = BatchResult{Index: index, Err: ErrBatchAborted}
+	}
+	results[failed].Err = err
+
+	return results
+}
+
+// failures counts the items of results which were not stored
+func failures(results []BatchResult) int {
+	var failed int
+
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	return failed
+}
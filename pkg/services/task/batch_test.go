@@ -0,0 +1,61 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestBatchModeValidate(test *testing.T) {
+	//-- Shared Variables ----------
+	var outcomes = make(map[BatchMode]error)
+
+	//-- Test Parameters ----------
+	var expected = map[BatchMode]error{
+		Atomic:   nil,
+		PerItem:  nil,
+		``:       ErrInvalidBatchMode,
+		`ATOMIC`: ErrInvalidBatchMode,
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	for mode := range expected {
+		outcomes[mode] = mode.Validate()
+	}
+
+	//-- Post-conditions ----------
+	assert.Equal(test, expected, outcomes)
+}
+
+func TestBatchAborted(test *testing.T) {
+	//-- Shared Variables ----------
+	var results []BatchResult
+
+	//-- Test Parameters ----------
+	var failure = errors.New(`item failed`)
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	results = aborted(3, 1, failure)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, []BatchResult{
+		{Index: 0, Err: ErrBatchAborted},
+		{Index: 1, Err: failure},
+		{Index: 2, Err: ErrBatchAborted},
+	}, results)
+	assert.Equal(test, 3, failures(results))
+}
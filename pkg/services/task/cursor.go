@@ -0,0 +1,112 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+var (
+	ErrInvalidCursor = errors.New(`the pagination cursor is malformed or was not issued by this service`)
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Cursor marks a position in a sorted set of Tasks using the sort key (Value, ID) of the Task at that position. A
+// forward cursor selects the Tasks after the position and a backward cursor selects the Tasks before it.
+type Cursor struct {
+	ID       uint `json:"id"`
+	Backward bool `json:"backward,omitempty"`
+
+	Field     SortField     `json:"field,omitempty"`
+	Direction SortDirection `json:"direction,omitempty"`
+	Value     *time.Time    `json:"value,omitempty"`
+}
+
+// Page is one keyset paginated slice of Tasks along with the opaque cursors needed to fetch its neighbours. A cursor is
+// empty when there is no page in that direction.
+type Page struct {
+	Tasks    []Task
+	Next     string
+	Previous string
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+func (cursor Cursor) Encode() string {
+	var output, _ = json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(output)
+}
+
+// DecodeCursor parses a value produced by Cursor.Encode, an empty value yields a nil cursor (the first page)
+func DecodeCursor(value string) (*Cursor, error) {
+	var cursor = new(Cursor)
+
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	if decoded, err := base64.RawURLEncoding.DecodeString(value); err != nil {
+		return nil, ErrInvalidCursor
+	} else if err := json.Unmarshal(decoded, cursor); err != nil {
+		return nil, ErrInvalidCursor
+	} else if cursor.ID == 0 {
+		return nil, ErrInvalidCursor
+	}
+
+	//-- Cursors issued before sorting was supported are always by ascending ID ----------
+	cursor.Field = Sort{Field: cursor.Field}.field()
+	cursor.Direction = Sort{Direction: cursor.Direction}.direction()
+
+	return cursor, nil
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+func newPage(sort Sort, cursor *Cursor, limit uint, tasks []Task) *Page {
+	var page = &Page{Tasks: tasks}
+	var more = uint(len(tasks)) > limit
+
+	//-- Trim the look-ahead record ----------
+	if more && cursor != nil && cursor.Backward {
+		page.Tasks = tasks[1:]
+	} else if more {
+		page.Tasks = tasks[:limit]
+	}
+
+	if len(page.Tasks) == 0 {
+		return page
+	}
+
+	//-- Neighbouring cursors ----------
+	var first, last = page.Tasks[0], page.Tasks[len(page.Tasks)-1]
+
+	if cursor != nil && cursor.Backward {
+		page.Next = sort.cursor(last, false).Encode()
+		if more {
+			page.Previous = sort.cursor(first, true).Encode()
+		}
+	} else {
+		if more {
+			page.Next = sort.cursor(last, false).Encode()
+		}
+		if cursor != nil {
+			page.Previous = sort.cursor(first, true).Encode()
+		}
+	}
+
+	return page
+}
+
+// matches reports whether cursor was issued for a listing with the same sort as the current one
+func (cursor Cursor) matches(sort Sort) bool {
+	if cursor.Field != sort.field() || cursor.Direction != sort.direction() {
+		return false
+	}
+
+	if cursor.Field == SortByID {
+		return cursor.Value == nil
+	}
+	return cursor.Field != SortByCreatedAt || cursor.Value != nil
+}
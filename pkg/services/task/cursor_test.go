@@ -0,0 +1,199 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+func newSequentialTasks(first uint, last uint) []Task {
+	var tasks = make([]Task, 0)
+
+	for id := first; id <= last; id++ {
+		tasks = append(tasks, Task{ID: id})
+	}
+
+	return tasks
+}
+
+func decodeCursor(test *testing.T, value string) *Cursor {
+	var cursor, err = DecodeCursor(value)
+
+	if err != nil {
+		test.Fatalf(`unexpected error when decoding cursor: %s`, err)
+	}
+
+	return cursor
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestCursorEncodeDecode(test *testing.T) {
+	//-- Shared Variables ----------
+	var cursor *Cursor
+	var decodeErr error
+
+	//-- Test Parameters ----------
+	var original = Cursor{ID: 42, Backward: true, Field: SortByID, Direction: Ascending}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	cursor, decodeErr = DecodeCursor(original.Encode())
+
+	//-- Post-conditions ----------
+	assert.Nil(test, decodeErr)
+	assert.Equal(test, original, *cursor)
+}
+
+func TestCursorDecodeEmpty(test *testing.T) {
+	//-- Shared Variables ----------
+	var cursor *Cursor
+	var decodeErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	cursor, decodeErr = DecodeCursor(``)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, decodeErr)
+	assert.Nil(test, cursor)
+}
+
+func TestCursorDecodeMalformed(test *testing.T) {
+	//-- Shared Variables ----------
+	var cursor *Cursor
+	var decodeErr error
+
+	//-- Test Parameters ----------
+	var value = `not a cursor!`
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	cursor, decodeErr = DecodeCursor(value)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, ErrInvalidCursor, decodeErr)
+	assert.Nil(test, cursor)
+}
+
+func TestCursorDecodeZeroID(test *testing.T) {
+	//-- Shared Variables ----------
+	var cursor *Cursor
+	var decodeErr error
+
+	//-- Test Parameters ----------
+	var value = Cursor{ID: 0}.Encode()
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	cursor, decodeErr = DecodeCursor(value)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, ErrInvalidCursor, decodeErr)
+	assert.Nil(test, cursor)
+}
+
+func TestCursorNewPageFirst(test *testing.T) {
+	//-- Shared Variables ----------
+	var page *Page
+
+	//-- Test Parameters ----------
+	var limit uint = 3
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	page = newPage(Sort{}, nil, limit, newSequentialTasks(1, 4))
+
+	//-- Post-conditions ----------
+	assert.Equal(test, 3, len(page.Tasks))
+	assert.Equal(test, ``, page.Previous)
+	assert.Equal(test, Cursor{ID: 3, Field: SortByID, Direction: Ascending}, *decodeCursor(test, page.Next))
+}
+
+func TestCursorNewPageLast(test *testing.T) {
+	//-- Shared Variables ----------
+	var page *Page
+
+	//-- Test Parameters ----------
+	var limit uint = 3
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	page = newPage(Sort{}, &Cursor{ID: 3}, limit, newSequentialTasks(4, 5))
+
+	//-- Post-conditions ----------
+	assert.Equal(test, 2, len(page.Tasks))
+	assert.Equal(test, ``, page.Next)
+	assert.Equal(test, Cursor{ID: 4, Backward: true, Field: SortByID, Direction: Ascending}, *decodeCursor(test, page.Previous))
+}
+
+func TestCursorNewPageBackward(test *testing.T) {
+	//-- Shared Variables ----------
+	var page *Page
+
+	//-- Test Parameters ----------
+	var limit uint = 3
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	page = newPage(Sort{}, &Cursor{ID: 6, Backward: true}, limit, newSequentialTasks(2, 5))
+
+	//-- Post-conditions ----------
+	if assert.Equal(test, 3, len(page.Tasks)) {
+		assert.Equal(test, uint(3), page.Tasks[0].ID)
+		assert.Equal(test, uint(5), page.Tasks[2].ID)
+	}
+	assert.Equal(test, Cursor{ID: 5, Field: SortByID, Direction: Ascending}, *decodeCursor(test, page.Next))
+	assert.Equal(test, Cursor{ID: 3, Backward: true, Field: SortByID, Direction: Ascending}, *decodeCursor(test, page.Previous))
+}
+
+func TestCursorNewPageBackwardFirst(test *testing.T) {
+	//-- Shared Variables ----------
+	var page *Page
+
+	//-- Test Parameters ----------
+	var limit uint = 3
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	page = newPage(Sort{}, &Cursor{ID: 4, Backward: true}, limit, newSequentialTasks(1, 3))
+
+	//-- Post-conditions ----------
+	assert.Equal(test, 3, len(page.Tasks))
+	assert.Equal(test, Cursor{ID: 3, Field: SortByID, Direction: Ascending}, *decodeCursor(test, page.Next))
+	assert.Equal(test, ``, page.Previous)
+}
+
+func TestCursorNewPageEmpty(test *testing.T) {
+	//-- Shared Variables ----------
+	var page *Page
+
+	//-- Test Parameters ----------
+	var limit uint = 0
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	page = newPage(Sort{}, nil, limit, newSequentialTasks(1, 1))
+
+	//-- Post-conditions ----------
+	assert.Equal(test, 0, len(page.Tasks))
+	assert.Equal(test, ``, page.Next)
+	assert.Equal(test, ``, page.Previous)
+}
@@ -0,0 +1,53 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task_test
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"fmt"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+type countingStore struct {
+	task.Store
+	reads int
+}
+
+func (store *countingStore) Read(ctx context.Context, id uint) (*task.Task, error) {
+	store.reads++
+	return store.Store.Read(ctx, id)
+}
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func ExampleStore() {
+	var ctx = context.Background()
+	var store = &countingStore{Store: task.NewMemoryStore()}
+
+	if err := store.Open(``); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var service = task.NewService(nil, store)
+	defer service.Shutdown()
+
+	var subject = &task.Task{Name: `Wrapped store example`}
+	if err := service.Create(ctx, subject); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if _, err := service.Read(ctx, subject.ID); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(store.reads)
+	// Output: 1
+}
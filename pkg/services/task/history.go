@@ -0,0 +1,171 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	Created  Action = `create`
+	Updated  Action = `update`
+	Deleted  Action = `delete`
+	Restored Action = `restore`
+	Purged   Action = `purge`
+
+	// AnonymousActor is recorded against changes made through a context which carries no actor
+	AnonymousActor = `anonymous`
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Action names the operation which produced a History entry
+type Action string
+
+// Change is the old and new value of a single Task field, a nil value means the field was empty. Timestamps are
+// formatted as RFC3339 so every field can be compared and stored the same way.
+type Change struct {
+	Field string  `json:"field"`
+	Old   *string `json:"old"`
+	New   *string `json:"new"`
+}
+
+// History is one entry in the audit trail of a Task, recorded in the same transaction as the change it describes.
+// Version is the version of the Task after the change, OwnerID and TenantID its owner and tenant, which confine the
+// entry to the same Scope as the Task even after the Task is purged.
+type History struct {
+	ID       uint
+	TaskID   uint
+	OwnerID  string
+	TenantID string
+	Action   Action
+	Actor    string
+	Version  uint
+	Changes  []Change
+
+	CreatedAt time.Time
+}
+
+// HistoryPage is one keyset paginated slice of History entries in the order they were recorded, Next is empty on the
+// last page
+type HistoryPage struct {
+	Entries []History
+	Next    string
+}
+
+type actorKey struct{}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// WithActor returns a copy of ctx which records actor against every change made through it
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor carried by ctx, falling back to the subject of its authenticated principal and then to
+// AnonymousActor
+func ActorFrom(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && len(actor) > 0 {
+		return actor
+	} else if principal := auth.PrincipalFrom(ctx); principal != nil && len(principal.Subject) > 0 {
+		return principal.Subject
+	}
+	return AnonymousActor
+}
+
+func (history History) String() string {
+	return fmt.Sprintf(`{ID: %d, TaskID: %d, OwnerID: %s, TenantID: %s, Action: %s, Actor: %s, Version: %d, Changes: %d, CreatedAt: %s}`, history.ID, history.TaskID, history.OwnerID, history.TenantID, history.Action, history.Actor, history.Version, len(history.Changes), history.CreatedAt)
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// newHistoryPage trims entries, fetched with one more than limit, to a page and issues the cursor for the next page
+// when the extra entry shows there is one
+func newHistoryPage(entries []History, limit uint) *HistoryPage {
+	var page = &HistoryPage{Entries: entries}
+
+	if uint(len(entries)) > limit {
+		page.Entries = entries[:limit]
+
+		if limit > 0 {
+			page.Next = Cursor{ID: entries[limit-1].ID, Field: SortByID, Direction: Ascending}.Encode()
+		}
+	}
+
+	return page
+}
+
+// newHistory describes the change from before to after, before is nil when the Task was created
+func newHistory(ctx context.Context, action Action, before *Task, after Task, timestamp time.Time) History {
+	return History{
+		TaskID:    after.ID,
+		OwnerID:   after.OwnerID,
+		TenantID:  after.TenantID,
+		Action:    action,
+		Actor:     ActorFrom(ctx),
+		Version:   after.Version,
+		Changes:   diff(before, after),
+		CreatedAt: timestamp,
+	}
+}
+
+// diff lists the user visible fields which differ between before and after, every non-empty field of after when
+// before is nil
+func diff(before *Task, after Task) []Change {
+	var changes = make([]Change, 0)
+	var previous Task
+
+	if before != nil {
+		previous = *before
+	}
+
+	for _, field := range []struct {
+		name     string
+		old, new *string
+	}{
+		{`name`, formatString(previous.Name), formatString(after.Name)},
+		{`details`, previous.Details, after.Details},
+		{`resolved_at`, formatTime(previous.ResolvedAt), formatTime(after.ResolvedAt)},
+		{`deleted_at`, formatTime(previous.DeletedAt), formatTime(after.DeletedAt)},
+	} {
+		if !equalValues(field.old, field.new) {
+			changes = append(changes, Change{Field: field.name, Old: copyValue(field.old), New: copyValue(field.new)})
+		}
+	}
+
+	return changes
+}
+
+func formatString(value string) *string {
+	if len(value) == 0 {
+		return nil
+	}
+	return &value
+}
+
+func formatTime(value *time.Time) *string {
+	if value == nil {
+		return nil
+	}
+
+	var formatted = value.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)
+	return &formatted
+}
+
+func equalValues(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func copyValue(value *string) *string {
+	if value == nil {
+		return nil
+	}
+
+	var copied = *value
+	return &copied
+}
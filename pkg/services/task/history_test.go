@@ -0,0 +1,101 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestHistoryActor(test *testing.T) {
+	//-- Shared Variables ----------
+	var anonymous, named, empty, authenticated, explicit string
+
+	//-- Test Parameters ----------
+	var actor = `user-1`
+	var principal = &auth.Principal{Subject: `user-2`}
+
+	//-- Pre-conditions ----------
+	var ctx = context.Background()
+
+	//-- Action ----------
+	anonymous = ActorFrom(ctx)
+	named = ActorFrom(WithActor(ctx, actor))
+	empty = ActorFrom(WithActor(ctx, ``))
+	authenticated = ActorFrom(auth.WithPrincipal(ctx, principal))
+	explicit = ActorFrom(WithActor(auth.WithPrincipal(ctx, principal), actor))
+
+	//-- Post-conditions ----------
+	assert.Equal(test, AnonymousActor, anonymous)
+	assert.Equal(test, actor, named)
+	assert.Equal(test, AnonymousActor, empty)
+	assert.Equal(test, principal.Subject, authenticated)
+	assert.Equal(test, actor, explicit)
+}
+
+func TestHistoryDiff(test *testing.T) {
+	//-- Shared Variables ----------
+	var created, updated, unchanged []Change
+
+	//-- Test Parameters ----------
+	var details = `Some details`
+	var resolvedAt = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var before = Task{ID: 1, Name: `Before`, Details: &details, Version: 1}
+	var after = Task{ID: 1, Name: `After`, ResolvedAt: &resolvedAt, Version: 2}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	created = diff(nil, before)
+	updated = diff(&before, after)
+	unchanged = diff(&after, after)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, []Change{
+		{Field: `name`, New: &before.Name},
+		{Field: `details`, New: &details},
+	}, created)
+
+	var formatted = `2019-01-01T00:00:00Z`
+	assert.Equal(test, []Change{
+		{Field: `name`, Old: &before.Name, New: &after.Name},
+		{Field: `details`, Old: &details},
+		{Field: `resolved_at`, New: &formatted},
+	}, updated)
+
+	assert.Empty(test, unchanged)
+}
+
+func TestHistoryPage(test *testing.T) {
+	//-- Shared Variables ----------
+	var full, last *HistoryPage
+
+	//-- Test Parameters ----------
+	var entries = []History{{ID: 3}, {ID: 5}, {ID: 8}}
+	var limit uint = 2
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	full = newHistoryPage(entries, limit)
+	last = newHistoryPage(entries[:2], limit)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, entries[:2], full.Entries)
+	assert.Equal(test, Cursor{ID: 5, Field: SortByID, Direction: Ascending}.Encode(), full.Next)
+
+	assert.Equal(test, entries[:2], last.Entries)
+	assert.Equal(test, ``, last.Next)
+}
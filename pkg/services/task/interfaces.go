@@ -4,48 +4,132 @@ package task
 //-- Imports -----------------------------------------------------------------------------------------------------------
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 //-- Constants ---------------------------------------------------------------------------------------------------------
 type Model interface {
 	compare() bool
-	sanitize() error
-	validate() error
+	Sanitize() error
+	Validate() error
 }
 
 type Service interface {
 	Create(ctx context.Context, task *Task) error
 	Update(ctx context.Context, task *Task) error
+	Patch(ctx context.Context, id uint, version uint, apply func(task *Task) error) (*Task, error)
+	Resolve(ctx context.Context, id uint) (*Task, error)
+	Reopen(ctx context.Context, id uint) (*Task, error)
 
 	Read(ctx context.Context, id uint) (*Task, error)
 	Delete(ctx context.Context, id uint) (*Task, error)
+	Restore(ctx context.Context, id uint) (*Task, error)
+	Purge(ctx context.Context, retention time.Duration) (uint, error)
 
-	List(ctx context.Context, limit uint, offset uint) ([]Task, error)
+	BulkCreate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error)
+	BulkUpdate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error)
+	BulkDelete(ctx context.Context, ids []uint, mode BatchMode) ([]BatchResult, error)
+
+	List(ctx context.Context, query Query, limit uint, offset uint) ([]Task, error)
+	Paginate(ctx context.Context, query Query, limit uint, cursor string) (*Page, error)
+
+	History(ctx context.Context, id uint, limit uint, cursor string) (*HistoryPage, error)
 
 	Shutdown() error
 }
 
 type Middleware func(Service) Service
 
+// MetricSink receives one Measurement for every call made through the metrics middleware. Implementations must be safe
+// for concurrent use as a single sink is shared by every request in the process.
+type MetricSink interface {
+	Record(ctx context.Context, measurement Measurement)
+}
+
+// Store is the persistence contract behind a Service. Implementations are expected to Sanitize and Validate a Task
+// before writing it, to return ErrIllAdvisedInsert when inserting a Task which already has an ID, to return
+// ErrVersionConflict when an update names a stale Version and to return sql.ErrNoRows when the requested Task does not
+// exist. Every read and change is confined to the ScopeFrom ctx, Tasks and History outside it are not found and are
+// left out of listings. Every change is recorded in the History of its Task, along with the actor carried by ctx,
+// atomically with the change itself.
 type Store interface {
+	// Open connects the store using an implementation specific set of options (e.g. a connection string)
 	Open(options string) error
+	// Close releases any resources held by the store
 	Close() error
 
+	// Prepare runs a schema operation (`up`, `down` or `drop`) using an implementation specific parameter
 	Prepare(option string, parameter string) error
 
-	insert(ctx context.Context, task *Task) error
-	update(ctx context.Context, task *Task) error
+	// Insert persists a new Task, assigning its ID, CreatedAt and a Version of 1
+	Insert(ctx context.Context, task *Task) error
+	// Update persists changes to an existing Task, assigning its UpdatedAt and incrementing its Version. A non-zero
+	// Version makes the update conditional on it matching the stored Version. task is then refreshed from the stored
+	// row, so fields Update leaves alone such as CreatedAt and OwnerID are filled in as well.
+	Update(ctx context.Context, task *Task) error
+	// Patch locks the Task with id, lets apply change a copy of it and persists the result as Update would, in a single
+	// transaction, then returns the stored Task. Only the user variables apply changes are kept, a non-zero version
+	// makes the patch conditional on it matching the stored Version and an error from apply abandons the patch. A patch
+	// which changes nothing is not stored, the Task is returned as it was without a new Version or History entry.
+	Patch(ctx context.Context, id uint, version uint, apply func(task *Task) error) (*Task, error)
+
+	// Read fetches a single Task by ID, Tasks in the trash are not found
+	Read(ctx context.Context, id uint) (*Task, error)
+	// Delete moves a single Task by ID to the trash by assigning its DeletedAt and returns it. Deleted Tasks are not
+	// found by Read, Update or Delete and are left out of listings unless the Query asks for them.
+	Delete(ctx context.Context, id uint) (*Task, error)
+	// Restore takes a single Task by ID out of the trash and returns it, a Task which is not deleted is not found
+	Restore(ctx context.Context, id uint) (*Task, error)
+	// Purge permanently removes every Task deleted before the given time and returns how many were removed
+	Purge(ctx context.Context, before time.Time) (uint, error)
 
-	read(ctx context.Context, id uint) (*Task, error)
-	delete(ctx context.Context, id uint) (*Task, error)
+	// BulkCreate inserts each of tasks as Insert would, all within a single transaction, and reports the outcome of
+	// each at its index. In Atomic mode the first item to fail rolls back the batch and every other item fails with
+	// ErrBatchAborted, in PerItem mode each item is stored or fails on its own. The given tasks are left unchanged and
+	// an error is only returned when the batch could not be run at all.
+	BulkCreate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error)
+	// BulkUpdate persists each of tasks as Update would, in a single transaction and reported as BulkCreate is
+	BulkUpdate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error)
+	// BulkDelete moves the Task with each of ids to the trash as Delete would, in a single transaction and reported as
+	// BulkCreate is
+	BulkDelete(ctx context.Context, ids []uint, mode BatchMode) ([]BatchResult, error)
 
-	list(ctx context.Context, limit uint, offset uint) ([]Task, error)
+	// List fetches at most limit Tasks matching and ordered by query, skipping the first offset Tasks
+	List(ctx context.Context, query Query, limit uint, offset uint) ([]Task, error)
+	// Seek fetches at most limit Tasks matching query on the requested side of cursor (from the start when nil),
+	// always returned in the order given by query
+	Seek(ctx context.Context, query Query, cursor *Cursor, limit uint) ([]Task, error)
+
+	// History fetches at most limit History entries of the Task with id, oldest first, starting after the entry with
+	// ID after (from the first entry when zero). Entries are kept after the Task is purged.
+	History(ctx context.Context, id uint, after uint, limit uint) ([]History, error)
+}
+
+// Pinger is implemented by stores which can report whether their connection is still usable, a Lifecycle reconnects
+// a store whose Ping fails
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Pooled is implemented by stores kept in a database/sql database, the stores of other services in the same database
+// borrow its connection pool rather than opening one of their own
+type Pooled interface {
+	Database() *sql.DB
 }
 
 //-- Structs -----------------------------------------------------------------------------------------------------------
+// ConnectionParameters describes the store a Lifecycle opens. Zero pool limits keep the database/sql defaults and a
+// zero HealthCheckInterval uses DefaultHealthCheckInterval, a negative interval checks on every reuse.
 type ConnectionParameters struct {
 	Driver  string
 	Options string
+
+	MaxOpenConnections    int
+	MaxIdleConnections    int
+	ConnectionMaxLifetime time.Duration
+
+	HealthCheckInterval time.Duration
 }
 
 //-- Exported Functions ------------------------------------------------------------------------------------------------
@@ -0,0 +1,172 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	PostgresDriver = `postgres`
+	MemoryDriver   = `memory`
+
+	DefaultHealthCheckInterval = 5 * time.Second
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Lifecycle owns a single store and service for the life of the process so that warm invocations reuse the open
+// connection pool. The store is opened lazily, its health is checked when it is reused and it is reopened after a
+// failed check or a failed open.
+type Lifecycle struct {
+	mutex sync.Mutex
+
+	parameters  ConnectionParameters
+	middlewares []Middleware
+
+	store   Store
+	service Service
+	checked time.Time
+}
+
+// lifecycleService hides Shutdown from handlers, the shared store is only closed through Lifecycle.Close
+type lifecycleService struct {
+	Service
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+func NewLifecycle(parameters ConnectionParameters, middlewares []Middleware) *Lifecycle {
+	return &Lifecycle{parameters: parameters, middlewares: middlewares}
+}
+
+// OpenStore builds and opens the store named by parameters.Driver (postgres when empty) and applies its pool limits
+func OpenStore(parameters ConnectionParameters) (Store, error) {
+	switch parameters.Driver {
+	case PostgresDriver, ``:
+		var store = new(postgresStore)
+
+		if err := store.Open(parameters.Options); err != nil {
+			return nil, err
+		}
+		store.limit(parameters)
+
+		return store, nil
+	case MemoryDriver:
+		var store = new(memoryStore)
+
+		if err := store.Open(parameters.Options); err != nil {
+			return nil, err
+		}
+
+		return store, nil
+	default:
+		return nil, errors.New(fmt.Sprintf(`lifecycle - unknown store driver '%s'`, parameters.Driver))
+	}
+}
+
+// Service returns the shared service, opening the store on first use and reopening it when a health check fails
+func (lifecycle *Lifecycle) Service(ctx context.Context) (Service, error) {
+	lifecycle.mutex.Lock()
+	defer lifecycle.mutex.Unlock()
+
+	if err := lifecycle.open(ctx); err != nil {
+		return nil, err
+	}
+
+	return lifecycle.service, nil
+}
+
+// Store returns the shared store, opened and checked as it is for Service, so the services of other records kept in
+// the same database reuse its connection pool (see Pooled). The store must not be closed by its caller.
+func (lifecycle *Lifecycle) Store(ctx context.Context) (Store, error) {
+	lifecycle.mutex.Lock()
+	defer lifecycle.mutex.Unlock()
+
+	if err := lifecycle.open(ctx); err != nil {
+		return nil, err
+	}
+
+	return lifecycle.store, nil
+}
+
+// Reset discards the current store so the next call to Service reconnects, it is safe to call when nothing is open
+func (lifecycle *Lifecycle) Reset() {
+	lifecycle.mutex.Lock()
+	defer lifecycle.mutex.Unlock()
+
+	lifecycle.discard()
+}
+
+// Close releases the shared store, a later call to Service opens a new one
+func (lifecycle *Lifecycle) Close() error {
+	lifecycle.mutex.Lock()
+	defer lifecycle.mutex.Unlock()
+
+	if lifecycle.store == nil {
+		return nil
+	}
+
+	var err = lifecycle.store.Close()
+	lifecycle.store, lifecycle.service = nil, nil
+
+	return err
+}
+
+// Shutdown is a no-op as the store outlives any single request
+func (service lifecycleService) Shutdown() error {
+	return nil
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// open makes sure a healthy store and its service are held, reusing the current store while it passes its checks
+func (lifecycle *Lifecycle) open(ctx context.Context) error {
+	//-- Reuse a healthy store ----------
+	if lifecycle.store != nil && !lifecycle.due() {
+		return nil
+	} else if lifecycle.store != nil {
+		if err := ping(ctx, lifecycle.store); err == nil {
+			lifecycle.checked = time.Now()
+			return nil
+		}
+		lifecycle.discard()
+	}
+
+	//-- Open a new store ----------
+	if store, err := OpenStore(lifecycle.parameters); err != nil {
+		return err
+	} else {
+		lifecycle.store = store
+		lifecycle.service = lifecycleService{NewService(lifecycle.middlewares, store)}
+		lifecycle.checked = time.Now()
+	}
+
+	return nil
+}
+
+func (lifecycle *Lifecycle) due() bool {
+	var interval = lifecycle.parameters.HealthCheckInterval
+	if interval == 0 {
+		interval = DefaultHealthCheckInterval
+	}
+
+	return interval < 0 || time.Since(lifecycle.checked) >= interval
+}
+
+func (lifecycle *Lifecycle) discard() {
+	if lifecycle.store != nil {
+		_ = lifecycle.store.Close()
+	}
+	lifecycle.store, lifecycle.service = nil, nil
+}
+
+// ping checks a store which can report its health, stores which cannot are assumed healthy
+func ping(ctx context.Context, store Store) error {
+	if pinger, ok := store.(Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
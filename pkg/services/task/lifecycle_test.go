@@ -0,0 +1,186 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+func closeLifecycle(test *testing.T, lifecycle *Lifecycle) {
+	if err := lifecycle.Close(); err != nil {
+		test.Fatalf(`an unexpected error occurred while closing the lifecycle: %s`, err)
+	}
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestLifecycleReuse(test *testing.T) {
+	//-- Shared Variables ----------
+	var lifecycle *Lifecycle
+	var firstStore, secondStore Store
+	var serviceErr error
+
+	//-- Test Parameters ----------
+	var parameters = ConnectionParameters{Driver: MemoryDriver, HealthCheckInterval: -1}
+
+	//-- Pre-conditions ----------
+	lifecycle = NewLifecycle(parameters, nil)
+	defer closeLifecycle(test, lifecycle)
+
+	if _, err := lifecycle.Service(context.Background()); err != nil {
+		test.Fatalf(`unexpected error when opening the service: %s`, err)
+	}
+	firstStore = lifecycle.store
+
+	//-- Action ----------
+	_, serviceErr = lifecycle.Service(context.Background())
+	secondStore = lifecycle.store
+
+	//-- Post-conditions ----------
+	assert.Nil(test, serviceErr)
+	assert.True(test, firstStore == secondStore)
+}
+
+func TestLifecycleReconnect(test *testing.T) {
+	//-- Shared Variables ----------
+	var lifecycle *Lifecycle
+	var firstStore, secondStore Store
+	var service Service
+	var serviceErr error
+
+	//-- Test Parameters ----------
+	var parameters = ConnectionParameters{Driver: MemoryDriver, HealthCheckInterval: -1}
+
+	//-- Pre-conditions ----------
+	lifecycle = NewLifecycle(parameters, nil)
+	defer closeLifecycle(test, lifecycle)
+
+	if _, err := lifecycle.Service(context.Background()); err != nil {
+		test.Fatalf(`unexpected error when opening the service: %s`, err)
+	}
+	firstStore = lifecycle.store
+
+	if err := firstStore.Close(); err != nil {
+		test.Fatalf(`unexpected error when closing the store: %s`, err)
+	}
+
+	//-- Action ----------
+	service, serviceErr = lifecycle.Service(context.Background())
+	secondStore = lifecycle.store
+
+	//-- Post-conditions ----------
+	assert.Nil(test, serviceErr)
+	assert.False(test, firstStore == secondStore)
+
+	if assert.NotNil(test, service) {
+		assert.Nil(test, service.Create(context.Background(), newValidTask()))
+	}
+}
+
+func TestLifecycleShutdownIgnored(test *testing.T) {
+	//-- Shared Variables ----------
+	var lifecycle *Lifecycle
+	var service Service
+	var shutdownErr error
+
+	//-- Test Parameters ----------
+	var parameters = ConnectionParameters{Driver: MemoryDriver}
+
+	//-- Pre-conditions ----------
+	lifecycle = NewLifecycle(parameters, nil)
+	defer closeLifecycle(test, lifecycle)
+
+	if result, err := lifecycle.Service(context.Background()); err != nil {
+		test.Fatalf(`unexpected error when opening the service: %s`, err)
+	} else {
+		service = result
+	}
+
+	//-- Action ----------
+	shutdownErr = service.Shutdown()
+
+	//-- Post-conditions ----------
+	assert.Nil(test, shutdownErr)
+	assert.Nil(test, ping(context.Background(), lifecycle.store))
+}
+
+func TestLifecycleReset(test *testing.T) {
+	//-- Shared Variables ----------
+	var lifecycle *Lifecycle
+	var firstStore Store
+	var serviceErr error
+
+	//-- Test Parameters ----------
+	var parameters = ConnectionParameters{Driver: MemoryDriver}
+
+	//-- Pre-conditions ----------
+	lifecycle = NewLifecycle(parameters, nil)
+	defer closeLifecycle(test, lifecycle)
+
+	if _, err := lifecycle.Service(context.Background()); err != nil {
+		test.Fatalf(`unexpected error when opening the service: %s`, err)
+	}
+	firstStore = lifecycle.store
+
+	//-- Action ----------
+	lifecycle.Reset()
+	_, serviceErr = lifecycle.Service(context.Background())
+
+	//-- Post-conditions ----------
+	assert.Nil(test, serviceErr)
+	assert.False(test, firstStore == lifecycle.store)
+	assert.NotNil(test, firstStore.Close())
+}
+
+func TestLifecycleUnknownDriver(test *testing.T) {
+	//-- Shared Variables ----------
+	var lifecycle *Lifecycle
+	var service Service
+	var serviceErr error
+
+	//-- Test Parameters ----------
+	var parameters = ConnectionParameters{Driver: `carrier pigeon`}
+
+	//-- Pre-conditions ----------
+	lifecycle = NewLifecycle(parameters, nil)
+
+	//-- Action ----------
+	service, serviceErr = lifecycle.Service(context.Background())
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, serviceErr)
+	assert.Nil(test, service)
+	assert.Nil(test, lifecycle.Close())
+}
+
+func TestLifecycleStore(test *testing.T) {
+	//-- Shared Variables ----------
+	var lifecycle *Lifecycle
+	var store Store
+	var storeErr error
+
+	//-- Test Parameters ----------
+	var parameters = ConnectionParameters{Driver: MemoryDriver, HealthCheckInterval: -1}
+
+	//-- Pre-conditions ----------
+	lifecycle = NewLifecycle(parameters, nil)
+	defer closeLifecycle(test, lifecycle)
+
+	if _, err := lifecycle.Service(context.Background()); err != nil {
+		test.Fatalf(`unexpected error when opening the service: %s`, err)
+	}
+
+	//-- Action ----------
+	store, storeErr = lifecycle.Store(context.Background())
+
+	//-- Post-conditions ----------
+	assert.Nil(test, storeErr)
+	assert.True(test, store == lifecycle.store)
+}
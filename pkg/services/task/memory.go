@@ -0,0 +1,545 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+var (
+	errMemoryStoreClosed = errors.New(`memory store was not initialized or in a connected state`)
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type memoryStore struct {
+	mutex    sync.RWMutex
+	sequence uint
+	tasks    map[uint]Task
+	history  []History
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+func NewMemoryStore() Store {
+	return new(memoryStore)
+}
+
+func (store *memoryStore) Open(options string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.tasks == nil {
+		store.tasks = make(map[uint]Task)
+	}
+
+	return nil
+}
+
+func (store *memoryStore) Close() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.tasks == nil {
+		return errMemoryStoreClosed
+	}
+
+	store.tasks = nil
+	store.history = nil
+	store.sequence = 0
+
+	return nil
+}
+
+func (store *memoryStore) Ping(ctx context.Context) error {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	if store.tasks == nil {
+		return errMemoryStoreClosed
+	}
+	return nil
+}
+
+func (store *memoryStore) Prepare(option string, parameter string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.tasks == nil {
+		return errMemoryStoreClosed
+	}
+
+	switch option {
+	case `up`:
+		return nil
+	case `down`, `drop`:
+		store.tasks = make(map[uint]Task)
+		store.history = nil
+		store.sequence = 0
+		return nil
+	default:
+		return errors.New(`no valid option provided, unable to prepare`)
+	}
+}
+
+func (store *memoryStore) Insert(ctx context.Context, task *Task) error {
+	//-- Common variables ----------
+	var timestamp = time.Now().UTC()
+
+	//-- Sanitize & validate ---------
+	if err := prepareInsert(ctx, task); err != nil {
+		return err
+	}
+
+	//-- Insert ----------
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.tasks == nil {
+		return errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	*task = store.insert(ctx, *task, timestamp)
+
+	return nil
+}
+
+func (store *memoryStore) Update(ctx context.Context, task *Task) error {
+	//-- Common variables ----------
+	var timestamp = time.Now().UTC()
+
+	//-- Sanitize & validate ---------
+	if err := task.Sanitize(); err != nil {
+		return err
+	} else if err := task.Validate(); err != nil {
+		return err
+	}
+
+	//-- Update ----------
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.tasks == nil {
+		return errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if stored, err := store.update(ctx, *task, timestamp); err != nil {
+		return err
+	} else {
+		*task = stored
+	}
+
+	return nil
+}
+
+func (store *memoryStore) Patch(ctx context.Context, id uint, version uint, apply func(task *Task) error) (*Task, error) {
+	//-- Common variables ----------
+	var timestamp = time.Now().UTC()
+
+	//-- Patch ----------
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.tasks == nil {
+		return nil, errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if existing, ok := store.tasks[id]; !ok || existing.DeletedAt != nil || !ScopeFrom(ctx).Allows(existing) {
+		return nil, sql.ErrNoRows
+	} else if version != 0 && version != existing.Version {
+		return nil, ErrVersionConflict
+	} else if task, err := patched(existing, apply); err != nil {
+		return nil, err
+	} else if len(diff(&existing, task)) == 0 {
+		var unchanged = cloneTask(existing)
+		return &unchanged, nil
+	} else {
+		var before = cloneTask(existing)
+		var after = updated(before, task, existing.Version+1, timestamp)
+
+		store.tasks[id] = cloneTask(after)
+		store.record(newHistory(ctx, Updated, &before, after, timestamp))
+
+		var stored = cloneTask(after)
+		return &stored, nil
+	}
+}
+
+func (store *memoryStore) Read(ctx context.Context, id uint) (*Task, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	if store.tasks == nil {
+		return nil, errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if existing, ok := store.tasks[id]; !ok || existing.DeletedAt != nil || !ScopeFrom(ctx).Allows(existing) {
+		return nil, sql.ErrNoRows
+	} else {
+		var task = cloneTask(existing)
+		return &task, nil
+	}
+}
+
+func (store *memoryStore) Delete(ctx context.Context, id uint) (*Task, error) {
+	//-- Common variables ----------
+	var timestamp = time.Now().UTC()
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.tasks == nil {
+		return nil, errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return store.delete(ctx, id, timestamp)
+}
+
+func (store *memoryStore) Restore(ctx context.Context, id uint) (*Task, error) {
+	//-- Common variables ----------
+	var timestamp = time.Now().UTC()
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.tasks == nil {
+		return nil, errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if existing, ok := store.tasks[id]; !ok || existing.DeletedAt == nil || !ScopeFrom(ctx).Allows(existing) {
+		return nil, sql.ErrNoRows
+	} else {
+		var before = cloneTask(existing)
+
+		existing.DeletedAt = nil
+		store.tasks[id] = cloneTask(existing)
+		store.record(newHistory(ctx, Restored, &before, existing, timestamp))
+
+		var task = cloneTask(existing)
+		return &task, nil
+	}
+}
+
+func (store *memoryStore) Purge(ctx context.Context, before time.Time) (uint, error) {
+	//-- Common variables ----------
+	var purged uint
+	var timestamp = time.Now().UTC()
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.tasks == nil {
+		return 0, errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	for id, existing := range store.tasks {
+		if existing.DeletedAt != nil && existing.DeletedAt.Before(before) && ScopeFrom(ctx).Allows(existing) {
+			delete(store.tasks, id)
+			store.record(newHistory(ctx, Purged, &existing, existing, timestamp))
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+func (store *memoryStore) List(ctx context.Context, query Query, limit uint, offset uint) ([]Task, error) {
+	//-- Common variables ----------
+	var tasks = make([]Task, 0)
+
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	if store.tasks == nil {
+		return nil, errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	} else if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	//-- Filter & order ----------
+	var matches = store.match(ScopeFrom(ctx), query, nil)
+
+	//-- Limit & offset ----------
+	for index := offset; index < uint(len(matches)) && uint(len(tasks)) < limit; index++ {
+		tasks = append(tasks, cloneTask(matches[index]))
+	}
+
+	return tasks, nil
+}
+
+func (store *memoryStore) Seek(ctx context.Context, query Query, cursor *Cursor, limit uint) ([]Task, error) {
+	//-- Common variables ----------
+	var tasks = make([]Task, 0)
+
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	if store.tasks == nil {
+		return nil, errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	} else if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	//-- Filter & order the records on the requested side of the cursor ----------
+	var matches = store.match(ScopeFrom(ctx), query, cursor)
+
+	//-- Keep the records closest to the cursor ----------
+	if cursor != nil && cursor.Backward && uint(len(matches)) > limit {
+		matches = matches[uint(len(matches))-limit:]
+	} else if uint(len(matches)) > limit {
+		matches = matches[:limit]
+	}
+
+	for _, match := range matches {
+		tasks = append(tasks, cloneTask(match))
+	}
+
+	return tasks, nil
+}
+
+func (store *memoryStore) BulkCreate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error) {
+	//-- Common variables ----------
+	var timestamp = time.Now().UTC()
+
+	//-- Batch ----------
+	return store.batch(ctx, len(tasks), mode, func(index int) (*Task, error) {
+		var task = cloneTask(*tasks[index])
+
+		if err := prepareInsert(ctx, &task); err != nil {
+			return nil, err
+		}
+
+		var stored = store.insert(ctx, task, timestamp)
+		return &stored, nil
+	})
+}
+
+func (store *memoryStore) BulkUpdate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error) {
+	//-- Common variables ----------
+	var timestamp = time.Now().UTC()
+
+	//-- Batch ----------
+	return store.batch(ctx, len(tasks), mode, func(index int) (*Task, error) {
+		var task = cloneTask(*tasks[index])
+
+		if err := task.Sanitize(); err != nil {
+			return nil, err
+		} else if err := task.Validate(); err != nil {
+			return nil, err
+		} else if stored, err := store.update(ctx, task, timestamp); err != nil {
+			return nil, err
+		} else {
+			return &stored, nil
+		}
+	})
+}
+
+func (store *memoryStore) BulkDelete(ctx context.Context, ids []uint, mode BatchMode) ([]BatchResult, error) {
+	//-- Common variables ----------
+	var timestamp = time.Now().UTC()
+
+	//-- Batch ----------
+	return store.batch(ctx, len(ids), mode, func(index int) (*Task, error) {
+		return store.delete(ctx, ids[index], timestamp)
+	})
+}
+
+func (store *memoryStore) History(ctx context.Context, id uint, after uint, limit uint) ([]History, error) {
+	//-- Common variables ----------
+	var entries = make([]History, 0)
+
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	if store.tasks == nil {
+		return nil, errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range store.history {
+		if uint(len(entries)) >= limit {
+			break
+		} else if entry.TaskID == id && entry.ID > after && ScopeFrom(ctx).Allows(Task{OwnerID: entry.OwnerID, TenantID: entry.TenantID}) {
+			entries = append(entries, cloneHistory(entry))
+		}
+	}
+
+	return entries, nil
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// batch runs item for each of the size items of a batch while holding the lock, so no other call sees a batch half
+// stored. In Atomic mode the first failure restores the Tasks, History and sequence the batch began with.
+func (store *memoryStore) batch(ctx context.Context, size int, mode BatchMode, item func(index int) (*Task, error)) ([]BatchResult, error) {
+	var results = make([]BatchResult, size)
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.tasks == nil {
+		return nil, errMemoryStoreClosed
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	//-- Snapshot for rollback ----------
+	var sequence, recorded = store.sequence, len(store.history)
+	var tasks map[uint]Task
+
+	if mode != PerItem {
+		tasks = make(map[uint]Task, len(store.tasks))
+		for id, task := range store.tasks {
+			tasks[id] = task
+		}
+	}
+
+	//-- Items ----------
+	for index := range results {
+		if task, err := item(index); err != nil && mode != PerItem {
+			store.tasks, store.history, store.sequence = tasks, store.history[:recorded], sequence
+			return aborted(size, index, err), nil
+		} else if err != nil {
+			results[index] = BatchResult{Index: index, Err: err}
+		} else {
+			results[index] = BatchResult{Index: index, Task: task}
+		}
+	}
+
+	return results, nil
+}
+
+// insert stores task, which has been prepared by prepareInsert, and returns it as it was stored. The lock must be held.
+func (store *memoryStore) insert(ctx context.Context, task Task, timestamp time.Time) Task {
+	store.sequence++
+
+	var stored = inserted(task, store.sequence, 1, timestamp)
+
+	store.tasks[stored.ID] = cloneTask(stored)
+	store.record(newHistory(ctx, Created, nil, stored, timestamp))
+
+	return stored
+}
+
+// update stores the user variables of task, which has been sanitized and validated, over the stored Task and returns
+// it as it was stored. The lock must be held.
+func (store *memoryStore) update(ctx context.Context, task Task, timestamp time.Time) (Task, error) {
+	if existing, ok := store.tasks[task.ID]; !ok || existing.DeletedAt != nil || !ScopeFrom(ctx).Allows(existing) {
+		return Task{}, sql.ErrNoRows
+	} else if task.Version != 0 && task.Version != existing.Version {
+		return Task{}, ErrVersionConflict
+	} else {
+		var before = cloneTask(existing)
+		var stored = updated(before, task, existing.Version+1, timestamp)
+
+		store.tasks[task.ID] = cloneTask(stored)
+		store.record(newHistory(ctx, Updated, &before, stored, timestamp))
+
+		return cloneTask(stored), nil
+	}
+}
+
+// delete moves the Task with id to the trash and returns it. The lock must be held.
+func (store *memoryStore) delete(ctx context.Context, id uint, timestamp time.Time) (*Task, error) {
+	if existing, ok := store.tasks[id]; !ok || existing.DeletedAt != nil || !ScopeFrom(ctx).Allows(existing) {
+		return nil, sql.ErrNoRows
+	} else {
+		var before = cloneTask(existing)
+
+		existing.DeletedAt = &timestamp
+		store.tasks[id] = cloneTask(existing)
+		store.record(newHistory(ctx, Deleted, &before, existing, timestamp))
+
+		var task = cloneTask(existing)
+		return &task, nil
+	}
+}
+
+// record appends history to the audit trail assigning it the next ID, the caller must hold the write lock
+func (store *memoryStore) record(history History) {
+	history.ID = uint(len(store.history)) + 1
+	store.history = append(store.history, cloneHistory(history))
+}
+
+// match returns the Tasks within scope selected by query (and cursor when present) in query order, the caller must
+// hold the lock
+func (store *memoryStore) match(scope Scope, query Query, cursor *Cursor) []Task {
+	var matches = make([]Task, 0)
+
+	for _, task := range store.tasks {
+		if !scope.Allows(task) || !query.Filter.match(task) {
+			continue
+		} else if cursor != nil && cursor.Backward && !query.Sort.before(query.Sort.key(task), task.ID, cursor.Value, cursor.ID) {
+			continue
+		} else if cursor != nil && !cursor.Backward && !query.Sort.before(cursor.Value, cursor.ID, query.Sort.key(task), task.ID) {
+			continue
+		}
+
+		matches = append(matches, task)
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return query.Sort.less(matches[i], matches[j]) })
+
+	return matches
+}
+
+func cloneTask(task Task) Task {
+	var clone = task
+
+	//-- Match the database's microsecond timestamp resolution ----------
+	clone.CreatedAt = task.CreatedAt.Truncate(time.Microsecond)
+
+	if task.Details != nil {
+		var details = *task.Details
+		clone.Details = &details
+	}
+	if task.ResolvedAt != nil {
+		var resolvedAt = task.ResolvedAt.Truncate(time.Microsecond)
+		clone.ResolvedAt = &resolvedAt
+	}
+	if task.UpdatedAt != nil {
+		var updatedAt = task.UpdatedAt.Truncate(time.Microsecond)
+		clone.UpdatedAt = &updatedAt
+	}
+	if task.DeletedAt != nil {
+		var deletedAt = task.DeletedAt.Truncate(time.Microsecond)
+		clone.DeletedAt = &deletedAt
+	}
+
+	return clone
+}
+
+func cloneHistory(history History) History {
+	var clone = history
+
+	clone.CreatedAt = history.CreatedAt.Truncate(time.Microsecond)
+	clone.Changes = make([]Change, 0, len(history.Changes))
+
+	for _, change := range history.Changes {
+		clone.Changes = append(clone.Changes, Change{Field: change.Field, Old: copyValue(change.Old), New: copyValue(change.New)})
+	}
+
+	return clone
+}
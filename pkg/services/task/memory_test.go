@@ -0,0 +1,239 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+func openMemoryStore(test *testing.T) Store {
+	var store = NewMemoryStore()
+
+	if store == nil {
+		test.Fatal(`an unexpected error occurred while initializing the store`)
+	} else if err := store.Open(``); err != nil {
+		test.Fatalf(`an unexpected error occured while opening the memory store: %s`, err.Error())
+	}
+
+	return store
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestMemoryStoreNewStore(test *testing.T) {
+	//-- Shared Variables ----------
+	var store Store
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	store = NewMemoryStore()
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, store)
+	assert.Nil(test, store.(*memoryStore).tasks)
+}
+
+func TestMemoryStoreOpen(test *testing.T) {
+	//-- Shared Variables ----------
+	var store Store
+	var openErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	store = NewMemoryStore()
+
+	//-- Action ----------
+	openErr = store.Open(``)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, openErr)
+	assert.NotNil(test, store.(*memoryStore).tasks)
+}
+
+func TestMemoryStoreClose(test *testing.T) {
+	//-- Shared Variables ----------
+	var store Store
+	var closeErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	store = openMemoryStore(test)
+
+	//-- Action ----------
+	closeErr = store.Close()
+
+	//-- Post-conditions ----------
+	assert.Nil(test, closeErr)
+	assert.Nil(test, store.(*memoryStore).tasks)
+}
+
+func TestMemoryStoreCloseNotConnected(test *testing.T) {
+	//-- Shared Variables ----------
+	var store Store
+	var closeErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	store = NewMemoryStore()
+
+	//-- Action ----------
+	closeErr = store.Close()
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, closeErr)
+}
+
+func TestMemoryStoreClosedOperations(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var model *Task
+	var store Store
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	model = newValidTask()
+
+	store = NewMemoryStore()
+
+	//-- Action ----------
+	var insertErr = store.(*memoryStore).Insert(ctx, model)
+	var _, readErr = store.(*memoryStore).Read(ctx, 1)
+	var _, listErr = store.(*memoryStore).List(ctx, Query{}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, errMemoryStoreClosed, insertErr)
+	assert.Equal(test, errMemoryStoreClosed, readErr)
+	assert.Equal(test, errMemoryStoreClosed, listErr)
+}
+
+func TestMemoryStorePrepareDrop(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var store Store
+	var prepareErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	if err := store.(*memoryStore).Insert(ctx, newValidTask()); err != nil {
+		test.Fatalf(`unexpected error when inserting record: %s`, err)
+	}
+
+	//-- Action ----------
+	prepareErr = store.Prepare(`drop`, ``)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, prepareErr)
+	assert.Equal(test, 0, len(store.(*memoryStore).tasks))
+	assert.Equal(test, uint(0), store.(*memoryStore).sequence)
+}
+
+func TestMemoryStorePrepareInvalid(test *testing.T) {
+	//-- Shared Variables ----------
+	var store Store
+	var prepareErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	store = openMemoryStore(test)
+
+	//-- Action ----------
+	prepareErr = store.Prepare(`invalid_option`, ``)
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, prepareErr)
+}
+
+func TestMemoryStoreInsertIsolated(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var model, readTask *Task
+	var store Store
+
+	//-- Test Parameters ----------
+	var name = `Testing isolated memory insert`
+	var mutatedDetails = `Mutated after insert`
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	model = newValidTask()
+	model.Name = name
+
+	store = openMemoryStore(test)
+
+	if err := store.(*memoryStore).Insert(ctx, model); err != nil {
+		test.Fatalf(`unexpected error when inserting record: %s`, err)
+	}
+
+	//-- Action ----------
+	*model.Details = mutatedDetails
+
+	readTask, _ = store.(*memoryStore).Read(ctx, model.ID)
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, readTask)
+	assert.NotEqual(test, mutatedDetails, *readTask.Details)
+}
+
+func TestMemoryStoreConcurrentInsert(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var store Store
+	var group sync.WaitGroup
+	var listTasks []Task
+
+	//-- Test Parameters ----------
+	var name = `Testing concurrent memory insert`
+	var quantity = 50
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	//-- Action ----------
+	for i := 0; i < quantity; i++ {
+		group.Add(1)
+		go func(index int) {
+			defer group.Done()
+
+			var model = newValidTask()
+			model.Name = fmt.Sprintf(`%s %d`, name, index)
+
+			if err := store.(*memoryStore).Insert(ctx, model); err != nil {
+				test.Errorf(`unexpected error when inserting record: %s`, err)
+			}
+		}(i)
+	}
+	group.Wait()
+
+	listTasks, _ = store.(*memoryStore).List(ctx, Query{}, uint(quantity*2), 0)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, quantity, len(listTasks))
+	assert.Equal(test, uint(quantity), listTasks[quantity-1].ID)
+}
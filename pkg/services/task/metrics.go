@@ -0,0 +1,287 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// DefaultNamespace is the CloudWatch namespace metrics are published to when none is given
+	DefaultNamespace = `TaskService`
+
+	latencyMetric = `Latency`
+	countMetric   = `Count`
+	errorsMetric  = `Errors`
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Measurement describes a single call to the Service, ErrorClass is empty when the call succeeded
+type Measurement struct {
+	Operation  string
+	Latency    time.Duration
+	ErrorClass string
+	Timestamp  time.Time
+}
+
+type metricsMiddleware struct {
+	next Service
+	sink MetricSink
+}
+
+// emfSink writes every Measurement as one CloudWatch Embedded Metric Format object per line, CloudWatch extracts the
+// metrics from the log stream and aggregates the latency values into percentiles
+type emfSink struct {
+	lock      *sync.Mutex
+	writer    io.Writer
+	namespace string
+}
+
+type emfDocument struct {
+	Metadata   emfMetadata `json:"_aws"`
+	Operation  string      `json:"Operation"`
+	Latency    float64     `json:"Latency"`
+	Count      uint        `json:"Count"`
+	Errors     uint        `json:"Errors"`
+	ErrorClass string      `json:"ErrorClass,omitempty"`
+}
+
+type emfMetadata struct {
+	Timestamp         int64          `json:"Timestamp"`
+	CloudWatchMetrics []emfDirective `json:"CloudWatchMetrics"`
+}
+
+type emfDirective struct {
+	Namespace  string      `json:"Namespace"`
+	Dimensions [][]string  `json:"Dimensions"`
+	Metrics    []emfMetric `json:"Metrics"`
+}
+
+type emfMetric struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit"`
+}
+
+// MemoryMetricSink keeps every Measurement it receives so tests can inspect them
+type MemoryMetricSink struct {
+	lock         sync.Mutex
+	measurements []Measurement
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// NewMetricsMiddleware records the latency and outcome of every call to the Service in sink
+func NewMetricsMiddleware(sink MetricSink) Middleware {
+	return func(next Service) Service {
+		return metricsMiddleware{next, sink}
+	}
+}
+
+// NewEMFSink returns a MetricSink which writes Embedded Metric Format lines to writer, under namespace or
+// DefaultNamespace when it is empty
+func NewEMFSink(writer io.Writer, namespace string) MetricSink {
+	if len(namespace) == 0 {
+		namespace = DefaultNamespace
+	}
+
+	return emfSink{lock: &sync.Mutex{}, writer: writer, namespace: namespace}
+}
+
+func NewMemoryMetricSink() *MemoryMetricSink {
+	return &MemoryMetricSink{}
+}
+
+func (middleware metricsMiddleware) Create(ctx context.Context, task *Task) error {
+	var start = time.Now()
+	var err = middleware.next.Create(ctx, task)
+
+	middleware.record(ctx, `create`, start, err)
+	return err
+}
+
+func (middleware metricsMiddleware) Update(ctx context.Context, task *Task) error {
+	var start = time.Now()
+	var err = middleware.next.Update(ctx, task)
+
+	middleware.record(ctx, `update`, start, err)
+	return err
+}
+
+func (middleware metricsMiddleware) Patch(ctx context.Context, id uint, version uint, apply func(task *Task) error) (*Task, error) {
+	var start = time.Now()
+	var result, err = middleware.next.Patch(ctx, id, version, apply)
+
+	middleware.record(ctx, `patch`, start, err)
+	return result, err
+}
+
+func (middleware metricsMiddleware) Resolve(ctx context.Context, id uint) (*Task, error) {
+	var start = time.Now()
+	var result, err = middleware.next.Resolve(ctx, id)
+
+	middleware.record(ctx, `resolve`, start, err)
+	return result, err
+}
+
+func (middleware metricsMiddleware) Reopen(ctx context.Context, id uint) (*Task, error) {
+	var start = time.Now()
+	var result, err = middleware.next.Reopen(ctx, id)
+
+	middleware.record(ctx, `reopen`, start, err)
+	return result, err
+}
+
+func (middleware metricsMiddleware) Read(ctx context.Context, id uint) (*Task, error) {
+	var start = time.Now()
+	var result, err = middleware.next.Read(ctx, id)
+
+	middleware.record(ctx, `read`, start, err)
+	return result, err
+}
+
+func (middleware metricsMiddleware) Delete(ctx context.Context, id uint) (*Task, error) {
+	var start = time.Now()
+	var result, err = middleware.next.Delete(ctx, id)
+
+	middleware.record(ctx, `delete`, start, err)
+	return result, err
+}
+
+func (middleware metricsMiddleware) Restore(ctx context.Context, id uint) (*Task, error) {
+	var start = time.Now()
+	var result, err = middleware.next.Restore(ctx, id)
+
+	middleware.record(ctx, `restore`, start, err)
+	return result, err
+}
+
+func (middleware metricsMiddleware) Purge(ctx context.Context, retention time.Duration) (uint, error) {
+	var start = time.Now()
+	var result, err = middleware.next.Purge(ctx, retention)
+
+	middleware.record(ctx, `purge`, start, err)
+	return result, err
+}
+
+func (middleware metricsMiddleware) BulkCreate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error) {
+	var start = time.Now()
+	var results, err = middleware.next.BulkCreate(ctx, tasks, mode)
+
+	middleware.record(ctx, `bulk_create`, start, err)
+	return results, err
+}
+
+func (middleware metricsMiddleware) BulkUpdate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error) {
+	var start = time.Now()
+	var results, err = middleware.next.BulkUpdate(ctx, tasks, mode)
+
+	middleware.record(ctx, `bulk_update`, start, err)
+	return results, err
+}
+
+func (middleware metricsMiddleware) BulkDelete(ctx context.Context, ids []uint, mode BatchMode) ([]BatchResult, error) {
+	var start = time.Now()
+	var results, err = middleware.next.BulkDelete(ctx, ids, mode)
+
+	middleware.record(ctx, `bulk_delete`, start, err)
+	return results, err
+}
+
+func (middleware metricsMiddleware) List(ctx context.Context, query Query, limit uint, offset uint) ([]Task, error) {
+	var start = time.Now()
+	var result, err = middleware.next.List(ctx, query, limit, offset)
+
+	middleware.record(ctx, `list`, start, err)
+	return result, err
+}
+
+func (middleware metricsMiddleware) Paginate(ctx context.Context, query Query, limit uint, cursor string) (*Page, error) {
+	var start = time.Now()
+	var result, err = middleware.next.Paginate(ctx, query, limit, cursor)
+
+	middleware.record(ctx, `paginate`, start, err)
+	return result, err
+}
+
+func (middleware metricsMiddleware) History(ctx context.Context, id uint, limit uint, cursor string) (*HistoryPage, error) {
+	var start = time.Now()
+	var result, err = middleware.next.History(ctx, id, limit, cursor)
+
+	middleware.record(ctx, `history`, start, err)
+	return result, err
+}
+
+func (middleware metricsMiddleware) Shutdown() error {
+	return middleware.next.Shutdown()
+}
+
+// Record writes measurement as a single line, a failed write is dropped rather than failing the call it measured
+func (sink emfSink) Record(ctx context.Context, measurement Measurement) {
+	var document = emfDocument{
+		Metadata: emfMetadata{
+			Timestamp: measurement.Timestamp.UnixMilli(),
+			CloudWatchMetrics: []emfDirective{{
+				Namespace:  sink.namespace,
+				Dimensions: [][]string{{`Operation`}},
+				Metrics: []emfMetric{
+					{Name: latencyMetric, Unit: `Milliseconds`},
+					{Name: countMetric, Unit: `Count`},
+					{Name: errorsMetric, Unit: `Count`},
+				},
+			}},
+		},
+		Operation:  measurement.Operation,
+		Latency:    milliseconds(measurement.Latency),
+		Count:      1,
+		ErrorClass: measurement.ErrorClass,
+	}
+
+	if len(measurement.ErrorClass) > 0 {
+		document.Errors = 1
+	}
+
+	if line, err := json.Marshal(document); err == nil {
+		sink.lock.Lock()
+		defer sink.lock.Unlock()
+
+		_, _ = sink.writer.Write(append(line, '\n'))
+	}
+}
+
+func (sink *MemoryMetricSink) Record(ctx context.Context, measurement Measurement) {
+	sink.lock.Lock()
+	defer sink.lock.Unlock()
+
+	sink.measurements = append(sink.measurements, measurement)
+}
+
+// Measurements returns a copy of everything recorded so far, in the order it was recorded
+func (sink *MemoryMetricSink) Measurements() []Measurement {
+	sink.lock.Lock()
+	defer sink.lock.Unlock()
+
+	return append([]Measurement{}, sink.measurements...)
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+func (middleware metricsMiddleware) record(ctx context.Context, operation string, start time.Time, err error) {
+	var measurement = Measurement{
+		Operation: operation,
+		Latency:   time.Since(start),
+		Timestamp: start,
+	}
+
+	if err != nil {
+		measurement.ErrorClass = errorClass(err)
+	}
+
+	middleware.sink.Record(ctx, measurement)
+}
+
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration.Microseconds()) / 1000
+}
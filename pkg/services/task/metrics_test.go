@@ -0,0 +1,134 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestMetricsMiddleware(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var store Store
+	var sink *MemoryMetricSink
+	var service Service
+	var model *Task
+	var measurements []Measurement
+
+	//-- Test Parameters ----------
+	var missing uint = 9999
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+	sink = NewMemoryMetricSink()
+
+	service = NewService([]Middleware{NewMetricsMiddleware(sink)}, store)
+	defer shutdownService(test, service)
+
+	model = newValidTask()
+
+	//-- Action ----------
+	_ = service.Create(ctx, model)
+	_, _ = service.Read(ctx, model.ID)
+	_, _ = service.Read(ctx, missing)
+	_, _ = service.List(ctx, Query{}, 10, 0)
+
+	//-- Post-conditions ----------
+	measurements = sink.Measurements()
+
+	if assert.Len(test, measurements, 4) {
+		assert.Equal(test, `create`, measurements[0].Operation)
+		assert.Empty(test, measurements[0].ErrorClass)
+
+		assert.Equal(test, `read`, measurements[1].Operation)
+		assert.Empty(test, measurements[1].ErrorClass)
+
+		assert.Equal(test, `read`, measurements[2].Operation)
+		assert.Equal(test, NotFoundClass, measurements[2].ErrorClass)
+
+		assert.Equal(test, `list`, measurements[3].Operation)
+
+		for _, measurement := range measurements {
+			assert.True(test, measurement.Latency > 0)
+			assert.False(test, measurement.Timestamp.IsZero())
+		}
+	}
+}
+
+func TestMetricsEMFSink(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var buffer bytes.Buffer
+	var sink MetricSink
+	var document map[string]interface{}
+
+	//-- Test Parameters ----------
+	var namespace = `TestNamespace`
+	var timestamp = time.Date(2019, 3, 25, 13, 49, 3, 0, time.UTC)
+	var measurement = Measurement{Operation: `update`, Latency: 1500 * time.Microsecond, ErrorClass: ConflictClass, Timestamp: timestamp}
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+	sink = NewEMFSink(&buffer, namespace)
+
+	//-- Action ----------
+	sink.Record(ctx, measurement)
+
+	//-- Post-conditions ----------
+	if err := json.Unmarshal(buffer.Bytes(), &document); err != nil {
+		test.Fatalf(`unable to parse metric line '%s': %s`, buffer.String(), err)
+	}
+
+	assert.Equal(test, `update`, document[`Operation`])
+	assert.Equal(test, 1.5, document[`Latency`])
+	assert.Equal(test, float64(1), document[`Count`])
+	assert.Equal(test, float64(1), document[`Errors`])
+	assert.Equal(test, ConflictClass, document[`ErrorClass`])
+
+	var metadata = document[`_aws`].(map[string]interface{})
+	var directive = metadata[`CloudWatchMetrics`].([]interface{})[0].(map[string]interface{})
+
+	assert.Equal(test, float64(timestamp.UnixMilli()), metadata[`Timestamp`])
+	assert.Equal(test, namespace, directive[`Namespace`])
+	assert.Equal(test, []interface{}{[]interface{}{`Operation`}}, directive[`Dimensions`])
+	assert.Len(test, directive[`Metrics`], 3)
+}
+
+func TestMetricsEMFSinkDefaults(test *testing.T) {
+	//-- Shared Variables ----------
+	var buffer bytes.Buffer
+	var document emfDocument
+
+	//-- Test Parameters ----------
+	var measurement = Measurement{Operation: `read`, Latency: time.Millisecond, Timestamp: time.Now()}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	NewEMFSink(&buffer, ``).Record(context.Background(), measurement)
+
+	//-- Post-conditions ----------
+	if err := json.Unmarshal(buffer.Bytes(), &document); err != nil {
+		test.Fatalf(`unable to parse metric line '%s': %s`, buffer.String(), err)
+	}
+
+	assert.Equal(test, DefaultNamespace, document.Metadata.CloudWatchMetrics[0].Namespace)
+	assert.Equal(test, uint(0), document.Errors)
+	assert.Empty(test, document.ErrorClass)
+	assert.NotContains(test, buffer.String(), `ErrorClass`)
+}
@@ -4,53 +4,105 @@ package task
 //-- Imports -----------------------------------------------------------------------------------------------------------
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
-	"log"
-
-	"github.com/google/uuid"
+	"log/slog"
+	"strings"
+	"time"
 )
 
 //-- Constants ---------------------------------------------------------------------------------------------------------
-var (
-	logFormat = "\n\tRequest ID -> %s \n\tFunction   -> %s \n\tParameters -> %s \n\tResponse   -> %v \n\tError      -> %v"
+const (
+	NotFoundClass  = `not_found`
+	ForbiddenClass = `forbidden`
+	ConflictClass  = `conflict`
+	InvalidClass   = `invalid`
+	TimeoutClass   = `timeout`
+	InternalClass  = `internal`
 )
 
 //-- Structs -----------------------------------------------------------------------------------------------------------
 type logMiddleware struct {
 	next   Service
-	logger log.Logger
+	logger *slog.Logger
 }
 
 //-- Exported Functions ------------------------------------------------------------------------------------------------
-func NewLogMiddleare(logger log.Logger) Middleware {
+// NewLogMiddleware logs one line per call with the operation, its parameters, latency and the class of any error. Calls
+// which fail because of the caller are logged as warnings and every other failure as an error, the response of each
+// call is only included at the debug level.
+func NewLogMiddleware(logger *slog.Logger) Middleware {
 	return func(next Service) Service {
 		return logMiddleware{next, logger}
 	}
 }
 
 func (middleware logMiddleware) Create(ctx context.Context, task *Task) error {
+	var start = time.Now()
 	var err error
 	var parameterCapture string
 
 	parameterCapture = fmt.Sprintf(`%v`, task)
 	err = middleware.next.Create(ctx, task)
 
-	middleware.logger.Printf(logFormat, uuid.New().String(), `task create`, parameterCapture, task, err)
+	middleware.log(ctx, `task create`, start, parameterCapture, task, err)
 	return err
 }
 
 func (middleware logMiddleware) Update(ctx context.Context, task *Task) error {
+	var start = time.Now()
 	var err error
 	var parameterCapture string
 
 	parameterCapture = fmt.Sprintf(`%v`, task)
 	err = middleware.next.Update(ctx, task)
 
-	middleware.logger.Printf(logFormat, uuid.New().String(), `task update`, parameterCapture, task, err)
+	middleware.log(ctx, `task update`, start, parameterCapture, task, err)
 	return err
 }
 
+func (middleware logMiddleware) Patch(ctx context.Context, id uint, version uint, apply func(task *Task) error) (*Task, error) {
+	var start = time.Now()
+	var err error
+	var result *Task
+	var parameterCapture string
+
+	parameterCapture = fmt.Sprintf(`{ID: %d, Version: %d}`, id, version)
+	result, err = middleware.next.Patch(ctx, id, version, apply)
+
+	middleware.log(ctx, `task patch`, start, parameterCapture, result, err)
+	return result, err
+}
+
+func (middleware logMiddleware) Resolve(ctx context.Context, id uint) (*Task, error) {
+	var start = time.Now()
+	var err error
+	var result *Task
+	var parameterCapture string
+
+	parameterCapture = fmt.Sprintf(`%d`, id)
+	result, err = middleware.next.Resolve(ctx, id)
+
+	middleware.log(ctx, `task resolve`, start, parameterCapture, result, err)
+	return result, err
+}
+
+func (middleware logMiddleware) Reopen(ctx context.Context, id uint) (*Task, error) {
+	var start = time.Now()
+	var err error
+	var result *Task
+	var parameterCapture string
+
+	parameterCapture = fmt.Sprintf(`%d`, id)
+	result, err = middleware.next.Reopen(ctx, id)
+
+	middleware.log(ctx, `task reopen`, start, parameterCapture, result, err)
+	return result, err
+}
+
 func (middleware logMiddleware) Read(ctx context.Context, id uint) (*Task, error) {
+	var start = time.Now()
 	var err error
 	var result *Task
 	var parameterCapture string
@@ -58,11 +110,12 @@ func (middleware logMiddleware) Read(ctx context.Context, id uint) (*Task, error
 	parameterCapture = fmt.Sprintf(`%d`, id)
 	result, err = middleware.next.Read(ctx, id)
 
-	middleware.logger.Printf(logFormat, uuid.New().String(), `task read`, parameterCapture, result, err)
+	middleware.log(ctx, `task read`, start, parameterCapture, result, err)
 	return result, err
 }
 
 func (middleware logMiddleware) Delete(ctx context.Context, id uint) (*Task, error) {
+	var start = time.Now()
 	var err error
 	var result *Task
 	var parameterCapture string
@@ -70,29 +123,180 @@ func (middleware logMiddleware) Delete(ctx context.Context, id uint) (*Task, err
 	parameterCapture = fmt.Sprintf(`%d`, id)
 	result, err = middleware.next.Delete(ctx, id)
 
-	middleware.logger.Printf(logFormat, uuid.New().String(), `task delete`, parameterCapture, result, err)
+	middleware.log(ctx, `task delete`, start, parameterCapture, result, err)
 	return result, err
 }
 
-func (middleware logMiddleware) List(ctx context.Context, limit uint, offset uint) ([]Task, error) {
+func (middleware logMiddleware) Restore(ctx context.Context, id uint) (*Task, error) {
+	var start = time.Now()
+	var err error
+	var result *Task
+	var parameterCapture string
+
+	parameterCapture = fmt.Sprintf(`%d`, id)
+	result, err = middleware.next.Restore(ctx, id)
+
+	middleware.log(ctx, `task restore`, start, parameterCapture, result, err)
+	return result, err
+}
+
+func (middleware logMiddleware) Purge(ctx context.Context, retention time.Duration) (uint, error) {
+	var start = time.Now()
+	var err error
+	var result uint
+	var parameterCapture string
+
+	parameterCapture = retention.String()
+	result, err = middleware.next.Purge(ctx, retention)
+
+	middleware.log(ctx, `task purge`, start, parameterCapture, result, err)
+	return result, err
+}
+
+func (middleware logMiddleware) BulkCreate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error) {
+	var start = time.Now()
+	var err error
+	var results []BatchResult
+	var parameterCapture string
+
+	parameterCapture = fmt.Sprintf(`{Items: %d, Mode: %s}`, len(tasks), mode)
+	results, err = middleware.next.BulkCreate(ctx, tasks, mode)
+
+	middleware.log(ctx, `task bulk create`, start, parameterCapture, batchOutcome(results), err)
+	return results, err
+}
+
+func (middleware logMiddleware) BulkUpdate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error) {
+	var start = time.Now()
+	var err error
+	var results []BatchResult
+	var parameterCapture string
+
+	parameterCapture = fmt.Sprintf(`{Items: %d, Mode: %s}`, len(tasks), mode)
+	results, err = middleware.next.BulkUpdate(ctx, tasks, mode)
+
+	middleware.log(ctx, `task bulk update`, start, parameterCapture, batchOutcome(results), err)
+	return results, err
+}
+
+func (middleware logMiddleware) BulkDelete(ctx context.Context, ids []uint, mode BatchMode) ([]BatchResult, error) {
+	var start = time.Now()
+	var err error
+	var results []BatchResult
+	var parameterCapture string
+
+	parameterCapture = fmt.Sprintf(`{IDs: %v, Mode: %s}`, ids, mode)
+	results, err = middleware.next.BulkDelete(ctx, ids, mode)
+
+	middleware.log(ctx, `task bulk delete`, start, parameterCapture, batchOutcome(results), err)
+	return results, err
+}
+
+func (middleware logMiddleware) List(ctx context.Context, query Query, limit uint, offset uint) ([]Task, error) {
+	var start = time.Now()
 	var err error
 	var result []Task
 	var parameterCapture string
 
-	parameterCapture = fmt.Sprintf(`{Limit: %d, Offset: %d}`, limit, offset)
-	result, err = middleware.next.List(ctx, limit, offset)
+	parameterCapture = fmt.Sprintf(`{Query: %s, Limit: %d, Offset: %d}`, query, limit, offset)
+	result, err = middleware.next.List(ctx, query, limit, offset)
+
+	middleware.log(ctx, `task list`, start, parameterCapture, result, err)
+	return result, err
+}
+
+func (middleware logMiddleware) Paginate(ctx context.Context, query Query, limit uint, cursor string) (*Page, error) {
+	var start = time.Now()
+	var err error
+	var result *Page
+	var parameterCapture string
+
+	parameterCapture = fmt.Sprintf(`{Query: %s, Limit: %d, Cursor: %s}`, query, limit, cursor)
+	result, err = middleware.next.Paginate(ctx, query, limit, cursor)
+
+	middleware.log(ctx, `task paginate`, start, parameterCapture, result, err)
+	return result, err
+}
+
+func (middleware logMiddleware) History(ctx context.Context, id uint, limit uint, cursor string) (*HistoryPage, error) {
+	var start = time.Now()
+	var err error
+	var result *HistoryPage
+	var parameterCapture string
+
+	parameterCapture = fmt.Sprintf(`{ID: %d, Limit: %d, Cursor: %s}`, id, limit, cursor)
+	result, err = middleware.next.History(ctx, id, limit, cursor)
 
-	middleware.logger.Printf(logFormat, uuid.New().String(), `task list`, parameterCapture, result, err)
+	middleware.log(ctx, `task history`, start, parameterCapture, result, err)
 	return result, err
 }
 
 func (middleware logMiddleware) Shutdown() error {
+	var start = time.Now()
 	var err error
 
 	err = middleware.next.Shutdown()
 
-	middleware.logger.Printf(logFormat, uuid.New().String(), `task shutdown`, ``, ``, err)
+	middleware.log(context.Background(), `task shutdown`, start, ``, nil, err)
 	return err
 }
 
 //-- Internal Functions ------------------------------------------------------------------------------------------------
+func (middleware logMiddleware) log(ctx context.Context, operation string, start time.Time, parameters string, result interface{}, err error) {
+	var level = slog.LevelInfo
+	var attributes = []slog.Attr{
+		slog.String(`operation`, operation),
+		slog.Float64(`latency_ms`, milliseconds(time.Since(start))),
+		slog.String(`parameters`, parameters),
+		slog.String(`scope`, ScopeFrom(ctx).String()),
+	}
+
+	if err != nil {
+		var class = errorClass(err)
+
+		if class == InternalClass || class == TimeoutClass {
+			level = slog.LevelError
+		} else {
+			level = slog.LevelWarn
+		}
+
+		attributes = append(attributes, slog.String(`error_class`, class), slog.String(`error`, err.Error()))
+	}
+
+	if result != nil && middleware.logger.Enabled(ctx, slog.LevelDebug) {
+		attributes = append(attributes, slog.String(`response`, fmt.Sprintf(`%v`, result)))
+	}
+
+	middleware.logger.LogAttrs(ctx, level, operation, attributes...)
+}
+
+// batchOutcome summarises the results of a batch for the log, nil when the batch could not be run
+func batchOutcome(results []BatchResult) interface{} {
+	if results == nil {
+		return nil
+	}
+	return fmt.Sprintf(`{Stored: %d, Failed: %d}`, len(results)-failures(results), failures(results))
+}
+
+// errorClass groups err by its cause so failures can be counted without parsing messages
+func errorClass(err error) string {
+	var message = err.Error()
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return NotFoundClass
+	} else if errors.Is(err, ErrForbidden) {
+		return ForbiddenClass
+	} else if errors.Is(err, ErrVersionConflict) {
+		return ConflictClass
+	} else if errors.Is(err, ErrInvalidCursor) || errors.Is(err, ErrInvalidRetention) || errors.Is(err, ErrIllAdvisedInsert) {
+		return InvalidClass
+	} else if errors.Is(err, ErrBatchTooLarge) || errors.Is(err, ErrInvalidBatchMode) {
+		return InvalidClass
+	} else if strings.HasPrefix(message, `validation - `) || strings.HasPrefix(message, `query - `) {
+		return InvalidClass
+	} else if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return TimeoutClass
+	}
+
+	return InternalClass
+}
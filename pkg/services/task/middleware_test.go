@@ -3,11 +3,14 @@ package task
 
 //-- Imports -----------------------------------------------------------------------------------------------------------
 import (
-	"bufio"
 	"bytes"
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"testing"
 	"time"
 
@@ -19,16 +22,8 @@ import (
 //-- Decorators --------------------------------------------------------------------------------------------------------
 
 //-- Helpers -----------------------------------------------------------------------------------------------------------
-func dummyLogger() log.Logger {
-	var buffer bytes.Buffer
-	var writer *bufio.Writer
-	var logger log.Logger
-
-	writer = bufio.NewWriter(&buffer)
-
-	logger.SetOutput(writer)
-
-	return logger
+func dummyLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(io.Discard, nil))
 }
 
 //-- Tests -------------------------------------------------------------------------------------------------------------
@@ -41,9 +36,8 @@ func TestMiddlewareLoggerNewService(test *testing.T) {
 	//-- Test Parameters ----------
 
 	//-- Pre-conditions ----------
-	store = openStore(test)
-	resetStore(test, store)
-	logger = NewLogMiddleare(dummyLogger())
+	store = openMemoryStore(test)
+	logger = NewLogMiddleware(dummyLogger())
 
 	//-- Action ----------
 	service = NewService([]Middleware{logger}, store)
@@ -68,10 +62,9 @@ func TestMiddlewareLoggerCreate(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
-	logger = NewLogMiddleare(dummyLogger())
+	logger = NewLogMiddleware(dummyLogger())
 
 	service = NewService([]Middleware{logger}, store)
 	defer shutdownService(test, service)
@@ -103,9 +96,8 @@ func TestMiddlewareLoggerCreateErr(test *testing.T) {
 
 	//-- Pre-conditions ----------
 	ctx = context.Background()
-	store = openStore(test)
-	resetStore(test, store)
-	logger = NewLogMiddleare(dummyLogger())
+	store = openMemoryStore(test)
+	logger = NewLogMiddleware(dummyLogger())
 	service = NewService([]Middleware{logger}, store)
 	defer shutdownService(test, service)
 
@@ -139,10 +131,9 @@ func TestMiddlewareLoggerUpdate(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
-	logger = NewLogMiddleare(dummyLogger())
+	logger = NewLogMiddleware(dummyLogger())
 
 	service = NewService([]Middleware{logger}, store)
 	defer shutdownService(test, service)
@@ -186,10 +177,9 @@ func TestMiddlewareLoggerUpdateErr(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
-	logger = NewLogMiddleare(dummyLogger())
+	logger = NewLogMiddleware(dummyLogger())
 
 	service = NewService([]Middleware{logger}, store)
 	defer shutdownService(test, service)
@@ -226,10 +216,9 @@ func TestMiddlewareLoggerRead(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
-	logger = NewLogMiddleare(dummyLogger())
+	logger = NewLogMiddleware(dummyLogger())
 
 	service = NewService([]Middleware{logger}, store)
 	defer shutdownService(test, service)
@@ -264,10 +253,9 @@ func TestMiddlewareLoggerReadErr(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
-	logger = NewLogMiddleare(dummyLogger())
+	logger = NewLogMiddleware(dummyLogger())
 
 	service = NewService([]Middleware{logger}, store)
 	defer shutdownService(test, service)
@@ -298,10 +286,9 @@ func TestMiddlewareLoggerDelete(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
-	logger = NewLogMiddleare(dummyLogger())
+	logger = NewLogMiddleware(dummyLogger())
 	service = NewService([]Middleware{logger}, store)
 
 	defer shutdownService(test, service)
@@ -318,7 +305,11 @@ func TestMiddlewareLoggerDelete(test *testing.T) {
 
 	//-- Post-conditions ----------
 	assert.Nil(test, deleteErr)
-	assert.True(test, model.compare(*deleteModel))
+
+	if assert.NotNil(test, deleteModel) && assert.NotNil(test, deleteModel.DeletedAt) {
+		model.DeletedAt = deleteModel.DeletedAt
+		assert.True(test, model.compare(*deleteModel))
+	}
 }
 
 func TestMiddlewareLoggerDeleteErr(test *testing.T) {
@@ -336,10 +327,9 @@ func TestMiddlewareLoggerDeleteErr(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
-	logger = NewLogMiddleare(dummyLogger())
+	logger = NewLogMiddleware(dummyLogger())
 
 	service = NewService([]Middleware{logger}, store)
 	defer shutdownService(test, service)
@@ -374,10 +364,9 @@ func TestMiddlewareLoggerList(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
-	logger = NewLogMiddleare(dummyLogger())
+	logger = NewLogMiddleware(dummyLogger())
 
 	service = NewService([]Middleware{logger}, store)
 	defer shutdownService(test, service)
@@ -392,7 +381,7 @@ func TestMiddlewareLoggerList(test *testing.T) {
 	}
 
 	//-- Action ----------
-	listModels, listErr = service.List(ctx, limit, offset)
+	listModels, listErr = service.List(ctx, Query{}, limit, offset)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, listErr)
@@ -418,10 +407,9 @@ func TestMiddlewareLoggerListLimit(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
-	logger = NewLogMiddleare(dummyLogger())
+	logger = NewLogMiddleware(dummyLogger())
 
 	service = NewService([]Middleware{logger}, store)
 	defer shutdownService(test, service)
@@ -436,7 +424,7 @@ func TestMiddlewareLoggerListLimit(test *testing.T) {
 	}
 
 	//-- Action ----------
-	listModels, listErr = service.List(ctx, limit, offset)
+	listModels, listErr = service.List(ctx, Query{}, limit, offset)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, listErr)
@@ -462,10 +450,9 @@ func TestMiddlewareLoggerListZeroLimit(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
-	logger = NewLogMiddleare(dummyLogger())
+	logger = NewLogMiddleware(dummyLogger())
 
 	service = NewService([]Middleware{logger}, store)
 	defer shutdownService(test, service)
@@ -480,7 +467,7 @@ func TestMiddlewareLoggerListZeroLimit(test *testing.T) {
 	}
 
 	//-- Action ----------
-	listModels, listErr = service.List(ctx, limit, offset)
+	listModels, listErr = service.List(ctx, Query{}, limit, offset)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, listErr)
@@ -506,10 +493,9 @@ func TestMiddlewareLoggerListOverLimit(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
-	logger = NewLogMiddleare(dummyLogger())
+	logger = NewLogMiddleware(dummyLogger())
 
 	service = NewService([]Middleware{logger}, store)
 	defer shutdownService(test, service)
@@ -524,7 +510,7 @@ func TestMiddlewareLoggerListOverLimit(test *testing.T) {
 	}
 
 	//-- Action ----------
-	listModels, listErr = service.List(ctx, limit, offset)
+	listModels, listErr = service.List(ctx, Query{}, limit, offset)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, listErr)
@@ -550,10 +536,9 @@ func TestMiddlewareLoggerListOffset(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
-	logger = NewLogMiddleare(dummyLogger())
+	logger = NewLogMiddleware(dummyLogger())
 
 	service = NewService([]Middleware{logger}, store)
 	defer shutdownService(test, service)
@@ -568,7 +553,7 @@ func TestMiddlewareLoggerListOffset(test *testing.T) {
 	}
 
 	//-- Action ----------
-	listModels, listErr = service.List(ctx, limit, offset)
+	listModels, listErr = service.List(ctx, Query{}, limit, offset)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, listErr)
@@ -594,10 +579,9 @@ func TestMiddlewareLoggerListOverOffset(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
-	logger = NewLogMiddleare(dummyLogger())
+	logger = NewLogMiddleware(dummyLogger())
 
 	service = NewService([]Middleware{logger}, store)
 	defer shutdownService(test, service)
@@ -612,9 +596,145 @@ func TestMiddlewareLoggerListOverOffset(test *testing.T) {
 	}
 
 	//-- Action ----------
-	listModels, listErr = service.List(ctx, limit, offset)
+	listModels, listErr = service.List(ctx, Query{}, limit, offset)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, listErr)
 	assert.Equal(test, 0, len(listModels))
 }
+
+func TestMiddlewareLoggerPaginate(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var store Store
+	var logger Middleware
+	var service Service
+	var page *Page
+	var paginateErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing logged pagination`
+	var quantity = 10
+
+	var limit uint = 4
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	logger = NewLogMiddleware(dummyLogger())
+
+	service = NewService([]Middleware{logger}, store)
+	defer shutdownService(test, service)
+
+	for i := 0; i < quantity; i++ {
+		var task = newValidTask()
+		task.Name = fmt.Sprintf(`%s %d`, name, i)
+
+		if err := service.Create(ctx, task); err != nil {
+			test.Fatalf(`unexpected error when inserting record: %s`, err)
+		}
+	}
+
+	//-- Action ----------
+	page, paginateErr = service.Paginate(ctx, Query{}, limit, ``)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, paginateErr)
+	assert.Equal(test, 4, len(page.Tasks))
+	assert.NotEqual(test, ``, page.Next)
+}
+
+func TestMiddlewareLoggerPaginateErr(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var store Store
+	var logger Middleware
+	var service Service
+	var page *Page
+	var paginateErr error
+
+	//-- Test Parameters ----------
+	var limit uint = 4
+	var cursor = `not a cursor!`
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	logger = NewLogMiddleware(dummyLogger())
+
+	service = NewService([]Middleware{logger}, store)
+	defer shutdownService(test, service)
+
+	//-- Action ----------
+	page, paginateErr = service.Paginate(ctx, Query{}, limit, cursor)
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, paginateErr)
+	assert.Nil(test, page)
+}
+
+func TestMiddlewareLoggerStructured(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var buffer bytes.Buffer
+	var store Store
+	var logger Middleware
+	var service Service
+	var line map[string]interface{}
+
+	//-- Test Parameters ----------
+	var id uint = 9999
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	logger = NewLogMiddleware(slog.New(slog.NewJSONHandler(&buffer, nil)))
+
+	service = NewService([]Middleware{logger}, store)
+	defer shutdownService(test, service)
+
+	//-- Action ----------
+	_, _ = service.Read(ctx, id)
+
+	//-- Post-conditions ----------
+	if err := json.Unmarshal(buffer.Bytes(), &line); err != nil {
+		test.Fatalf(`unable to parse log line '%s': %s`, buffer.String(), err)
+	}
+
+	assert.Equal(test, `WARN`, line[`level`])
+	assert.Equal(test, `task read`, line[`operation`])
+	assert.Equal(test, fmt.Sprintf(`%d`, id), line[`parameters`])
+	assert.Equal(test, NotFoundClass, line[`error_class`])
+	assert.Contains(test, line, `latency_ms`)
+	assert.NotContains(test, line, `response`)
+}
+
+func TestMiddlewareLoggerErrorClass(test *testing.T) {
+	//-- Shared Variables ----------
+
+	//-- Test Parameters ----------
+	var cases = map[error]string{
+		sql.ErrNoRows:                 NotFoundClass,
+		ErrForbidden:                  ForbiddenClass,
+		ErrVersionConflict:            ConflictClass,
+		ErrInvalidCursor:              InvalidClass,
+		Task{}.Validate():             InvalidClass,
+		context.DeadlineExceeded:      TimeoutClass,
+		errors.New(`connection lost`): InternalClass,
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+
+	//-- Post-conditions ----------
+	for err, class := range cases {
+		assert.Equal(test, class, errorClass(err), err.Error())
+	}
+}
@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version INTEGER DEFAULT 1 NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS task_history;
+DROP SEQUENCE IF EXISTS task_history_id_seq;
\ No newline at end of file
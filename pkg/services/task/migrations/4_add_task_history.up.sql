@@ -0,0 +1,19 @@
+CREATE SEQUENCE IF NOT EXISTS task_history_id_seq
+  AS INTEGER
+  MAXVALUE 2147483647;
+
+CREATE TABLE IF NOT EXISTS task_history
+(
+  id         INTEGER DEFAULT nextval('task_history_id_seq'::regclass) NOT NULL CONSTRAINT task_history_pkey PRIMARY KEY ,
+
+  task_id    INTEGER NOT NULL,
+  action     VARCHAR(16) NOT NULL,
+  actor      VARCHAR(255) NOT NULL,
+  version    INTEGER NOT NULL,
+  changes    JSONB DEFAULT '[]' NOT NULL,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- History outlives purged tasks, so task_id is deliberately not a foreign key
+CREATE INDEX IF NOT EXISTS idx_task_history_task_id ON task_history (task_id, id);
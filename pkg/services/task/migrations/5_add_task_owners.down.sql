@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tasks_owner_id;
+
+ALTER TABLE task_history DROP COLUMN IF EXISTS owner_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS owner_id;
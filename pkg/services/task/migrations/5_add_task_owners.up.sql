@@ -0,0 +1,5 @@
+-- Tasks created before ownership belong to no one, so only admins can reach them
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS owner_id VARCHAR(255) DEFAULT '' NOT NULL;
+ALTER TABLE task_history ADD COLUMN IF NOT EXISTS owner_id VARCHAR(255) DEFAULT '' NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_owner_id ON tasks (owner_id, id);
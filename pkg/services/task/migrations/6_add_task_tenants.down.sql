@@ -0,0 +1,14 @@
+DROP POLICY IF EXISTS task_history_tenant_isolation ON task_history;
+DROP POLICY IF EXISTS tasks_tenant_isolation ON tasks;
+
+ALTER TABLE task_history NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE task_history DISABLE ROW LEVEL SECURITY;
+ALTER TABLE tasks NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE tasks DISABLE ROW LEVEL SECURITY;
+
+DROP INDEX IF EXISTS idx_tasks_tenant_owner_id;
+DROP INDEX IF EXISTS idx_tasks_tenant_id;
+CREATE INDEX IF NOT EXISTS idx_tasks_owner_id ON tasks (owner_id, id);
+
+ALTER TABLE task_history DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,22 @@
+-- Every task created before tenancy belongs to the default tenant
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) DEFAULT 'default' NOT NULL;
+ALTER TABLE task_history ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) DEFAULT 'default' NOT NULL;
+
+DROP INDEX IF EXISTS idx_tasks_owner_id;
+CREATE INDEX IF NOT EXISTS idx_tasks_tenant_id ON tasks (tenant_id, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_tenant_owner_id ON tasks (tenant_id, owner_id, id);
+
+-- Row level security backs up the tenant condition of every statement. The store names the tenant of each transaction
+-- in app.tenant_id, sessions which never name one (migrations, maintenance) and the scheduled purge see every tenant.
+ALTER TABLE tasks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tasks FORCE ROW LEVEL SECURITY;
+ALTER TABLE task_history ENABLE ROW LEVEL SECURITY;
+ALTER TABLE task_history FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY tasks_tenant_isolation ON tasks
+  USING (COALESCE(current_setting('app.tenant_id', TRUE), '') IN ('', tenant_id))
+  WITH CHECK (COALESCE(current_setting('app.tenant_id', TRUE), '') IN ('', tenant_id));
+
+CREATE POLICY task_history_tenant_isolation ON task_history
+  USING (COALESCE(current_setting('app.tenant_id', TRUE), '') IN ('', tenant_id))
+  WITH CHECK (COALESCE(current_setting('app.tenant_id', TRUE), '') IN ('', tenant_id));
@@ -10,6 +10,10 @@ import (
 )
 
 //-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// maximumOwnerID is the width of the owner_id column
+	maximumOwnerID = 255
+)
 
 //-- Structs -----------------------------------------------------------------------------------------------------------
 type Task struct {
@@ -22,17 +26,21 @@ type Task struct {
 	ResolvedAt *time.Time
 
 	//-- System Variables ----------
+	Version uint
 
 	//-- Relations ----------
+	OwnerID  string
+	TenantID string
 
 	//-- Automated fields (Timestamps) ----------
 	CreatedAt time.Time
 	UpdatedAt *time.Time
+	DeletedAt *time.Time
 }
 
 //-- Exported Functions ------------------------------------------------------------------------------------------------
 func (task Task) String() string {
-	var details, resolvedAt, updatedAt = `<nil>`, `<nil>`, `<nil>`
+	var details, resolvedAt, updatedAt, deletedAt = `<nil>`, `<nil>`, `<nil>`, `<nil>`
 
 	if task.Details != nil {
 		details = *task.Details
@@ -43,8 +51,11 @@ func (task Task) String() string {
 	if task.UpdatedAt != nil {
 		updatedAt = task.UpdatedAt.String()
 	}
+	if task.DeletedAt != nil {
+		deletedAt = task.DeletedAt.String()
+	}
 
-	return fmt.Sprintf(`{ID: %d, Name: %s, Details: %s, ResolvedAt: %s, CreatedAt: %s, UpdatedAt: %s}`, task.ID, task.Name, details, resolvedAt, task.CreatedAt, updatedAt)
+	return fmt.Sprintf(`{ID: %d, Name: %s, Details: %s, ResolvedAt: %s, OwnerID: %s, TenantID: %s, CreatedAt: %s, UpdatedAt: %s, DeletedAt: %s, Version: %d}`, task.ID, task.Name, details, resolvedAt, task.OwnerID, task.TenantID, task.CreatedAt, updatedAt, deletedAt, task.Version)
 }
 
 //-- Store Functions ---------------------------------------------------------------------------------------------------
@@ -53,10 +64,22 @@ func (task Task) compare(other Task) bool {
 		return false
 	}
 
+	if task.Version != other.Version {
+		return false
+	}
+
 	if task.Name != other.Name {
 		return false
 	}
 
+	if task.OwnerID != other.OwnerID {
+		return false
+	}
+
+	if task.TenantID != other.TenantID {
+		return false
+	}
+
 	if (task.Details == nil && other.Details != nil) || (task.Details != nil && other.Details == nil) {
 		return false
 	} else if task.Details != nil && other.Details != nil && *task.Details != *other.Details {
@@ -79,10 +102,16 @@ func (task Task) compare(other Task) bool {
 		return false
 	}
 
+	if (task.DeletedAt == nil && other.DeletedAt != nil) || (task.DeletedAt != nil && other.DeletedAt == nil) {
+		return false
+	} else if task.DeletedAt != nil && other.DeletedAt != nil && task.DeletedAt.Unix() != other.DeletedAt.Unix() {
+		return false
+	}
+
 	return true
 }
 
-func (task *Task) sanitize() error {
+func (task *Task) Sanitize() error {
 	if task.ID == 0 {
 		task.UpdatedAt = nil
 	}
@@ -101,10 +130,14 @@ func (task *Task) sanitize() error {
 		*task.UpdatedAt = task.UpdatedAt.UTC()
 	}
 
+	if task.DeletedAt != nil {
+		*task.DeletedAt = task.DeletedAt.UTC()
+	}
+
 	return nil
 }
 
-func (task Task) validate() error {
+func (task Task) Validate() error {
 	if err := task.validateName(); err != nil {
 		return err
 	}
@@ -117,6 +150,14 @@ func (task Task) validate() error {
 		return err
 	}
 
+	if err := task.validateOwnerID(); err != nil {
+		return err
+	}
+
+	if err := task.validateTenantID(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -157,3 +198,21 @@ func (task Task) validateUpdatedAt() error {
 
 	return nil
 }
+
+func (task Task) validateOwnerID() error {
+	//-- Check for length ----------
+	if len(task.OwnerID) > maximumOwnerID {
+		return errors.New(fmt.Sprintf(`validation - OwnerID '%s' may not exceed %d characters`, task.OwnerID, maximumOwnerID))
+	}
+
+	return nil
+}
+
+func (task Task) validateTenantID() error {
+	//-- Check for pattern adherence, a Task is stamped with its tenant when it is inserted ----------
+	if len(task.TenantID) > 0 {
+		return ValidateTenant(task.TenantID)
+	}
+
+	return nil
+}
@@ -514,7 +514,7 @@ func TestModelSanitize(test *testing.T) {
 	model.ResolvedAt = &resolvedAt
 
 	//-- Action ----------
-	sanitizeErr = model.sanitize()
+	sanitizeErr = model.Sanitize()
 
 	//-- Post-conditions ----------
 	assert.Nil(test, sanitizeErr)
@@ -539,7 +539,7 @@ func TestModelSanitizeDetailsNil(test *testing.T) {
 	model.Details = details
 
 	//-- Action ----------
-	sanitizeErr = model.sanitize()
+	sanitizeErr = model.Sanitize()
 
 	//-- Post-conditions ----------
 	assert.Nil(test, sanitizeErr)
@@ -562,7 +562,7 @@ func TestModelSanitizeDetailsZeroValue(test *testing.T) {
 	model.Details = &details
 
 	//-- Action ----------
-	sanitizeErr = model.sanitize()
+	sanitizeErr = model.Sanitize()
 
 	//-- Post-conditions ----------
 	assert.Nil(test, sanitizeErr)
@@ -595,7 +595,7 @@ func TestModelSanitizeTimezones(test *testing.T) {
 	assert.Equal(test, `Local`, model.UpdatedAt.Location().String())
 
 	//-- Action ----------
-	sanitizeErr = model.sanitize()
+	sanitizeErr = model.Sanitize()
 
 	//-- Post-conditions ----------
 	assert.Nil(test, sanitizeErr)
@@ -623,7 +623,7 @@ func TestModelSanitizeNilTimezones(test *testing.T) {
 	model.Name = name
 
 	//-- Action ----------
-	sanitizeErr = model.sanitize()
+	sanitizeErr = model.Sanitize()
 
 	//-- Post-conditions ----------
 	assert.Nil(test, sanitizeErr)
@@ -646,7 +646,7 @@ func TestModelValidateValid(test *testing.T) {
 	model.Name = name
 
 	//-- Action ----------
-	validationErr = model.validate()
+	validationErr = model.Validate()
 
 	//-- Post-conditions ----------
 	assert.Nil(test, validationErr)
@@ -665,7 +665,7 @@ func TestModelValidateNotValidName(test *testing.T) {
 	model.Name = name
 
 	//-- Action ----------
-	validationErr = model.validate()
+	validationErr = model.Validate()
 
 	//-- Post-conditions ----------
 	assert.NotNil(test, validationErr)
@@ -686,7 +686,7 @@ func TestModelValidateNotValidDetails(test *testing.T) {
 	model.Details = &details
 
 	//-- Action ----------
-	validationErr = model.validate()
+	validationErr = model.Validate()
 
 	//-- Post-conditions ----------
 	assert.NotNil(test, validationErr)
@@ -709,7 +709,7 @@ func TestModelValidateNotValidUpdatedAt(test *testing.T) {
 	model.UpdatedAt = &updatedAt
 
 	//-- Action ----------
-	validationErr = model.validate()
+	validationErr = model.Validate()
 
 	//-- Post-conditions ----------
 	assert.NotNil(test, validationErr)
@@ -0,0 +1,131 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// AdminRole may read and change the Tasks of every owner
+	AdminRole Role = `admin`
+	// MemberRole may only read and change its own Tasks, it is held by every authenticated caller who is not an admin
+	MemberRole Role = `member`
+)
+
+var (
+	ErrForbidden = errors.New(`the caller may not act on Tasks owned by others`)
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type Role string
+
+// Scope is the set of Tasks a caller may see and change. A Scope with a Tenant reaches only the Tasks of that tenant,
+// a confined Scope further reaches only the Tasks of Owner and the zero Scope reaches every Task.
+type Scope struct {
+	Tenant   string
+	Owner    string
+	Confined bool
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// RoleFrom returns the Role of the principal carried by ctx. A context without a principal, such as the one the purge
+// schedule runs with or one whose authentication is disabled, acts as an admin.
+func RoleFrom(ctx context.Context) Role {
+	if principal := auth.PrincipalFrom(ctx); principal != nil && !principal.HasRole(string(AdminRole)) {
+		return MemberRole
+	}
+	return AdminRole
+}
+
+// ScopeFrom returns the Scope of the caller carried by ctx, Stores confine every read and change to it so the Tasks of
+// other tenants, and for a member those of other owners, are not found. Even an admin is confined to its tenant.
+func ScopeFrom(ctx context.Context) Scope {
+	var scope Scope
+
+	if !acrossTenants(ctx) {
+		scope.Tenant = TenantFrom(ctx)
+	}
+
+	if RoleFrom(ctx) == MemberRole {
+		scope.Owner = auth.PrincipalFrom(ctx).Subject
+		scope.Confined = true
+	}
+
+	return scope
+}
+
+// Allows reports whether task lies within scope
+func (scope Scope) Allows(task Task) bool {
+	return scope.Reaches(task.TenantID, task.OwnerID)
+}
+
+// Reaches reports whether a record of tenant owned by owner lies within scope, it lets other records owned within a
+// tenant share the rules which confine Tasks
+func (scope Scope) Reaches(tenant string, owner string) bool {
+	if len(scope.Tenant) > 0 && tenant != scope.Tenant {
+		return false
+	}
+	return !scope.Confined || owner == scope.Owner
+}
+
+func (scope Scope) String() string {
+	var tenant, owner = `*`, `*`
+
+	if len(scope.Tenant) > 0 {
+		tenant = scope.Tenant
+	}
+	if scope.Confined {
+		owner = scope.Owner
+	}
+
+	return fmt.Sprintf(`{Tenant: %s, Owner: %s}`, tenant, owner)
+}
+
+// OwnerParameter is the owner a SQL statement is confined to, nil when it reaches every owner, so other stores of the
+// same database confine their records as the task store does
+func (scope Scope) OwnerParameter() interface{} {
+	if !scope.Confined {
+		return nil
+	}
+	return scope.Owner
+}
+
+// TenantParameter is the tenant a SQL statement is confined to, nil when it reaches every tenant
+func (scope Scope) TenantParameter() interface{} {
+	if len(scope.Tenant) == 0 {
+		return nil
+	}
+	return scope.Tenant
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// claim assigns task to the caller carried by ctx when it names no owner, a member may not create Tasks for others
+func claim(ctx context.Context, task *Task) error {
+	var scope = ScopeFrom(ctx)
+
+	if len(task.OwnerID) == 0 {
+		if principal := auth.PrincipalFrom(ctx); principal != nil {
+			task.OwnerID = principal.Subject
+		}
+	}
+
+	if scope.Confined && task.OwnerID != scope.Owner {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// permit rejects a query which asks a member for the Tasks of another owner
+func permit(ctx context.Context, query Query) error {
+	if scope := ScopeFrom(ctx); len(query.Filter.Owner) > 0 && scope.Confined && query.Filter.Owner != scope.Owner {
+		return ErrForbidden
+	}
+	return nil
+}
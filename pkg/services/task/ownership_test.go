@@ -0,0 +1,144 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+func withPrincipal(subject string, roles ...Role) context.Context {
+	var principal = &auth.Principal{Subject: subject}
+
+	for _, role := range roles {
+		principal.Roles = append(principal.Roles, string(role))
+	}
+
+	return auth.WithPrincipal(context.Background(), principal)
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestOwnershipScope(test *testing.T) {
+	//-- Shared Variables ----------
+
+	//-- Test Parameters ----------
+	var member = withPrincipal(`user-1`)
+	var admin = withPrincipal(`user-2`, AdminRole)
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+
+	//-- Post-conditions ----------
+	assert.Equal(test, AdminRole, RoleFrom(context.Background()))
+	assert.Equal(test, Scope{Tenant: DefaultTenant}, ScopeFrom(context.Background()))
+
+	assert.Equal(test, MemberRole, RoleFrom(member))
+	assert.Equal(test, Scope{Tenant: DefaultTenant, Owner: `user-1`, Confined: true}, ScopeFrom(member))
+	assert.True(test, ScopeFrom(member).Allows(Task{OwnerID: `user-1`, TenantID: DefaultTenant}))
+	assert.False(test, ScopeFrom(member).Allows(Task{OwnerID: `user-2`, TenantID: DefaultTenant}))
+	assert.False(test, ScopeFrom(member).Allows(Task{TenantID: DefaultTenant}))
+
+	assert.Equal(test, AdminRole, RoleFrom(admin))
+	assert.True(test, ScopeFrom(admin).Allows(Task{OwnerID: `user-1`, TenantID: DefaultTenant}))
+}
+
+func TestServiceCreateOwner(test *testing.T) {
+	//-- Shared Variables ----------
+	var service Service
+	var own, other, assigned *Task
+	var ownErr, otherErr, assignedErr error
+
+	//-- Test Parameters ----------
+	var member = withPrincipal(`user-1`)
+	var admin = withPrincipal(`user-2`, AdminRole)
+
+	//-- Pre-conditions ----------
+	service = NewService(nil, openMemoryStore(test))
+	defer shutdownService(test, service)
+
+	own = &Task{Name: `Test create own task`}
+	other = &Task{Name: `Test create other task`, OwnerID: `user-2`}
+	assigned = &Task{Name: `Test create assigned task`, OwnerID: `user-1`}
+
+	//-- Action ----------
+	ownErr = service.Create(member, own)
+	otherErr = service.Create(member, other)
+	assignedErr = service.Create(admin, assigned)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, ownErr)
+	assert.Equal(test, `user-1`, own.OwnerID)
+
+	assert.Equal(test, ErrForbidden, otherErr)
+	assert.Equal(test, uint(0), other.ID)
+
+	assert.Nil(test, assignedErr)
+	assert.Equal(test, `user-1`, assigned.OwnerID)
+}
+
+func TestServiceListOwner(test *testing.T) {
+	//-- Shared Variables ----------
+	var service Service
+	var own, other []Task
+	var ownErr, otherErr, paginateErr, adminErr error
+
+	//-- Test Parameters ----------
+	var member = withPrincipal(`user-1`)
+	var admin = withPrincipal(`user-2`, AdminRole)
+
+	//-- Pre-conditions ----------
+	service = NewService(nil, openMemoryStore(test))
+	defer shutdownService(test, service)
+
+	for _, ctx := range []context.Context{member, admin} {
+		if err := service.Create(ctx, &Task{Name: `Test list owner`}); err != nil {
+			test.Fatalf(`unable to create a task: %s`, err)
+		}
+	}
+
+	//-- Action ----------
+	own, ownErr = service.List(member, Query{Filter: Filter{Owner: `user-1`}}, 10, 0)
+	_, otherErr = service.List(member, Query{Filter: Filter{Owner: `user-2`}}, 10, 0)
+	_, paginateErr = service.Paginate(member, Query{Filter: Filter{Owner: `user-2`}}, 10, ``)
+	other, adminErr = service.List(admin, Query{Filter: Filter{Owner: `user-1`}}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, ownErr)
+	assert.Equal(test, 1, len(own))
+	assert.Equal(test, ErrForbidden, otherErr)
+	assert.Equal(test, ErrForbidden, paginateErr)
+	assert.Nil(test, adminErr)
+	assert.Equal(test, own, other)
+}
+
+func TestServicePurgeMember(test *testing.T) {
+	//-- Shared Variables ----------
+	var service Service
+	var memberErr, adminErr error
+
+	//-- Test Parameters ----------
+	var member = withPrincipal(`user-1`)
+	var admin = withPrincipal(`user-2`, AdminRole)
+
+	//-- Pre-conditions ----------
+	service = NewService(nil, openMemoryStore(test))
+	defer shutdownService(test, service)
+
+	//-- Action ----------
+	_, memberErr = service.Purge(member, time.Hour)
+	_, adminErr = service.Purge(admin, time.Hour)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, ErrForbidden, memberErr)
+	assert.Nil(test, adminErr)
+}
@@ -0,0 +1,256 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	SortByID         SortField = `id`
+	SortByCreatedAt  SortField = `created_at`
+	SortByUpdatedAt  SortField = `updated_at`
+	SortByResolvedAt SortField = `resolved_at`
+	SortByDeletedAt  SortField = `deleted_at`
+
+	Ascending  SortDirection = `asc`
+	Descending SortDirection = `desc`
+
+	ExcludeDeleted Deletion = `exclude`
+	IncludeDeleted Deletion = `include`
+	OnlyDeleted    Deletion = `only`
+)
+
+var (
+	sortFields     = map[SortField]bool{SortByID: true, SortByCreatedAt: true, SortByUpdatedAt: true, SortByResolvedAt: true, SortByDeletedAt: true}
+	sortDirections = map[SortDirection]bool{Ascending: true, Descending: true}
+	deletions      = map[Deletion]bool{ExcludeDeleted: true, IncludeDeleted: true, OnlyDeleted: true}
+
+	validNameFilterPattern = regexp.MustCompile(`\A[a-zA-Z0-9 \-:]{0,50}\z`)
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type SortField string
+
+type SortDirection string
+
+// Deletion selects whether a listing shows live Tasks (the default), every Task or only the Tasks in the trash
+type Deletion string
+
+// TimeRange matches timestamps at or after From and strictly before To, either bound may be omitted
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// Filter narrows a listing, zero-value fields do not filter. Name matches a case-insensitive substring of the Task name
+// and Resolved selects only resolved (true) or unresolved (false) Tasks. Owner selects the Tasks of a single owner.
+// Deleted Tasks are left out unless Deleted asks for them.
+type Filter struct {
+	Resolved *bool
+	Name     string
+	Owner    string
+	Deleted  Deletion
+
+	CreatedAt  TimeRange
+	UpdatedAt  TimeRange
+	ResolvedAt TimeRange
+}
+
+// Sort orders a listing by Field and then by ID in the same Direction. Missing timestamps sort before all others.
+type Sort struct {
+	Field     SortField
+	Direction SortDirection
+}
+
+// Query is the typed filter and sort specification shared by List and Paginate, the zero value selects every Task
+// ordered by ascending ID
+type Query struct {
+	Filter Filter
+	Sort   Sort
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+func (query Query) String() string {
+	var resolved = `<nil>`
+
+	if query.Filter.Resolved != nil {
+		resolved = fmt.Sprintf(`%t`, *query.Filter.Resolved)
+	}
+
+	return fmt.Sprintf(`{Resolved: %s, Name: %s, Owner: %s, Deleted: %s, CreatedAt: %s, UpdatedAt: %s, ResolvedAt: %s, Sort: %s %s}`, resolved, query.Filter.Name, query.Filter.Owner, query.Filter.deletion(), query.Filter.CreatedAt, query.Filter.UpdatedAt, query.Filter.ResolvedAt, query.Sort.field(), query.Sort.direction())
+}
+
+func (bounds TimeRange) String() string {
+	var from, to = `<nil>`, `<nil>`
+
+	if bounds.From != nil {
+		from = bounds.From.String()
+	}
+	if bounds.To != nil {
+		to = bounds.To.String()
+	}
+
+	return fmt.Sprintf(`[%s, %s)`, from, to)
+}
+
+func (query Query) Validate() error {
+	if !sortFields[query.Sort.field()] {
+		return errors.New(fmt.Sprintf(`query - Sort field '%s' must be one of id, created_at, updated_at, resolved_at or deleted_at`, query.Sort.Field))
+	}
+
+	if !sortDirections[query.Sort.direction()] {
+		return errors.New(fmt.Sprintf(`query - Sort direction '%s' must be one of asc or desc`, query.Sort.Direction))
+	}
+
+	if !deletions[query.Filter.deletion()] {
+		return errors.New(fmt.Sprintf(`query - Deleted filter '%s' must be one of exclude, include or only`, query.Filter.Deleted))
+	}
+
+	if !validNameFilterPattern.MatchString(query.Filter.Name) {
+		return errors.New(fmt.Sprintf(`query - Name filter '%s' must be comprised only of letters, numbers, spaces and hyphens/colons and may not exceed 50 characters`, query.Filter.Name))
+	}
+
+	if len(query.Filter.Owner) > maximumOwnerID {
+		return errors.New(fmt.Sprintf(`query - Owner filter may not exceed %d characters`, maximumOwnerID))
+	}
+
+	for name, bounds := range map[string]TimeRange{`CreatedAt`: query.Filter.CreatedAt, `UpdatedAt`: query.Filter.UpdatedAt, `ResolvedAt`: query.Filter.ResolvedAt} {
+		if bounds.From != nil && bounds.To != nil && !bounds.From.Before(*bounds.To) {
+			return errors.New(fmt.Sprintf(`query - %s range must start before it ends`, name))
+		}
+	}
+
+	return nil
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+func (sort Sort) field() SortField {
+	if len(sort.Field) == 0 {
+		return SortByID
+	}
+	return sort.Field
+}
+
+func (sort Sort) direction() SortDirection {
+	if len(sort.Direction) == 0 {
+		return Ascending
+	}
+	return sort.Direction
+}
+
+// key returns the sort value of task, nil when the Task has no such timestamp or when sorting by ID alone
+func (sort Sort) key(task Task) *time.Time {
+	switch sort.field() {
+	case SortByCreatedAt:
+		var createdAt = task.CreatedAt
+		return &createdAt
+	case SortByUpdatedAt:
+		return task.UpdatedAt
+	case SortByResolvedAt:
+		return task.ResolvedAt
+	case SortByDeletedAt:
+		return task.DeletedAt
+	default:
+		return nil
+	}
+}
+
+// less reports whether a is ordered strictly before b
+func (sort Sort) less(a Task, b Task) bool {
+	return sort.before(sort.key(a), a.ID, sort.key(b), b.ID)
+}
+
+// before reports whether the key (value, id) is ordered strictly before (otherValue, otherID)
+func (sort Sort) before(value *time.Time, id uint, otherValue *time.Time, otherID uint) bool {
+	var comparison = compareTimes(value, otherValue)
+
+	if comparison == 0 && id < otherID {
+		comparison = -1
+	} else if comparison == 0 && id > otherID {
+		comparison = 1
+	}
+
+	if sort.direction() == Descending {
+		return comparison > 0
+	}
+	return comparison < 0
+}
+
+func (sort Sort) cursor(task Task, backward bool) Cursor {
+	return Cursor{ID: task.ID, Backward: backward, Field: sort.field(), Direction: sort.direction(), Value: sort.key(task)}
+}
+
+func (filter Filter) deletion() Deletion {
+	if len(filter.Deleted) == 0 {
+		return ExcludeDeleted
+	}
+	return filter.Deleted
+}
+
+func (filter Filter) match(task Task) bool {
+	switch filter.deletion() {
+	case ExcludeDeleted:
+		if task.DeletedAt != nil {
+			return false
+		}
+	case OnlyDeleted:
+		if task.DeletedAt == nil {
+			return false
+		}
+	}
+
+	if filter.Resolved != nil && *filter.Resolved != (task.ResolvedAt != nil) {
+		return false
+	}
+
+	if len(filter.Name) > 0 && !strings.Contains(strings.ToLower(task.Name), strings.ToLower(filter.Name)) {
+		return false
+	}
+
+	if len(filter.Owner) > 0 && task.OwnerID != filter.Owner {
+		return false
+	}
+
+	var createdAt = task.CreatedAt
+	return filter.CreatedAt.match(&createdAt) && filter.UpdatedAt.match(task.UpdatedAt) && filter.ResolvedAt.match(task.ResolvedAt)
+}
+
+func (bounds TimeRange) match(value *time.Time) bool {
+	if bounds.From == nil && bounds.To == nil {
+		return true
+	} else if value == nil {
+		return false
+	}
+
+	if bounds.From != nil && value.Before(*bounds.From) {
+		return false
+	} else if bounds.To != nil && !value.Before(*bounds.To) {
+		return false
+	}
+
+	return true
+}
+
+// compareTimes orders timestamps with a missing (nil) timestamp before all others
+func compareTimes(a *time.Time, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	case a.Before(*b):
+		return -1
+	case a.After(*b):
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,119 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestQueryValidateZero(test *testing.T) {
+	//-- Shared Variables ----------
+	var validateErr error
+
+	//-- Test Parameters ----------
+	var query = Query{}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	validateErr = query.Validate()
+
+	//-- Post-conditions ----------
+	assert.Nil(test, validateErr)
+}
+
+func TestQueryValidateInvalid(test *testing.T) {
+	//-- Shared Variables ----------
+	var validateErrs []error
+
+	//-- Test Parameters ----------
+	var from = time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)
+	var to = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var queries = []Query{
+		{Sort: Sort{Field: `name`}},
+		{Sort: Sort{Direction: `sideways`}},
+		{Filter: Filter{Name: `it's_100%`}},
+		{Filter: Filter{Deleted: `forever`}},
+		{Filter: Filter{UpdatedAt: TimeRange{From: &from, To: &to}}},
+		{Filter: Filter{CreatedAt: TimeRange{From: &from, To: &from}}},
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	for _, query := range queries {
+		validateErrs = append(validateErrs, query.Validate())
+	}
+
+	//-- Post-conditions ----------
+	for _, validateErr := range validateErrs {
+		assert.NotNil(test, validateErr)
+	}
+}
+
+func TestQueryFilterMatch(test *testing.T) {
+	//-- Shared Variables ----------
+	var matches []bool
+
+	//-- Test Parameters ----------
+	var resolved = true
+	var from = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	var to = time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	var filter = Filter{Resolved: &resolved, Name: `WIDGET`, ResolvedAt: TimeRange{From: &from, To: &to}}
+
+	var tasks = []Task{
+		{Name: `Ship the widget`, ResolvedAt: &from},
+		{Name: `Ship the widget`},
+		{Name: `Ship the gadget`, ResolvedAt: &from},
+		{Name: `Ship the widget`, ResolvedAt: &to},
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	for _, task := range tasks {
+		matches = append(matches, filter.match(task))
+	}
+
+	//-- Post-conditions ----------
+	assert.Equal(test, []bool{true, false, false, false}, matches)
+}
+
+func TestQuerySortLess(test *testing.T) {
+	//-- Shared Variables ----------
+	var ascending, descending bool
+	var tieAscending, tieDescending bool
+
+	//-- Test Parameters ----------
+	var resolvedAt = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var unresolved = Task{ID: 2}
+	var resolved = Task{ID: 1, ResolvedAt: &resolvedAt}
+	var other = Task{ID: 3, ResolvedAt: &resolvedAt}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	ascending = Sort{Field: SortByResolvedAt}.less(unresolved, resolved)
+	descending = Sort{Field: SortByResolvedAt, Direction: Descending}.less(unresolved, resolved)
+	tieAscending = Sort{Field: SortByResolvedAt}.less(resolved, other)
+	tieDescending = Sort{Field: SortByResolvedAt, Direction: Descending}.less(resolved, other)
+
+	//-- Post-conditions ----------
+	assert.True(test, ascending)
+	assert.False(test, descending)
+	assert.True(test, tieAscending)
+	assert.False(test, tieDescending)
+}
@@ -4,15 +4,20 @@ package task
 //-- Imports -----------------------------------------------------------------------------------------------------------
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"time"
 )
 
 //-- Constants ---------------------------------------------------------------------------------------------------------
+var (
+	ErrInvalidRetention = errors.New(`the purge retention period must be positive`)
+	ErrAlreadyResolved  = errors.New(`the Task is already resolved; reopen it before resolving it again`)
+)
 
 //-- Structs -----------------------------------------------------------------------------------------------------------
 type taskService struct {
-	store  Store
-	logger log.Logger
+	store Store
 }
 
 //-- Exported Functions ------------------------------------------------------------------------------------------------
@@ -27,8 +32,11 @@ func NewService(middlewares []Middleware, store Store) Service {
 	return service
 }
 
+// Create assigns task to the caller when it names no owner, only an admin may create a Task for another owner
 func (service taskService) Create(ctx context.Context, task *Task) error {
-	if err := service.store.insert(ctx, task); err != nil {
+	if err := claim(ctx, task); err != nil {
+		return err
+	} else if err := service.store.Insert(ctx, task); err != nil {
 		return err
 	} else {
 		return nil
@@ -36,15 +44,48 @@ func (service taskService) Create(ctx context.Context, task *Task) error {
 }
 
 func (service taskService) Update(ctx context.Context, task *Task) error {
-	if err := service.store.update(ctx, task); err != nil {
+	if err := service.store.Update(ctx, task); err != nil {
 		return err
 	} else {
 		return nil
 	}
 }
 
+func (service taskService) Patch(ctx context.Context, id uint, version uint, apply func(task *Task) error) (*Task, error) {
+	if task, err := service.store.Patch(ctx, id, version, apply); err != nil {
+		return nil, err
+	} else {
+		return task, nil
+	}
+}
+
+// Resolve sets the ResolvedAt of the Task with id to the current time of the service rather than that of a client,
+// resolving a Task which is already resolved leaves it as it is and returns ErrAlreadyResolved
+func (service taskService) Resolve(ctx context.Context, id uint) (*Task, error) {
+	//-- Match the database's microsecond timestamp resolution ----------
+	var timestamp = time.Now().UTC().Truncate(time.Microsecond)
+
+	return service.store.Patch(ctx, id, 0, func(task *Task) error {
+		if task.ResolvedAt != nil {
+			return fmt.Errorf(`%w (resolved at %s)`, ErrAlreadyResolved, task.ResolvedAt.UTC().Format(time.RFC3339))
+		}
+
+		task.ResolvedAt = &timestamp
+		return nil
+	})
+}
+
+// Reopen clears the ResolvedAt of the Task with id, reopening a Task which is not resolved changes nothing and returns
+// it as it is
+func (service taskService) Reopen(ctx context.Context, id uint) (*Task, error) {
+	return service.store.Patch(ctx, id, 0, func(task *Task) error {
+		task.ResolvedAt = nil
+		return nil
+	})
+}
+
 func (service taskService) Read(ctx context.Context, id uint) (*Task, error) {
-	if task, err := service.store.read(ctx, id); err != nil {
+	if task, err := service.store.Read(ctx, id); err != nil {
 		return nil, err
 	} else {
 		return task, nil
@@ -52,21 +93,141 @@ func (service taskService) Read(ctx context.Context, id uint) (*Task, error) {
 }
 
 func (service taskService) Delete(ctx context.Context, id uint) (*Task, error) {
-	if task, err := service.store.delete(ctx, id); err != nil {
+	if task, err := service.store.Delete(ctx, id); err != nil {
 		return nil, err
 	} else {
 		return task, nil
 	}
 }
 
-func (service taskService) List(ctx context.Context, limit uint, offset uint) ([]Task, error) {
-	if tasks, err := service.store.list(ctx, limit, offset); err != nil {
+func (service taskService) Restore(ctx context.Context, id uint) (*Task, error) {
+	if task, err := service.store.Restore(ctx, id); err != nil {
+		return nil, err
+	} else {
+		return task, nil
+	}
+}
+
+// Purge permanently removes the Tasks which have been in the trash for longer than retention, whoever owns them, so
+// only an admin may purge
+func (service taskService) Purge(ctx context.Context, retention time.Duration) (uint, error) {
+	if retention <= 0 {
+		return 0, ErrInvalidRetention
+	} else if RoleFrom(ctx) != AdminRole {
+		return 0, ErrForbidden
+	} else if purged, err := service.store.Purge(ctx, time.Now().UTC().Add(-retention)); err != nil {
+		return 0, err
+	} else {
+		return purged, nil
+	}
+}
+
+// BulkCreate creates each of tasks as Create would, in a single transaction of the store. A Task the caller may not
+// create fails as its own item, which in Atomic mode aborts the batch before anything is stored.
+func (service taskService) BulkCreate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error) {
+	var results = make([]BatchResult, len(tasks))
+	var claimed []*Task
+	var indexes []int
+
+	if err := checkBatch(len(tasks), mode); err != nil {
+		return nil, err
+	}
+
+	//-- Claim each Task without changing the caller's ----------
+	for index, task := range tasks {
+		var candidate = cloneTask(*task)
+
+		if err := claim(ctx, &candidate); err != nil && mode == Atomic {
+			return aborted(len(tasks), index, err), nil
+		} else if err != nil {
+			results[index] = BatchResult{Index: index, Err: err}
+		} else {
+			claimed = append(claimed, &candidate)
+			indexes = append(indexes, index)
+		}
+	}
+
+	//-- Store the claimed Tasks, reported at their index in tasks ----------
+	if stored, err := service.store.BulkCreate(ctx, claimed, mode); err != nil {
+		return nil, err
+	} else {
+		for position, result := range stored {
+			result.Index = indexes[position]
+			results[result.Index] = result
+		}
+	}
+
+	return results, nil
+}
+
+func (service taskService) BulkUpdate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error) {
+	if err := checkBatch(len(tasks), mode); err != nil {
+		return nil, err
+	} else if results, err := service.store.BulkUpdate(ctx, tasks, mode); err != nil {
+		return nil, err
+	} else {
+		return results, nil
+	}
+}
+
+func (service taskService) BulkDelete(ctx context.Context, ids []uint, mode BatchMode) ([]BatchResult, error) {
+	if err := checkBatch(len(ids), mode); err != nil {
+		return nil, err
+	} else if results, err := service.store.BulkDelete(ctx, ids, mode); err != nil {
+		return nil, err
+	} else {
+		return results, nil
+	}
+}
+
+func (service taskService) List(ctx context.Context, query Query, limit uint, offset uint) ([]Task, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	} else if err := permit(ctx, query); err != nil {
+		return nil, err
+	} else if tasks, err := service.store.List(ctx, query, limit, offset); err != nil {
 		return nil, err
 	} else {
 		return tasks, nil
 	}
 }
 
+func (service taskService) Paginate(ctx context.Context, query Query, limit uint, token string) (*Page, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	} else if err := permit(ctx, query); err != nil {
+		return nil, err
+	} else if cursor, err := DecodeCursor(token); err != nil {
+		return nil, err
+	} else if cursor != nil && !cursor.matches(query.Sort) {
+		return nil, ErrInvalidCursor
+	} else if tasks, err := service.store.Seek(ctx, query, cursor, limit+1); err != nil {
+		return nil, err
+	} else {
+		return newPage(query.Sort, cursor, limit, tasks), nil
+	}
+}
+
+// History pages through the audit trail of the Task with id, oldest first. The cursor of a page is only valid for the
+// history of the same Task.
+func (service taskService) History(ctx context.Context, id uint, limit uint, token string) (*HistoryPage, error) {
+	var after uint
+
+	if cursor, err := DecodeCursor(token); err != nil {
+		return nil, err
+	} else if cursor != nil && (cursor.Backward || !cursor.matches(Sort{})) {
+		return nil, ErrInvalidCursor
+	} else if cursor != nil {
+		after = cursor.ID
+	}
+
+	if entries, err := service.store.History(ctx, id, after, limit+1); err != nil {
+		return nil, err
+	} else {
+		return newHistoryPage(entries, limit), nil
+	}
+}
+
 func (service taskService) Shutdown() error {
 	if err := service.store.Close(); err != nil {
 		return err
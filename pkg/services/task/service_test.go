@@ -4,8 +4,9 @@ package task
 //-- Imports -----------------------------------------------------------------------------------------------------------
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"testing"
 	"time"
@@ -33,7 +34,7 @@ func TestServiceNewService(test *testing.T) {
 	//-- Test Parameters ----------
 
 	//-- Pre-conditions ----------
-	store = openStore(test)
+	store = openMemoryStore(test)
 
 	//-- Action ----------
 	service = NewService(nil, store)
@@ -52,10 +53,9 @@ func TestServiceNewServiceWithMiddleware(test *testing.T) {
 	//-- Test Parameters ----------
 
 	//-- Pre-conditions ----------
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
-	logger = NewLogMiddleare(*log.New(os.Stdout, `task_service: `, 3))
+	logger = NewLogMiddleware(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
 
 	//-- Action ----------
 	service = NewService([]Middleware{logger}, store)
@@ -79,8 +79,7 @@ func TestServiceCreate(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
 	service = NewService(nil, store)
 	defer shutdownService(test, service)
@@ -112,8 +111,7 @@ func TestServiceCreateErr(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
 	service = NewService(nil, store)
 	defer shutdownService(test, service)
@@ -147,8 +145,7 @@ func TestServiceUpdate(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
 	service = NewService(nil, store)
 	defer shutdownService(test, service)
@@ -191,8 +188,7 @@ func TestServiceUpdateErr(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
 	service = NewService(nil, store)
 	defer shutdownService(test, service)
@@ -228,8 +224,7 @@ func TestServiceRead(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
 	service = NewService(nil, store)
 	defer shutdownService(test, service)
@@ -263,8 +258,7 @@ func TestServiceReadErr(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
 	service = NewService(nil, store)
 	defer shutdownService(test, service)
@@ -294,8 +288,7 @@ func TestServiceDelete(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
 	service = NewService(nil, store)
 	defer shutdownService(test, service)
@@ -312,7 +305,11 @@ func TestServiceDelete(test *testing.T) {
 
 	//-- Post-conditions ----------
 	assert.Nil(test, deleteErr)
-	assert.True(test, model.compare(*deleteModel))
+
+	if assert.NotNil(test, deleteModel) && assert.NotNil(test, deleteModel.DeletedAt) {
+		model.DeletedAt = deleteModel.DeletedAt
+		assert.True(test, model.compare(*deleteModel))
+	}
 }
 
 func TestServiceDeleteErr(test *testing.T) {
@@ -329,8 +326,7 @@ func TestServiceDeleteErr(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
 	service = NewService(nil, store)
 	defer shutdownService(test, service)
@@ -346,6 +342,456 @@ func TestServiceDeleteErr(test *testing.T) {
 	assert.Nil(test, deleteModel)
 }
 
+func TestServiceRestore(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var model, restoreModel, readModel *Task
+	var store Store
+	var service Service
+	var restoreErr, readErr error
+
+	//-- Test Parameters ----------
+	var name = `Test restore`
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	model = newValidTask()
+	model.Name = name
+
+	if err := service.Create(ctx, model); err != nil {
+		test.Fatalf(`unexpected error when creating record: %s`, err)
+	} else if _, err := service.Delete(ctx, model.ID); err != nil {
+		test.Fatalf(`unexpected error when deleting record: %s`, err)
+	}
+
+	//-- Action ----------
+	restoreModel, restoreErr = service.Restore(ctx, model.ID)
+	readModel, readErr = service.Read(ctx, model.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, restoreErr)
+	assert.Nil(test, readErr)
+
+	if assert.NotNil(test, restoreModel) {
+		assert.True(test, model.compare(*restoreModel))
+	}
+	if assert.NotNil(test, readModel) {
+		assert.True(test, model.compare(*readModel))
+	}
+}
+
+func TestServiceResolve(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var model, resolvedModel, readModel *Task
+	var store Store
+	var service Service
+	var resolveErr error
+	var before, after time.Time
+
+	//-- Test Parameters ----------
+	var name = `Test resolve`
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	model = newValidTask()
+	model.Name = name
+	model.ResolvedAt = nil
+
+	if err := service.Create(ctx, model); err != nil {
+		test.Fatalf(`unexpected error when creating record: %s`, err)
+	}
+
+	//-- Action ----------
+	before = time.Now().UTC().Truncate(time.Microsecond)
+	resolvedModel, resolveErr = service.Resolve(ctx, model.ID)
+	after = time.Now().UTC()
+
+	readModel, _ = service.Read(ctx, model.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, resolveErr)
+
+	if assert.NotNil(test, resolvedModel) && assert.NotNil(test, resolvedModel.ResolvedAt) {
+		assert.False(test, resolvedModel.ResolvedAt.Before(before))
+		assert.False(test, resolvedModel.ResolvedAt.After(after))
+		assert.Equal(test, time.UTC, resolvedModel.ResolvedAt.Location())
+		assert.Equal(test, uint(2), resolvedModel.Version)
+	}
+	if assert.NotNil(test, readModel) && assert.NotNil(test, readModel.ResolvedAt) {
+		assert.True(test, resolvedModel.ResolvedAt.Equal(*readModel.ResolvedAt))
+	}
+}
+
+func TestServiceResolveResolved(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var model, resolvedModel, readModel *Task
+	var store Store
+	var service Service
+	var resolveErr error
+
+	//-- Test Parameters ----------
+	var name = `Test resolve resolved`
+	var resolvedAt = time.Date(2019, time.January, 1, 0, 0, 1, 0, time.UTC)
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	model = newValidTask()
+	model.Name = name
+	model.ResolvedAt = &resolvedAt
+
+	if err := service.Create(ctx, model); err != nil {
+		test.Fatalf(`unexpected error when creating record: %s`, err)
+	}
+
+	//-- Action ----------
+	resolvedModel, resolveErr = service.Resolve(ctx, model.ID)
+	readModel, _ = service.Read(ctx, model.ID)
+
+	//-- Post-conditions ----------
+	assert.True(test, errors.Is(resolveErr, ErrAlreadyResolved))
+	assert.Contains(test, resolveErr.Error(), `2019-01-01T00:00:01Z`)
+	assert.Nil(test, resolvedModel)
+
+	if assert.NotNil(test, readModel) && assert.NotNil(test, readModel.ResolvedAt) {
+		assert.True(test, resolvedAt.Equal(*readModel.ResolvedAt))
+		assert.Equal(test, uint(1), readModel.Version)
+	}
+}
+
+func TestServiceReopen(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var model, reopenedModel, repeatedModel *Task
+	var store Store
+	var service Service
+	var reopenErr, repeatedErr error
+
+	//-- Test Parameters ----------
+	var name = `Test reopen`
+	var resolvedAt = time.Now()
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	model = newValidTask()
+	model.Name = name
+	model.ResolvedAt = &resolvedAt
+
+	if err := service.Create(ctx, model); err != nil {
+		test.Fatalf(`unexpected error when creating record: %s`, err)
+	}
+
+	//-- Action ----------
+	reopenedModel, reopenErr = service.Reopen(ctx, model.ID)
+	repeatedModel, repeatedErr = service.Reopen(ctx, model.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, reopenErr)
+	assert.Nil(test, repeatedErr)
+
+	if assert.NotNil(test, reopenedModel) {
+		assert.Nil(test, reopenedModel.ResolvedAt)
+		assert.Equal(test, uint(2), reopenedModel.Version)
+	}
+	if assert.NotNil(test, repeatedModel) {
+		assert.Nil(test, repeatedModel.ResolvedAt)
+		assert.Equal(test, uint(2), repeatedModel.Version)
+	}
+}
+
+func TestServiceBulkCreate(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var models []*Task
+	var results []BatchResult
+	var store Store
+	var service Service
+	var bulkErr error
+
+	//-- Test Parameters ----------
+	var member = `bulk-member`
+
+	//-- Pre-conditions ----------
+	ctx = withPrincipal(member)
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	models = []*Task{newValidTask(), newValidTask(), newValidTask()}
+	models[1].OwnerID = `bulk-someone-else`
+
+	//-- Action ----------
+	results, bulkErr = service.BulkCreate(ctx, models, PerItem)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, bulkErr)
+
+	if assert.Equal(test, 3, len(results)) {
+		assert.Nil(test, results[0].Err)
+		assert.Equal(test, ErrForbidden, results[1].Err)
+		assert.Nil(test, results[2].Err)
+
+		for index, result := range results {
+			assert.Equal(test, index, result.Index)
+		}
+
+		assert.Equal(test, member, results[0].Task.OwnerID)
+		assert.Equal(test, member, results[2].Task.OwnerID)
+		assert.NotEqual(test, results[0].Task.ID, results[2].Task.ID)
+	}
+
+	assert.Equal(test, ``, models[0].OwnerID)
+	assert.Equal(test, uint(0), models[0].ID)
+}
+
+func TestServiceBulkCreateForbidden(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var models []*Task
+	var results []BatchResult
+	var listed []Task
+	var store Store
+	var service Service
+	var bulkErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	ctx = withPrincipal(`bulk-member`)
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	models = []*Task{newValidTask(), newValidTask()}
+	models[1].OwnerID = `bulk-someone-else`
+
+	//-- Action ----------
+	results, bulkErr = service.BulkCreate(ctx, models, Atomic)
+	listed, _ = service.List(ctx, Query{}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, bulkErr)
+	assert.Equal(test, 0, len(listed))
+
+	if assert.Equal(test, 2, len(results)) {
+		assert.Equal(test, ErrBatchAborted, results[0].Err)
+		assert.Equal(test, ErrForbidden, results[1].Err)
+	}
+}
+
+func TestServiceBulkErr(test *testing.T) {
+	//-- Shared Variables ----------
+	var store Store
+	var service Service
+	var largeErr, modeErr, updateErr, deleteErr error
+
+	//-- Test Parameters ----------
+	var oversized = make([]uint, MaxBatchSize+1)
+
+	//-- Pre-conditions ----------
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	//-- Action ----------
+	_, largeErr = service.BulkDelete(context.Background(), oversized, Atomic)
+	_, modeErr = service.BulkCreate(context.Background(), []*Task{newValidTask()}, BatchMode(`some`))
+	_, updateErr = service.BulkUpdate(context.Background(), nil, ``)
+	_, deleteErr = service.BulkDelete(context.Background(), oversized[:MaxBatchSize], PerItem)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, ErrBatchTooLarge, largeErr)
+	assert.Equal(test, ErrInvalidBatchMode, modeErr)
+	assert.Equal(test, ErrInvalidBatchMode, updateErr)
+	assert.Nil(test, deleteErr)
+}
+
+func TestServicePurge(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var live, trashed *Task
+	var store Store
+	var service Service
+	var purged uint
+	var purgeErr error
+	var remaining []Task
+
+	//-- Test Parameters ----------
+	var retention = 10 * time.Millisecond
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	live, trashed = newValidTask(), newValidTask()
+
+	for _, model := range []*Task{live, trashed} {
+		if err := service.Create(ctx, model); err != nil {
+			test.Fatalf(`unexpected error when creating record: %s`, err)
+		}
+	}
+
+	if _, err := service.Delete(ctx, trashed.ID); err != nil {
+		test.Fatalf(`unexpected error when deleting record: %s`, err)
+	}
+	time.Sleep(2 * retention)
+
+	//-- Action ----------
+	purged, purgeErr = service.Purge(ctx, retention)
+	remaining, _ = service.List(ctx, Query{Filter: Filter{Deleted: IncludeDeleted}}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, purgeErr)
+	assert.Equal(test, uint(1), purged)
+
+	if assert.Equal(test, 1, len(remaining)) {
+		assert.Equal(test, live.ID, remaining[0].ID)
+	}
+}
+
+func TestServicePurgeErr(test *testing.T) {
+	//-- Shared Variables ----------
+	var store Store
+	var service Service
+	var purged uint
+	var purgeErr error
+
+	//-- Test Parameters ----------
+	var retention time.Duration = 0
+
+	//-- Pre-conditions ----------
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	//-- Action ----------
+	purged, purgeErr = service.Purge(context.Background(), retention)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, ErrInvalidRetention, purgeErr)
+	assert.Equal(test, uint(0), purged)
+}
+
+func TestServiceHistory(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var model *Task
+	var store Store
+	var service Service
+	var first, second *HistoryPage
+	var firstErr, secondErr error
+
+	//-- Test Parameters ----------
+	var actor = `Test history actor`
+	var updates = 3
+	var limit uint = 2
+
+	//-- Pre-conditions ----------
+	ctx = WithActor(context.Background(), actor)
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	model = newValidTask()
+
+	if err := service.Create(ctx, model); err != nil {
+		test.Fatalf(`unexpected error when creating record: %s`, err)
+	}
+	for index := 0; index < updates; index++ {
+		if err := service.Update(ctx, model); err != nil {
+			test.Fatalf(`unexpected error when updating record: %s`, err)
+		}
+	}
+
+	//-- Action ----------
+	first, firstErr = service.History(ctx, model.ID, limit, ``)
+	if first != nil {
+		second, secondErr = service.History(ctx, model.ID, limit, first.Next)
+	}
+
+	//-- Post-conditions ----------
+	assert.Nil(test, firstErr)
+	assert.Nil(test, secondErr)
+
+	if assert.NotNil(test, first) && assert.Equal(test, 2, len(first.Entries)) {
+		assert.Equal(test, Created, first.Entries[0].Action)
+		assert.Equal(test, actor, first.Entries[0].Actor)
+		assert.NotEqual(test, ``, first.Next)
+	}
+	if assert.NotNil(test, second) && assert.Equal(test, 2, len(second.Entries)) {
+		assert.Equal(test, uint(4), second.Entries[1].Version)
+		assert.Equal(test, ``, second.Next)
+	}
+}
+
+func TestServiceHistoryErr(test *testing.T) {
+	//-- Shared Variables ----------
+	var store Store
+	var service Service
+	var results = make([]error, 0)
+
+	//-- Test Parameters ----------
+	var cursors = []string{
+		`not a cursor`,
+		Cursor{ID: 1, Backward: true, Field: SortByID, Direction: Ascending}.Encode(),
+		Cursor{ID: 1, Field: SortByCreatedAt, Direction: Ascending}.Encode(),
+	}
+
+	//-- Pre-conditions ----------
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	//-- Action ----------
+	for _, cursor := range cursors {
+		var _, err = service.History(context.Background(), 1, 10, cursor)
+		results = append(results, err)
+	}
+
+	//-- Post-conditions ----------
+	for _, result := range results {
+		assert.Equal(test, ErrInvalidCursor, result)
+	}
+}
+
 func TestServiceList(test *testing.T) {
 	//-- Shared Variables ----------
 	var ctx context.Context
@@ -364,8 +810,7 @@ func TestServiceList(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
 	service = NewService(nil, store)
 	defer shutdownService(test, service)
@@ -380,7 +825,7 @@ func TestServiceList(test *testing.T) {
 	}
 
 	//-- Action ----------
-	listModels, listErr = service.List(ctx, limit, offset)
+	listModels, listErr = service.List(ctx, Query{}, limit, offset)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, listErr)
@@ -405,8 +850,7 @@ func TestServiceListLimit(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
 	service = NewService(nil, store)
 	defer shutdownService(test, service)
@@ -421,7 +865,7 @@ func TestServiceListLimit(test *testing.T) {
 	}
 
 	//-- Action ----------
-	listModels, listErr = service.List(ctx, limit, offset)
+	listModels, listErr = service.List(ctx, Query{}, limit, offset)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, listErr)
@@ -446,8 +890,7 @@ func TestServiceListZeroLimit(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
 	service = NewService(nil, store)
 	defer shutdownService(test, service)
@@ -462,7 +905,7 @@ func TestServiceListZeroLimit(test *testing.T) {
 	}
 
 	//-- Action ----------
-	listModels, listErr = service.List(ctx, limit, offset)
+	listModels, listErr = service.List(ctx, Query{}, limit, offset)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, listErr)
@@ -487,8 +930,7 @@ func TestServiceListOverLimit(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
 	service = NewService(nil, store)
 	defer shutdownService(test, service)
@@ -503,7 +945,7 @@ func TestServiceListOverLimit(test *testing.T) {
 	}
 
 	//-- Action ----------
-	listModels, listErr = service.List(ctx, limit, offset)
+	listModels, listErr = service.List(ctx, Query{}, limit, offset)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, listErr)
@@ -528,8 +970,7 @@ func TestServiceListOffset(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
 	service = NewService(nil, store)
 	defer shutdownService(test, service)
@@ -544,7 +985,7 @@ func TestServiceListOffset(test *testing.T) {
 	}
 
 	//-- Action ----------
-	listModels, listErr = service.List(ctx, limit, offset)
+	listModels, listErr = service.List(ctx, Query{}, limit, offset)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, listErr)
@@ -569,8 +1010,7 @@ func TestServiceListOverOffset(test *testing.T) {
 	//-- Pre-conditions ----------
 	ctx = context.Background()
 
-	store = openStore(test)
-	resetStore(test, store)
+	store = openMemoryStore(test)
 
 	service = NewService(nil, store)
 	defer shutdownService(test, service)
@@ -585,9 +1025,291 @@ func TestServiceListOverOffset(test *testing.T) {
 	}
 
 	//-- Action ----------
-	listModels, listErr = service.List(ctx, limit, offset)
+	listModels, listErr = service.List(ctx, Query{}, limit, offset)
 
 	//-- Post-conditions ----------
 	assert.Nil(test, listErr)
 	assert.Equal(test, 0, len(listModels))
 }
+
+func TestServicePaginate(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var store Store
+	var service Service
+	var pages []*Page
+
+	//-- Test Parameters ----------
+	var name = `Testing keyset pagination`
+	var quantity = 10
+
+	var limit uint = 4
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	for i := 0; i < quantity; i++ {
+		var task = newValidTask()
+		task.Name = fmt.Sprintf(`%s %d`, name, i)
+
+		if err := service.Create(ctx, task); err != nil {
+			test.Fatalf(`unexpected error when inserting record: %s`, err)
+		}
+	}
+
+	//-- Action ----------
+	for cursor := ``; len(pages) == 0 || len(cursor) > 0; {
+		if page, err := service.Paginate(ctx, Query{}, limit, cursor); err != nil {
+			test.Fatalf(`unexpected error when paginating records: %s`, err)
+		} else {
+			pages = append(pages, page)
+			cursor = page.Next
+		}
+	}
+
+	//-- Post-conditions ----------
+	if assert.Equal(test, 3, len(pages)) {
+		assert.Equal(test, 4, len(pages[0].Tasks))
+		assert.Equal(test, 4, len(pages[1].Tasks))
+		assert.Equal(test, 2, len(pages[2].Tasks))
+
+		assert.Equal(test, ``, pages[0].Previous)
+		assert.NotEqual(test, ``, pages[2].Previous)
+		assert.Equal(test, uint(10), pages[2].Tasks[1].ID)
+	}
+}
+
+func TestServicePaginateBackward(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var store Store
+	var service Service
+	var firstPage, secondPage, previousPage *Page
+	var paginateErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing backward pagination`
+	var quantity = 10
+
+	var limit uint = 4
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	for i := 0; i < quantity; i++ {
+		var task = newValidTask()
+		task.Name = fmt.Sprintf(`%s %d`, name, i)
+
+		if err := service.Create(ctx, task); err != nil {
+			test.Fatalf(`unexpected error when inserting record: %s`, err)
+		}
+	}
+
+	firstPage, _ = service.Paginate(ctx, Query{}, limit, ``)
+	secondPage, _ = service.Paginate(ctx, Query{}, limit, firstPage.Next)
+
+	//-- Action ----------
+	previousPage, paginateErr = service.Paginate(ctx, Query{}, limit, secondPage.Previous)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, paginateErr)
+	assert.Equal(test, firstPage.Tasks, previousPage.Tasks)
+	assert.Equal(test, ``, previousPage.Previous)
+	assert.Equal(test, firstPage.Next, previousPage.Next)
+}
+
+func TestServicePaginateStable(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var store Store
+	var service Service
+	var firstPage, secondPage *Page
+	var paginateErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing stable pagination`
+	var quantity = 6
+
+	var limit uint = 3
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	for i := 0; i < quantity; i++ {
+		var task = newValidTask()
+		task.Name = fmt.Sprintf(`%s %d`, name, i)
+
+		if err := service.Create(ctx, task); err != nil {
+			test.Fatalf(`unexpected error when inserting record: %s`, err)
+		}
+	}
+
+	firstPage, _ = service.Paginate(ctx, Query{}, limit, ``)
+
+	if _, err := service.Delete(ctx, firstPage.Tasks[0].ID); err != nil {
+		test.Fatalf(`unexpected error when deleting record: %s`, err)
+	}
+
+	//-- Action ----------
+	secondPage, paginateErr = service.Paginate(ctx, Query{}, limit, firstPage.Next)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, paginateErr)
+
+	if assert.Equal(test, 3, len(secondPage.Tasks)) {
+		assert.Equal(test, uint(4), secondPage.Tasks[0].ID)
+	}
+}
+
+func TestServicePaginateErr(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var store Store
+	var service Service
+	var page *Page
+	var paginateErr error
+
+	//-- Test Parameters ----------
+	var limit uint = 4
+	var cursor = `not a cursor!`
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	//-- Action ----------
+	page, paginateErr = service.Paginate(ctx, Query{}, limit, cursor)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, ErrInvalidCursor, paginateErr)
+	assert.Nil(test, page)
+}
+
+func TestServicePaginateSorted(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var store Store
+	var service Service
+	var firstPage, secondPage *Page
+	var paginateErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing sorted pagination`
+	var quantity = 5
+
+	var query = Query{Filter: Filter{Name: `sorted`}, Sort: Sort{Field: SortByCreatedAt, Direction: Descending}}
+	var limit uint = 3
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	for i := 0; i < quantity; i++ {
+		var task = newValidTask()
+		task.Name = fmt.Sprintf(`%s %d`, name, i)
+
+		if err := service.Create(ctx, task); err != nil {
+			test.Fatalf(`unexpected error when inserting record: %s`, err)
+		}
+	}
+
+	if err := service.Create(ctx, newValidTask()); err != nil {
+		test.Fatalf(`unexpected error when inserting record: %s`, err)
+	}
+
+	firstPage, _ = service.Paginate(ctx, query, limit, ``)
+
+	//-- Action ----------
+	secondPage, paginateErr = service.Paginate(ctx, query, limit, firstPage.Next)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, paginateErr)
+
+	if assert.Equal(test, 3, len(firstPage.Tasks)) {
+		assert.Equal(test, uint(5), firstPage.Tasks[0].ID)
+	}
+	if assert.Equal(test, 2, len(secondPage.Tasks)) {
+		assert.Equal(test, uint(2), secondPage.Tasks[0].ID)
+		assert.Equal(test, uint(1), secondPage.Tasks[1].ID)
+	}
+	assert.Equal(test, ``, secondPage.Next)
+}
+
+func TestServicePaginateSortMismatch(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var store Store
+	var service Service
+	var page *Page
+	var paginateErr error
+
+	//-- Test Parameters ----------
+	var limit uint = 4
+	var cursor = Cursor{ID: 3, Field: SortByID, Direction: Ascending}.Encode()
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	//-- Action ----------
+	page, paginateErr = service.Paginate(ctx, Query{Sort: Sort{Direction: Descending}}, limit, cursor)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, ErrInvalidCursor, paginateErr)
+	assert.Nil(test, page)
+}
+
+func TestServicePaginateInvalidQuery(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var store Store
+	var service Service
+	var page *Page
+	var paginateErr error
+
+	//-- Test Parameters ----------
+	var limit uint = 4
+	var query = Query{Filter: Filter{Name: `100%`}}
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	store = openMemoryStore(test)
+
+	service = NewService(nil, store)
+	defer shutdownService(test, service)
+
+	//-- Action ----------
+	page, paginateErr = service.Paginate(ctx, query, limit, ``)
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, paginateErr)
+	assert.Nil(test, page)
+}
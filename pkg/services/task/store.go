@@ -5,10 +5,14 @@ package task
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/JustonDavies/go_serverless_api/pkg/tracing"
 	_ "github.com/lib/pq"
 
 	"github.com/golang-migrate/migrate/v4"
@@ -17,16 +21,72 @@ import (
 )
 
 //-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// taskColumns is the column order every query scans into a Task with taskFields
+	taskColumns = `id, name, details, resolved_at, created_at, updated_at, version, deleted_at, owner_id, tenant_id`
+
+	// historyColumns is the column order every query scans into a History entry with scanHistory
+	historyColumns = `id, task_id, owner_id, tenant_id, action, actor, version, changes, created_at`
+)
+
 var (
 	queryMap = map[string]string{
-		`insertTask`: `INSERT INTO tasks(name, details, resolved_at, created_at) VALUES($1, $2, $3, $4) RETURNING id`,
-		`updateTask`: `UPDATE tasks SET name = $2, details = $3, resolved_at = $4, updated_at = $5 WHERE id = $1 RETURNING id`,
-		`readTask`:   `SELECT * FROM tasks WHERE id = $1 LIMIT 1`,
-		`deleteTask`: `DELETE FROM tasks WHERE id = $1 RETURNING *`,
-		`listTasks`:  `SELECT * FROM tasks ORDER BY id LIMIT $1 OFFSET $2 ROWS`,
+		`insertTask`:  `INSERT INTO tasks(name, details, resolved_at, created_at, owner_id, tenant_id, version) VALUES($1, $2, $3, $4, $5, $6, 1) RETURNING id, version`,
+		`updateTask`:  `UPDATE tasks SET name = $2, details = $3, resolved_at = $4, updated_at = $5, version = version + 1 WHERE id = $1 AND deleted_at IS NULL AND ($6::INTEGER = 0 OR version = $6::INTEGER) AND ($7::VARCHAR IS NULL OR tenant_id = $7::VARCHAR) RETURNING version`,
+		`lockTask`:    `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2::VARCHAR) LIMIT 1 FOR UPDATE`,
+		`readTask`:    `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1 AND deleted_at IS NULL AND ($2::VARCHAR IS NULL OR owner_id = $2::VARCHAR) AND ($3::VARCHAR IS NULL OR tenant_id = $3::VARCHAR) LIMIT 1`,
+		`deleteTask`:  `UPDATE tasks SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL AND ($3::VARCHAR IS NULL OR tenant_id = $3::VARCHAR) RETURNING ` + taskColumns,
+		`restoreTask`: `UPDATE tasks SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL AND ($2::VARCHAR IS NULL OR tenant_id = $2::VARCHAR) RETURNING ` + taskColumns,
+		`purgeTasks`:  `WITH purged AS (DELETE FROM tasks WHERE deleted_at < $1 AND ($5::VARCHAR IS NULL OR owner_id = $5::VARCHAR) AND ($6::VARCHAR IS NULL OR tenant_id = $6::VARCHAR) RETURNING id, owner_id, tenant_id, version) INSERT INTO task_history(task_id, owner_id, tenant_id, action, actor, version, changes, created_at) SELECT id, owner_id, tenant_id, $2::VARCHAR, $3::VARCHAR, version, '[]'::JSONB, $4::TIMESTAMP WITH TIME ZONE FROM purged`,
+		`listTasks`:   `SELECT ` + taskColumns + ` FROM tasks WHERE %s ORDER BY %s LIMIT %s OFFSET %s ROWS`,
+
+		`insertHistory`: `INSERT INTO task_history(task_id, owner_id, tenant_id, action, actor, version, changes, created_at) VALUES($1, $2, $3, $4, $5, $6, $7, $8)`,
+		`listHistory`:   `SELECT ` + historyColumns + ` FROM task_history WHERE task_id = $1 AND id > $2 AND ($4::VARCHAR IS NULL OR owner_id = $4::VARCHAR) AND ($5::VARCHAR IS NULL OR tenant_id = $5::VARCHAR) ORDER BY id ASC LIMIT $3`,
+
+		// savepointItem, rollbackItem and releaseItem confine the failure of an item of a per item batch to that item
+		`savepointItem`: `SAVEPOINT batch_item`,
+		`rollbackItem`:  `ROLLBACK TO SAVEPOINT batch_item`,
+		`releaseItem`:   `RELEASE SAVEPOINT batch_item`,
+
+		// scopeTenant names the tenant the row level security policies of the transaction allow, empty allows every tenant
+		`scopeTenant`: `SELECT set_config('app.tenant_id', $1::TEXT, TRUE)`,
+
+		`seekTasks`:       `SELECT ` + taskColumns + ` FROM tasks WHERE %s ORDER BY %s LIMIT %s`,
+		`seekTasksBefore`: `SELECT * FROM (SELECT ` + taskColumns + ` FROM tasks WHERE %s ORDER BY %s LIMIT %s) AS page ORDER BY %s`,
+
+		`filterNone`:       `TRUE`,
+		`filterResolved`:   `resolved_at IS NOT NULL`,
+		`filterUnresolved`: `resolved_at IS NULL`,
+		`filterLive`:       `deleted_at IS NULL`,
+		`filterDeleted`:    `deleted_at IS NOT NULL`,
+		`filterName`:       `strpos(lower(name), lower(%s)) > 0`,
+		`filterOwner`:      `owner_id = %s`,
+		`filterTenant`:     `tenant_id = %s`,
+		`filterFrom`:       `%s >= %s`,
+		`filterTo`:         `%s < %s`,
+		`filterAfter`:      `(%s, id) > (%s, %s)`,
+		`filterBefore`:     `(%s, id) < (%s, %s)`,
+		`cursorValue`:      `COALESCE(%s::TIMESTAMP WITH TIME ZONE, '-infinity')`,
+	}
+
+	// statementTable finds the table a statement reads or writes, to name its span
+	statementTable = regexp.MustCompile(`(?i)\b(?:FROM|INTO|UPDATE)\s+([a-z_]+)`)
+
+	sortColumns = map[SortField]string{
+		SortByID:         `id`,
+		SortByCreatedAt:  `COALESCE(created_at, '-infinity')`,
+		SortByUpdatedAt:  `COALESCE(updated_at, '-infinity')`,
+		SortByResolvedAt: `COALESCE(resolved_at, '-infinity')`,
+		SortByDeletedAt:  `COALESCE(deleted_at, '-infinity')`,
+	}
+
+	sortOrders = map[SortDirection]string{
+		Ascending:  `ASC`,
+		Descending: `DESC`,
 	}
 
 	ErrIllAdvisedInsert = errors.New(`inserting a Task with non-zero ID in inadvisable; either pass a clean struct or do an update if this is an existing record`)
+	ErrVersionConflict  = errors.New(`the Task has been changed since the given version was read; re-read it and reapply the update`)
 )
 
 //-- Structs -----------------------------------------------------------------------------------------------------------
@@ -34,6 +94,19 @@ type postgresStore struct {
 	database *sql.DB
 }
 
+// tracedTx runs every statement of a transaction as a child span of the context the transaction was begun with
+type tracedTx struct {
+	*sql.Tx
+	ctx context.Context
+}
+
+// statement accumulates the WHERE conditions and positional parameters of a dynamically filtered query, only fragments
+// from queryMap and the sort whitelists are ever written into the SQL text
+type statement struct {
+	conditions []string
+	parameters []interface{}
+}
+
 //-- Exported Functions ------------------------------------------------------------------------------------------------
 func NewPostgresStore() Store {
 	return new(postgresStore)
@@ -64,6 +137,18 @@ func (store *postgresStore) Close() error {
 	return nil
 }
 
+func (store *postgresStore) Ping(ctx context.Context) error {
+	if store.database == nil {
+		return errors.New(`database was not initialized or in a connected state`)
+	}
+	return store.database.PingContext(ctx)
+}
+
+// Database is the connection pool of the store, which the stores of other services in the same database borrow
+func (store *postgresStore) Database() *sql.DB {
+	return store.database
+}
+
 func (store *postgresStore) Prepare(option string, parameter string) error {
 	switch option {
 	case `up`:
@@ -77,133 +162,103 @@ func (store *postgresStore) Prepare(option string, parameter string) error {
 	}
 }
 
-//-- Internal Functions ------------------------------------------------------------------------------------------------
-func (store *postgresStore) handleTransactionError(transaction *sql.Tx, original error) error {
-	if err := transaction.Rollback(); err != nil {
-		return errors.New(fmt.Sprintf(`an unrecoverable exception has occured rolling back the transaction (%s) - > (%s)`, original, err))
-	}
-
-	return original
-}
-
-func (store *postgresStore) up(migrationPath string) error {
-	if driver, err := postgres.WithInstance(store.database, &postgres.Config{}); err != nil {
-		return err
-	} else if migration, err := migrate.NewWithDatabaseInstance(migrationPath, `postgres`, driver); err != nil {
-		return err
-	} else if err := migration.Up(); err != nil && err != migrate.ErrNoChange {
-		return err
-	}
-	return nil
-}
-
-func (store *postgresStore) down(migrationPath string) error {
-	if driver, err := postgres.WithInstance(store.database, &postgres.Config{}); err != nil {
-		return err
-	} else if migration, err := migrate.NewWithDatabaseInstance(migrationPath, `postgres`, driver); err != nil {
-		return err
-	} else if err := migration.Down(); err != nil {
-		return err
-	}
-	return nil
-}
-
-func (store *postgresStore) drop(migrationPath string) error {
-	if driver, err := postgres.WithInstance(store.database, &postgres.Config{}); err != nil {
-		return err
-	} else if migration, err := migrate.NewWithDatabaseInstance(migrationPath, `postgres`, driver); err != nil {
-		return err
-	} else if err := migration.Drop(); err != nil {
-		return err
-	}
-	return nil
-}
-
-func (store *postgresStore) version(migrationPath string) (uint, bool, error) {
-	if driver, err := postgres.WithInstance(store.database, &postgres.Config{}); err != nil {
-		return 0, false, err
-	} else if migration, err := migrate.NewWithDatabaseInstance(migrationPath, `postgres`, driver); err != nil {
-		return 0, false, err
-	} else if ver, dirty, err := migration.Version(); err != nil && err != migrate.ErrNoChange {
-		return 0, false, err
-	} else {
-		return ver, dirty, nil
-	}
-}
-
-func (store *postgresStore) insert(ctx context.Context, task *Task) error {
+func (store *postgresStore) Insert(ctx context.Context, task *Task) error {
 	//-- Common variables ----------
-	var id int
 	var timestamp = time.Now().UTC()
-	var query = queryMap[`insertTask`]
-
-	//-- Parameter checking ----------
-	if task.ID != 0 {
-		return ErrIllAdvisedInsert
-	}
 
 	//-- Sanitize & validate ---------
-	if err := task.sanitize(); err != nil {
-		return err
-	} else if err := task.validate(); err != nil {
+	if err := prepareInsert(ctx, task); err != nil {
 		return err
 	}
 
 	//-- Insert Transaction ----------
 	{
-		if transaction, err := store.database.BeginTx(ctx, nil); err != nil {
+		if transaction, err := store.begin(ctx); err != nil {
 			return err
-		} else if err := transaction.QueryRow(query, task.Name, task.Details, task.ResolvedAt, timestamp).Scan(&id); err != nil {
+		} else if stored, err := store.insert(ctx, transaction, *task, timestamp); err != nil {
 			return store.handleTransactionError(transaction, err)
 		} else if err := transaction.Commit(); err != nil {
 			return err
 		} else {
-			task.ID = uint(id)
-			task.CreatedAt = timestamp
-			task.UpdatedAt = nil
+			*task = stored
 			return nil
 		}
 	}
 }
 
-func (store *postgresStore) update(ctx context.Context, task *Task) error {
+func (store *postgresStore) Update(ctx context.Context, task *Task) error {
 	//-- Common variables ----------
-	var id int
 	var timestamp = time.Now().UTC()
-	var query = queryMap[`updateTask`]
 
 	//-- Sanitize & validate ---------
-	if err := task.sanitize(); err != nil {
+	if err := task.Sanitize(); err != nil {
 		return err
-	} else if err := task.validate(); err != nil {
+	} else if err := task.Validate(); err != nil {
 		return err
 	}
 
-	//-- Insert Transaction ----------
+	//-- Update Transaction ----------
 	{
-		if transaction, err := store.database.BeginTx(ctx, nil); err != nil {
+		if transaction, err := store.begin(ctx); err != nil {
 			return err
-		} else if err := transaction.QueryRow(query, task.ID, task.Name, task.Details, task.ResolvedAt, timestamp).Scan(&id); err != nil {
+		} else if stored, err := store.update(ctx, transaction, *task, timestamp); err != nil {
 			return store.handleTransactionError(transaction, err)
 		} else if err := transaction.Commit(); err != nil {
 			return err
 		} else {
-			task.UpdatedAt = &timestamp
+			*task = stored
 			return nil
 		}
 	}
 }
 
-func (store *postgresStore) read(ctx context.Context, id uint) (*Task, error) {
+func (store *postgresStore) Patch(ctx context.Context, id uint, version uint, apply func(task *Task) error) (*Task, error) {
+	//-- Common variables ----------
+	var updatedVersion int
+	var timestamp = time.Now().UTC()
+	var query = queryMap[`updateTask`]
+
+	//-- Patch Transaction ----------
+	{
+		var scope = ScopeFrom(ctx)
+
+		if transaction, err := store.begin(ctx); err != nil {
+			return nil, err
+		} else if before, err := store.lock(transaction, scope, id, false); err != nil {
+			return nil, store.handleTransactionError(transaction, err)
+		} else if version != 0 && version != before.Version {
+			return nil, store.handleTransactionError(transaction, ErrVersionConflict)
+		} else if task, err := patched(*before, apply); err != nil {
+			return nil, store.handleTransactionError(transaction, err)
+		} else if len(diff(before, task)) == 0 {
+			if err := transaction.Commit(); err != nil {
+				return nil, err
+			}
+			return before, nil
+		} else if err := transaction.QueryRow(query, id, task.Name, task.Details, task.ResolvedAt, timestamp, int(before.Version), scope.TenantParameter()).Scan(&updatedVersion); err != nil {
+			return nil, store.handleTransactionError(transaction, err)
+		} else if err := store.record(transaction, newHistory(ctx, Updated, before, updated(*before, task, uint(updatedVersion), timestamp), timestamp)); err != nil {
+			return nil, store.handleTransactionError(transaction, err)
+		} else if err := transaction.Commit(); err != nil {
+			return nil, err
+		} else {
+			var result = updated(*before, task, uint(updatedVersion), timestamp)
+			return &result, nil
+		}
+	}
+}
+
+func (store *postgresStore) Read(ctx context.Context, id uint) (*Task, error) {
 	//-- Common variables ----------
 	var task = new(Task)
+	var scope = ScopeFrom(ctx)
 	var query = queryMap[`readTask`]
 
 	//-- Insert Transaction ----------
 	{
-		if transaction, err := store.database.BeginTx(ctx, nil); err != nil {
+		if transaction, err := store.begin(ctx); err != nil {
 			return nil, err
-		} else if err := transaction.QueryRow(query, id).Scan(&task.ID, &task.Name, &task.Details, &task.ResolvedAt, &task.CreatedAt, &task.UpdatedAt); err != nil {
+		} else if err := transaction.QueryRow(query, id, scope.OwnerParameter(), scope.TenantParameter()).Scan(taskFields(task)...); err != nil {
 			return nil, store.handleTransactionError(transaction, err)
 		} else if err := transaction.Commit(); err != nil {
 			return nil, err
@@ -213,16 +268,40 @@ func (store *postgresStore) read(ctx context.Context, id uint) (*Task, error) {
 	}
 }
 
-func (store *postgresStore) delete(ctx context.Context, id uint) (*Task, error) {
+func (store *postgresStore) Delete(ctx context.Context, id uint) (*Task, error) {
+	//-- Common variables ----------
+	var timestamp = time.Now().UTC()
+
+	//-- Delete Transaction ----------
+	{
+		if transaction, err := store.begin(ctx); err != nil {
+			return nil, err
+		} else if task, err := store.delete(ctx, transaction, id, timestamp); err != nil {
+			return nil, store.handleTransactionError(transaction, err)
+		} else if err := transaction.Commit(); err != nil {
+			return nil, err
+		} else {
+			return task, nil
+		}
+	}
+}
+
+func (store *postgresStore) Restore(ctx context.Context, id uint) (*Task, error) {
 	//-- Common variables ----------
 	var task = new(Task)
-	var query = queryMap[`deleteTask`]
+	var timestamp = time.Now().UTC()
+	var scope = ScopeFrom(ctx)
+	var query = queryMap[`restoreTask`]
 
-	//-- Insert Transaction ----------
+	//-- Restore Transaction ----------
 	{
-		if transaction, err := store.database.BeginTx(ctx, nil); err != nil {
+		if transaction, err := store.begin(ctx); err != nil {
 			return nil, err
-		} else if err := transaction.QueryRow(query, id).Scan(&task.ID, &task.Name, &task.Details, &task.ResolvedAt, &task.CreatedAt, &task.UpdatedAt); err != nil {
+		} else if before, err := store.lock(transaction, scope, id, true); err != nil {
+			return nil, store.handleTransactionError(transaction, err)
+		} else if err := transaction.QueryRow(query, id, scope.TenantParameter()).Scan(taskFields(task)...); err != nil {
+			return nil, store.handleTransactionError(transaction, err)
+		} else if err := store.record(transaction, newHistory(ctx, Restored, before, *task, timestamp)); err != nil {
 			return nil, store.handleTransactionError(transaction, err)
 		} else if err := transaction.Commit(); err != nil {
 			return nil, err
@@ -232,29 +311,323 @@ func (store *postgresStore) delete(ctx context.Context, id uint) (*Task, error)
 	}
 }
 
-func (store *postgresStore) list(ctx context.Context, limit uint, offset uint) ([]Task, error) {
+func (store *postgresStore) Purge(ctx context.Context, before time.Time) (uint, error) {
+	//-- Common variables ----------
+	var timestamp = time.Now().UTC()
+	var scope = ScopeFrom(ctx)
+	var query = queryMap[`purgeTasks`]
+
+	//-- Purge Transaction ----------
+	{
+		if transaction, err := store.begin(ctx); err != nil {
+			return 0, err
+		} else if result, err := transaction.Exec(query, before.UTC(), Purged, ActorFrom(ctx), timestamp, scope.OwnerParameter(), scope.TenantParameter()); err != nil {
+			return 0, store.handleTransactionError(transaction, err)
+		} else if purged, err := result.RowsAffected(); err != nil {
+			return 0, store.handleTransactionError(transaction, err)
+		} else if err := transaction.Commit(); err != nil {
+			return 0, err
+		} else {
+			return uint(purged), nil
+		}
+	}
+}
+
+func (store *postgresStore) BulkCreate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error) {
+	//-- Common variables ----------
+	var timestamp = time.Now().UTC()
+
+	//-- Batch Transaction ----------
+	return store.batch(ctx, len(tasks), mode, func(transaction *tracedTx, index int) (*Task, error) {
+		var task = cloneTask(*tasks[index])
+
+		if err := prepareInsert(ctx, &task); err != nil {
+			return nil, err
+		} else if stored, err := store.insert(ctx, transaction, task, timestamp); err != nil {
+			return nil, err
+		} else {
+			return &stored, nil
+		}
+	})
+}
+
+func (store *postgresStore) BulkUpdate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error) {
+	//-- Common variables ----------
+	var timestamp = time.Now().UTC()
+
+	//-- Batch Transaction ----------
+	return store.batch(ctx, len(tasks), mode, func(transaction *tracedTx, index int) (*Task, error) {
+		var task = cloneTask(*tasks[index])
+
+		if err := task.Sanitize(); err != nil {
+			return nil, err
+		} else if err := task.Validate(); err != nil {
+			return nil, err
+		} else if stored, err := store.update(ctx, transaction, task, timestamp); err != nil {
+			return nil, err
+		} else {
+			return &stored, nil
+		}
+	})
+}
+
+func (store *postgresStore) BulkDelete(ctx context.Context, ids []uint, mode BatchMode) ([]BatchResult, error) {
+	//-- Common variables ----------
+	var timestamp = time.Now().UTC()
+
+	//-- Batch Transaction ----------
+	return store.batch(ctx, len(ids), mode, func(transaction *tracedTx, index int) (*Task, error) {
+		return store.delete(ctx, transaction, ids[index], timestamp)
+	})
+}
+
+func (store *postgresStore) History(ctx context.Context, id uint, after uint, limit uint) ([]History, error) {
+	//-- Common variables ----------
+	var entries = make([]History, 0)
+	var scope = ScopeFrom(ctx)
+	var query = queryMap[`listHistory`]
+
+	//-- Query Transaction ----------
+	{
+		var transaction *tracedTx
+		var results *sql.Rows
+
+		if begun, err := store.begin(ctx); err != nil {
+			return nil, err
+		} else {
+			transaction = begun
+		}
+
+		if rows, err := transaction.Query(query, id, after, limit, scope.OwnerParameter(), scope.TenantParameter()); err != nil {
+			return nil, store.handleTransactionError(transaction, err)
+		} else {
+			results = rows
+		}
+
+		var resultsScanError error
+		for results.Next() {
+			if entry, err := scanHistory(results); err != nil {
+				resultsScanError = err
+				break
+			} else {
+				entries = append(entries, *entry)
+			}
+		}
+
+		if err := results.Close(); err != nil {
+			return nil, store.handleTransactionError(transaction, err)
+		} else if resultsScanError != nil {
+			return nil, store.handleTransactionError(transaction, resultsScanError)
+		} else if err := transaction.Commit(); err != nil {
+			return nil, err
+		}
+
+		return entries, nil
+	}
+}
+
+func (store *postgresStore) List(ctx context.Context, query Query, limit uint, offset uint) ([]Task, error) {
+	//-- Parameter checking ----------
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	//-- Build statement ----------
+	var statement = newStatement(ScopeFrom(ctx), query.Filter)
+	var text = fmt.Sprintf(queryMap[`listTasks`], statement.where(), orderBy(query.Sort, false), statement.bind(limit), statement.bind(offset))
+
+	return store.queryTasks(ctx, text, statement.parameters...)
+}
+
+func (store *postgresStore) Seek(ctx context.Context, query Query, cursor *Cursor, limit uint) ([]Task, error) {
+	//-- Common variables ----------
+	var text string
+
+	//-- Parameter checking ----------
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	//-- Build statement ----------
+	var statement = newStatement(ScopeFrom(ctx), query.Filter)
+
+	if cursor != nil {
+		statement.seek(query.Sort, *cursor)
+	}
+
+	if cursor != nil && cursor.Backward {
+		text = fmt.Sprintf(queryMap[`seekTasksBefore`], statement.where(), orderBy(query.Sort, true), statement.bind(limit), orderBy(query.Sort, false))
+	} else {
+		text = fmt.Sprintf(queryMap[`seekTasks`], statement.where(), orderBy(query.Sort, false), statement.bind(limit))
+	}
+
+	return store.queryTasks(ctx, text, statement.parameters...)
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// begin starts a traced transaction which row level security confines to the tenant of the ScopeFrom ctx, a second line
+// of defence behind the tenant condition of every statement
+func (store *postgresStore) begin(ctx context.Context) (*tracedTx, error) {
+	var transaction *tracedTx
+
+	if begun, err := store.database.BeginTx(ctx, nil); err != nil {
+		return nil, err
+	} else {
+		transaction = &tracedTx{Tx: begun, ctx: ctx}
+	}
+
+	if _, err := transaction.Exec(queryMap[`scopeTenant`], ScopeFrom(ctx).Tenant); err != nil {
+		return nil, store.handleTransactionError(transaction, err)
+	}
+
+	return transaction, nil
+}
+
+func (transaction *tracedTx) QueryRow(query string, parameters ...interface{}) *sql.Row {
+	var ctx, span = startStatement(transaction.ctx, query)
+	defer span.End()
+
+	var row = transaction.Tx.QueryRowContext(ctx, query, parameters...)
+	span.RecordError(row.Err())
+
+	return row
+}
+
+func (transaction *tracedTx) Query(query string, parameters ...interface{}) (*sql.Rows, error) {
+	var ctx, span = startStatement(transaction.ctx, query)
+	defer span.End()
+
+	var rows, err = transaction.Tx.QueryContext(ctx, query, parameters...)
+	span.RecordError(err)
+
+	return rows, err
+}
+
+func (transaction *tracedTx) Exec(query string, parameters ...interface{}) (sql.Result, error) {
+	var ctx, span = startStatement(transaction.ctx, query)
+	defer span.End()
+
+	var result, err = transaction.Tx.ExecContext(ctx, query, parameters...)
+	if err != nil {
+		span.RecordError(err)
+	} else if affected, err := result.RowsAffected(); err == nil {
+		span.SetAttribute(`db.rows_affected`, affected)
+	}
+
+	return result, err
+}
+
+func (transaction *tracedTx) Commit() error {
+	var _, span = startStatement(transaction.ctx, `COMMIT`)
+	defer span.End()
+
+	var err = transaction.Tx.Commit()
+	span.RecordError(err)
+
+	return err
+}
+
+func (transaction *tracedTx) Rollback() error {
+	var _, span = startStatement(transaction.ctx, `ROLLBACK`)
+	defer span.End()
+
+	var err = transaction.Tx.Rollback()
+	span.RecordError(err)
+
+	return err
+}
+
+// startStatement begins a client span for query named by its SQL verb and table, such as `SELECT tasks`, which keeps
+// the span names few while the full text is kept in the db.statement attribute
+func startStatement(ctx context.Context, query string) (context.Context, *tracing.Span) {
+	var name = strings.ToUpper(strings.Fields(query)[0])
+
+	if match := statementTable.FindStringSubmatch(query); match != nil {
+		name = name + ` ` + match[1]
+	}
+
+	var traced, span = tracing.Start(ctx, name, tracing.KindClient)
+	span.SetAttribute(`db.system`, `postgresql`)
+	span.SetAttribute(`db.statement`, query)
+
+	return traced, span
+}
+
+func (store *postgresStore) handleTransactionError(transaction *tracedTx, original error) error {
+	if err := transaction.Rollback(); err != nil {
+		return errors.New(fmt.Sprintf(`an unrecoverable exception has occured rolling back the transaction (%s) - > (%s)`, original, err))
+	}
+
+	return original
+}
+
+func (store *postgresStore) up(migrationPath string) error {
+	if driver, err := postgres.WithInstance(store.database, &postgres.Config{}); err != nil {
+		return err
+	} else if migration, err := migrate.NewWithDatabaseInstance(migrationPath, `postgres`, driver); err != nil {
+		return err
+	} else if err := migration.Up(); err != nil && err != migrate.ErrNoChange {
+		return err
+	}
+	return nil
+}
+
+func (store *postgresStore) down(migrationPath string) error {
+	if driver, err := postgres.WithInstance(store.database, &postgres.Config{}); err != nil {
+		return err
+	} else if migration, err := migrate.NewWithDatabaseInstance(migrationPath, `postgres`, driver); err != nil {
+		return err
+	} else if err := migration.Down(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (store *postgresStore) drop(migrationPath string) error {
+	if driver, err := postgres.WithInstance(store.database, &postgres.Config{}); err != nil {
+		return err
+	} else if migration, err := migrate.NewWithDatabaseInstance(migrationPath, `postgres`, driver); err != nil {
+		return err
+	} else if err := migration.Drop(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (store *postgresStore) version(migrationPath string) (uint, bool, error) {
+	if driver, err := postgres.WithInstance(store.database, &postgres.Config{}); err != nil {
+		return 0, false, err
+	} else if migration, err := migrate.NewWithDatabaseInstance(migrationPath, `postgres`, driver); err != nil {
+		return 0, false, err
+	} else if ver, dirty, err := migration.Version(); err != nil && err != migrate.ErrNoChange {
+		return 0, false, err
+	} else {
+		return ver, dirty, nil
+	}
+}
+
+func (store *postgresStore) queryTasks(ctx context.Context, query string, parameters ...interface{}) ([]Task, error) {
 	//-- Common variables ----------
 	var tasks = make([]Task, 0)
-	var query = queryMap[`listTasks`]
 
-	//-- Insert Transaction ----------
+	//-- Query Transaction ----------
 	{
-		var transaction *sql.Tx
-		if t, err := store.database.BeginTx(ctx, nil); err != nil {
+		var transaction *tracedTx
+		if t, err := store.begin(ctx); err != nil {
 			return nil, err
 		} else {
 			transaction = t
 		}
 
-		var results, err = transaction.Query(query, limit, offset)
+		var results, err = transaction.Query(query, parameters...)
 		if err != nil {
-			return tasks, err
+			return nil, store.handleTransactionError(transaction, err)
 		}
 
 		var resultsScanError error
 		for results.Next() {
 			var task = new(Task)
-			if err := results.Scan(&task.ID, &task.Name, &task.Details, &task.ResolvedAt, &task.CreatedAt, &task.UpdatedAt); err != nil {
+			if err := results.Scan(taskFields(task)...); err != nil {
 				resultsScanError = err
 				break
 			}
@@ -262,13 +635,312 @@ func (store *postgresStore) list(ctx context.Context, limit uint, offset uint) (
 		}
 
 		if err := results.Close(); err != nil {
-			return nil, err
-		} else if err := transaction.Commit(); err != nil {
-			return nil, err
+			return nil, store.handleTransactionError(transaction, err)
 		} else if resultsScanError != nil {
 			return nil, store.handleTransactionError(transaction, resultsScanError)
+		} else if err := transaction.Commit(); err != nil {
+			return nil, err
 		}
 
 		return tasks, nil
 	}
 }
+
+// batch runs item for each of the size items of a batch within a single transaction. In PerItem mode every item runs
+// within a savepoint which a failure rolls back to, leaving the items before it in place, while in Atomic mode the
+// first failure rolls back the whole transaction.
+func (store *postgresStore) batch(ctx context.Context, size int, mode BatchMode, item func(transaction *tracedTx, index int) (*Task, error)) ([]BatchResult, error) {
+	var results = make([]BatchResult, size)
+
+	var transaction, err = store.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range results {
+		if mode != PerItem {
+			if task, err := item(transaction, index); err != nil {
+				return aborted(size, index, store.handleTransactionError(transaction, err)), nil
+			} else {
+				results[index] = BatchResult{Index: index, Task: task}
+			}
+		} else if _, err := transaction.Exec(queryMap[`savepointItem`]); err != nil {
+			return nil, store.handleTransactionError(transaction, err)
+		} else if task, err := item(transaction, index); err != nil {
+			if _, err := transaction.Exec(queryMap[`rollbackItem`]); err != nil {
+				return nil, store.handleTransactionError(transaction, err)
+			}
+			results[index] = BatchResult{Index: index, Err: err}
+		} else if _, err := transaction.Exec(queryMap[`releaseItem`]); err != nil {
+			return nil, store.handleTransactionError(transaction, err)
+		} else {
+			results[index] = BatchResult{Index: index, Task: task}
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// insert writes task, which has been prepared by prepareInsert, as part of transaction and returns it as it was stored
+func (store *postgresStore) insert(ctx context.Context, transaction *tracedTx, task Task, timestamp time.Time) (Task, error) {
+	var id, version int
+
+	if err := transaction.QueryRow(queryMap[`insertTask`], task.Name, task.Details, task.ResolvedAt, timestamp, task.OwnerID, task.TenantID).Scan(&id, &version); err != nil {
+		return Task{}, err
+	}
+
+	var stored = inserted(task, uint(id), uint(version), timestamp)
+	if err := store.record(transaction, newHistory(ctx, Created, nil, stored, timestamp)); err != nil {
+		return Task{}, err
+	}
+
+	return stored, nil
+}
+
+// update writes the user variables of task, which has been sanitized and validated, over the stored Task as part of
+// transaction and returns it as it was stored
+func (store *postgresStore) update(ctx context.Context, transaction *tracedTx, task Task, timestamp time.Time) (Task, error) {
+	var version int
+	var scope = ScopeFrom(ctx)
+
+	if before, err := store.lock(transaction, scope, task.ID, false); err != nil {
+		return Task{}, err
+	} else if task.Version != 0 && task.Version != before.Version {
+		return Task{}, ErrVersionConflict
+	} else if err := transaction.QueryRow(queryMap[`updateTask`], task.ID, task.Name, task.Details, task.ResolvedAt, timestamp, int(task.Version), scope.TenantParameter()).Scan(&version); err != nil {
+		return Task{}, err
+	} else {
+		var stored = updated(*before, task, uint(version), timestamp)
+		if err := store.record(transaction, newHistory(ctx, Updated, before, stored, timestamp)); err != nil {
+			return Task{}, err
+		}
+
+		return stored, nil
+	}
+}
+
+// delete moves the Task with id to the trash as part of transaction and returns it
+func (store *postgresStore) delete(ctx context.Context, transaction *tracedTx, id uint, timestamp time.Time) (*Task, error) {
+	var task = new(Task)
+	var scope = ScopeFrom(ctx)
+
+	if before, err := store.lock(transaction, scope, id, false); err != nil {
+		return nil, err
+	} else if err := transaction.QueryRow(queryMap[`deleteTask`], id, timestamp, scope.TenantParameter()).Scan(taskFields(task)...); err != nil {
+		return nil, err
+	} else if err := store.record(transaction, newHistory(ctx, Deleted, before, *task, timestamp)); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// lock reads the Task with id and holds its row until the transaction ends, so a change is diffed against the row it
+// replaces. A Task whose deletion state is not the one expected, or which lies outside scope, is reported as not found.
+func (store *postgresStore) lock(transaction *tracedTx, scope Scope, id uint, deleted bool) (*Task, error) {
+	var task = new(Task)
+
+	if err := transaction.QueryRow(queryMap[`lockTask`], id, scope.TenantParameter()).Scan(taskFields(task)...); err != nil {
+		return nil, err
+	} else if (task.DeletedAt != nil) != deleted || !scope.Allows(*task) {
+		return nil, sql.ErrNoRows
+	}
+
+	return task, nil
+}
+
+// record writes history into the audit trail as part of transaction
+func (store *postgresStore) record(transaction *tracedTx, history History) error {
+	if changes, err := json.Marshal(history.Changes); err != nil {
+		return err
+	} else if _, err := transaction.Exec(queryMap[`insertHistory`], history.TaskID, history.OwnerID, history.TenantID, history.Action, history.Actor, history.Version, string(changes), history.CreatedAt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (store *postgresStore) limit(parameters ConnectionParameters) {
+	if parameters.MaxOpenConnections > 0 {
+		store.database.SetMaxOpenConns(parameters.MaxOpenConnections)
+	}
+	if parameters.MaxIdleConnections > 0 {
+		store.database.SetMaxIdleConns(parameters.MaxIdleConnections)
+	}
+	if parameters.ConnectionMaxLifetime > 0 {
+		store.database.SetConnMaxLifetime(parameters.ConnectionMaxLifetime)
+	}
+}
+
+// taskFields lists the destinations for a row selected with taskColumns
+func taskFields(task *Task) []interface{} {
+	return []interface{}{&task.ID, &task.Name, &task.Details, &task.ResolvedAt, &task.CreatedAt, &task.UpdatedAt, &task.Version, &task.DeletedAt, &task.OwnerID, &task.TenantID}
+}
+
+// scanHistory reads a row selected with historyColumns
+func scanHistory(results *sql.Rows) (*History, error) {
+	var entry = new(History)
+	var changes []byte
+
+	if err := results.Scan(&entry.ID, &entry.TaskID, &entry.OwnerID, &entry.TenantID, &entry.Action, &entry.Actor, &entry.Version, &changes, &entry.CreatedAt); err != nil {
+		return nil, err
+	} else if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// prepareInsert confines task to the tenant of ctx and sanitizes and validates it, a Task which already has an ID is
+// refused
+func prepareInsert(ctx context.Context, task *Task) error {
+	if task.ID != 0 {
+		return ErrIllAdvisedInsert
+	}
+
+	task.TenantID = TenantFrom(ctx)
+
+	if err := task.Sanitize(); err != nil {
+		return err
+	} else if err := task.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// inserted is task as it was stored by Insert
+func inserted(task Task, id uint, version uint, timestamp time.Time) Task {
+	task.ID = id
+	task.CreatedAt = timestamp
+	task.UpdatedAt = nil
+	task.Version = version
+	return task
+}
+
+// updated is before with the user variables of task applied as they were stored by Update
+func updated(before Task, task Task, version uint, timestamp time.Time) Task {
+	before.Name = task.Name
+	before.Details = task.Details
+	before.ResolvedAt = task.ResolvedAt
+	before.UpdatedAt = &timestamp
+	before.Version = version
+	return before
+}
+
+// patched is before with the user variables apply changed on a copy of it, sanitized and validated as Update would
+func patched(before Task, apply func(task *Task) error) (Task, error) {
+	var changed = cloneTask(before)
+	var task = cloneTask(before)
+
+	if err := apply(&changed); err != nil {
+		return Task{}, err
+	}
+
+	task.Name = changed.Name
+	task.Details = changed.Details
+	task.ResolvedAt = changed.ResolvedAt
+
+	if err := task.Sanitize(); err != nil {
+		return Task{}, err
+	} else if err := task.Validate(); err != nil {
+		return Task{}, err
+	}
+
+	return task, nil
+}
+
+// newStatement confines the statement to scope before applying filter
+func newStatement(scope Scope, filter Filter) *statement {
+	var statement = new(statement)
+
+	if len(scope.Tenant) > 0 {
+		statement.conditions = append(statement.conditions, fmt.Sprintf(queryMap[`filterTenant`], statement.bind(scope.Tenant)))
+	}
+
+	if scope.Confined {
+		statement.conditions = append(statement.conditions, fmt.Sprintf(queryMap[`filterOwner`], statement.bind(scope.Owner)))
+	}
+
+	switch filter.deletion() {
+	case ExcludeDeleted:
+		statement.conditions = append(statement.conditions, queryMap[`filterLive`])
+	case OnlyDeleted:
+		statement.conditions = append(statement.conditions, queryMap[`filterDeleted`])
+	}
+
+	if filter.Resolved != nil && *filter.Resolved {
+		statement.conditions = append(statement.conditions, queryMap[`filterResolved`])
+	} else if filter.Resolved != nil {
+		statement.conditions = append(statement.conditions, queryMap[`filterUnresolved`])
+	}
+
+	if len(filter.Name) > 0 {
+		statement.conditions = append(statement.conditions, fmt.Sprintf(queryMap[`filterName`], statement.bind(filter.Name)))
+	}
+
+	if len(filter.Owner) > 0 {
+		statement.conditions = append(statement.conditions, fmt.Sprintf(queryMap[`filterOwner`], statement.bind(filter.Owner)))
+	}
+
+	statement.between(`created_at`, filter.CreatedAt)
+	statement.between(`updated_at`, filter.UpdatedAt)
+	statement.between(`resolved_at`, filter.ResolvedAt)
+
+	return statement
+}
+
+func (statement *statement) bind(value interface{}) string {
+	statement.parameters = append(statement.parameters, value)
+	return fmt.Sprintf(`$%d`, len(statement.parameters))
+}
+
+func (statement *statement) between(column string, bounds TimeRange) {
+	if bounds.From != nil {
+		statement.conditions = append(statement.conditions, fmt.Sprintf(queryMap[`filterFrom`], column, statement.bind(*bounds.From)))
+	}
+	if bounds.To != nil {
+		statement.conditions = append(statement.conditions, fmt.Sprintf(queryMap[`filterTo`], column, statement.bind(*bounds.To)))
+	}
+}
+
+func (statement *statement) seek(sort Sort, cursor Cursor) {
+	var condition = queryMap[`filterAfter`]
+	if cursor.Backward != (sort.direction() == Descending) {
+		condition = queryMap[`filterBefore`]
+	}
+
+	var value string
+	if sort.field() == SortByID {
+		value = statement.bind(cursor.ID)
+	} else {
+		value = fmt.Sprintf(queryMap[`cursorValue`], statement.bind(cursor.Value))
+	}
+
+	statement.conditions = append(statement.conditions, fmt.Sprintf(condition, sortColumns[sort.field()], value, statement.bind(cursor.ID)))
+}
+
+func (statement *statement) where() string {
+	if len(statement.conditions) == 0 {
+		return queryMap[`filterNone`]
+	}
+	return strings.Join(statement.conditions, ` AND `)
+}
+
+func orderBy(sort Sort, reverse bool) string {
+	var direction = sort.direction()
+	if reverse && direction == Descending {
+		direction = Ascending
+	} else if reverse {
+		direction = Descending
+	}
+
+	if sort.field() == SortByID {
+		return fmt.Sprintf(`id %s`, sortOrders[direction])
+	}
+	return fmt.Sprintf(`%s %s, id %s`, sortColumns[sort.field()], sortOrders[direction], sortOrders[direction])
+}
@@ -3,11 +3,8 @@ package task
 
 //-- Imports -----------------------------------------------------------------------------------------------------------
 import (
-	"context"
-	"fmt"
 	"os"
 	"testing"
-	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database"
@@ -458,579 +455,3 @@ func TestStoreVersionBadPath(test *testing.T) {
 	//-- Post-conditions ----------
 	assert.NotNil(test, migrationErr)
 }
-
-func TestStoreInsert(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var model *Task
-	var store Store
-	var insertErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing valid model store insert`
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	model = newValidTask()
-	model.Name = name
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	//-- Action ----------
-	insertErr = store.(*postgresStore).insert(ctx, model)
-
-	//-- Post-conditions ----------
-	assert.Nil(test, insertErr)
-	assert.NotEqual(test, uint(0), model.ID)
-	assert.NotEqual(test, time.Time{}, model.CreatedAt.Unix())
-	assert.Nil(test, model.UpdatedAt)
-}
-
-func TestStoreInsertInvalid(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var model *Task
-	var store Store
-	var insertErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing invalid model store insert ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~`
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	model = newValidTask()
-	model.Name = name
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	//-- Action ----------
-	insertErr = store.(*postgresStore).insert(ctx, model)
-
-	//-- Post-conditions ----------
-	assert.NotNil(test, insertErr)
-	assert.Equal(test, uint(0), model.ID)
-	assert.Equal(test, time.Time{}, model.CreatedAt)
-}
-
-func TestStoreInsertIllAdvised(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var model *Task
-	var store Store
-	var insertErr error
-
-	//-- Test Parameters ----------
-	var id uint = 1
-	var name = `Testing zero-value ID model store insert`
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	model = newValidTask()
-	model.ID = id
-	model.Name = name
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	//-- Action ----------
-	insertErr = store.(*postgresStore).insert(ctx, model)
-
-	//-- Post-conditions ----------
-	assert.NotNil(test, insertErr)
-	assert.Equal(test, time.Time{}, model.CreatedAt)
-}
-
-func TestStoreInsertDuplicate(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var model, duplicateTask *Task
-	var store Store
-	var insertErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing inadvisable model store insert`
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	model = newValidTask()
-	model.Name = name
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	if err := store.(*postgresStore).insert(ctx, model); err != nil {
-		test.Fatalf(`unexpected error when inserting record: %s`, err)
-	}
-
-	//-- Action ----------
-	duplicateTask = new(Task)
-	*duplicateTask = *model
-
-	insertErr = store.(*postgresStore).insert(ctx, model)
-
-	//-- Post-conditions ----------
-	assert.NotNil(test, insertErr)
-	assert.True(test, model.compare(*duplicateTask))
-}
-
-func TestStoreUpdate(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var model, udpatedTask *Task
-	var store Store
-	var updateErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing valid update`
-	var updatedName = `Testing valid name update`
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	model = newValidTask()
-	model.Name = name
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	if err := store.(*postgresStore).insert(ctx, model); err != nil {
-		test.Fatalf(`unexpected error when inserting record: %s`, err)
-	}
-
-	//-- Action ----------
-	udpatedTask = new(Task)
-	*udpatedTask = *model
-	udpatedTask.Name = updatedName
-
-	updateErr = store.(*postgresStore).update(ctx, udpatedTask)
-
-	//-- Post-conditions ----------
-	assert.Nil(test, updateErr)
-	assert.Equal(test, model.ID, udpatedTask.ID)
-	assert.NotEqual(test, model.Name, udpatedTask.Name)
-	assert.NotNil(test, udpatedTask.UpdatedAt)
-	assert.Nil(test, model.UpdatedAt)
-}
-
-func TestStoreUpdateInvalid(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var model *Task
-	var store Store
-	var updateErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing valid update`
-	var updatedName = `Testing valid name update ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~`
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	model = newValidTask()
-	model.Name = name
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	if err := store.(*postgresStore).insert(ctx, model); err != nil {
-		test.Fatalf(`unexpected error when inserting record: %s`, err)
-	}
-
-	//-- Action ----------
-	model.Name = updatedName
-
-	updateErr = store.(*postgresStore).update(ctx, model)
-
-	//-- Post-conditions ----------
-	assert.NotNil(test, updateErr)
-	assert.Nil(test, model.UpdatedAt)
-	assert.Nil(test, model.UpdatedAt)
-}
-
-func TestStoreUpdateNotFound(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var model *Task
-	var store Store
-	var updateErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing not-found update`
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	model = newValidTask()
-	model.Name = name
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	//-- Action ----------
-	updateErr = store.(*postgresStore).update(ctx, model)
-
-	//-- Post-conditions ----------
-	assert.NotNil(test, updateErr)
-	assert.Nil(test, model.UpdatedAt)
-}
-
-func TestStoreRead(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var model, readTask *Task
-	var store Store
-	var readErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing valid read`
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	model = newValidTask()
-	model.Name = name
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	if err := store.(*postgresStore).insert(ctx, model); err != nil {
-		test.Fatalf(`unexpected error when inserting record: %s`, err)
-	}
-
-	//-- Action ----------
-	readTask, readErr = store.(*postgresStore).read(ctx, model.ID)
-
-	//-- Post-conditions ----------
-	assert.Nil(test, readErr)
-	assert.True(test, model.compare(*readTask))
-}
-
-func TestStoreReadNotFound(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var model, readTask *Task
-	var store Store
-	var readErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing not-found read`
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	model = newValidTask()
-	model.Name = name
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	//-- Action ----------
-	readTask, readErr = store.(*postgresStore).read(ctx, uint(0))
-
-	//-- Post-conditions ----------
-	assert.NotNil(test, readErr)
-	assert.Nil(test, readTask)
-}
-
-func TestStoreDelete(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var model, deleteTask *Task
-	var store Store
-	var deleteErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing valid delete`
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	model = newValidTask()
-	model.Name = name
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	if err := store.(*postgresStore).insert(ctx, model); err != nil {
-		test.Fatalf(`unexpected error when inserting record: %s`, err)
-	}
-
-	//-- Action ----------
-	deleteTask, deleteErr = store.(*postgresStore).delete(ctx, model.ID)
-
-	//-- Post-conditions ----------
-	assert.Nil(test, deleteErr)
-	assert.True(test, model.compare(*deleteTask))
-}
-
-func TestStoreDeleteNotFound(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var model, deleteTask *Task
-	var store Store
-	var deleteErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing no-found delete`
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	model = newValidTask()
-	model.Name = name
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	//-- Action ----------
-	deleteTask, deleteErr = store.(*postgresStore).delete(ctx, model.ID)
-
-	//-- Post-conditions ----------
-	assert.NotNil(test, deleteErr)
-	assert.Nil(test, deleteTask)
-}
-
-func TestStoreList(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var store Store
-	var listTasks []Task
-	var listErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing valid list`
-	var quantity = 10
-
-	var limit uint = 25
-	var offset uint = 0
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	for i := 0; i < quantity; i++ {
-		var model = newValidTask()
-		model.Name = fmt.Sprintf(`%s %d`, name, i)
-
-		if err := store.(*postgresStore).insert(ctx, model); err != nil {
-			test.Fatalf(`unexpected error when inserting record: %s`, err)
-		}
-	}
-
-	//-- Action ----------
-	listTasks, listErr = store.(*postgresStore).list(ctx, limit, offset)
-
-	//-- Post-conditions ----------
-	assert.Nil(test, listErr)
-	assert.Equal(test, quantity, len(listTasks))
-}
-
-func TestStoreListLimit(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var store Store
-	var listTasks []Task
-	var listErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing limit list`
-	var quantity = 10
-
-	var limit uint = 5
-	var offset uint = 0
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	for i := 0; i < quantity; i++ {
-		var model = newValidTask()
-		model.Name = fmt.Sprintf(`%s %d`, name, i)
-
-		if err := store.(*postgresStore).insert(ctx, model); err != nil {
-			test.Fatalf(`unexpected error when inserting record: %s`, err)
-		}
-	}
-
-	//-- Action ----------
-	listTasks, listErr = store.(*postgresStore).list(ctx, limit, offset)
-
-	//-- Post-conditions ----------
-	assert.Nil(test, listErr)
-	assert.Equal(test, 5, len(listTasks))
-}
-
-func TestStoreListZeroLimit(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var store Store
-	var listTasks []Task
-	var listErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing zero limit list`
-	var quantity = 10
-
-	var limit uint = 0
-	var offset uint = 0
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	for i := 0; i < quantity; i++ {
-		var model = newValidTask()
-		model.Name = fmt.Sprintf(`%s %d`, name, i)
-
-		if err := store.(*postgresStore).insert(ctx, model); err != nil {
-			test.Fatalf(`unexpected error when inserting record: %s`, err)
-		}
-	}
-
-	//-- Action ----------
-	listTasks, listErr = store.(*postgresStore).list(ctx, limit, offset)
-
-	//-- Post-conditions ----------
-	assert.Nil(test, listErr)
-	assert.Equal(test, 0, len(listTasks))
-}
-
-func TestStoreListOverLimit(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var store Store
-	var listTasks []Task
-	var listErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing over limit list`
-	var quantity = 10
-
-	var limit uint = 200
-	var offset uint = 0
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	for i := 0; i < quantity; i++ {
-		var model = newValidTask()
-		model.Name = fmt.Sprintf(`%s %d`, name, i)
-
-		if err := store.(*postgresStore).insert(ctx, model); err != nil {
-			test.Fatalf(`unexpected error when inserting record: %s`, err)
-		}
-	}
-
-	//-- Action ----------
-	listTasks, listErr = store.(*postgresStore).list(ctx, limit, offset)
-
-	//-- Post-conditions ----------
-	assert.Nil(test, listErr)
-	assert.Equal(test, 10, len(listTasks))
-}
-
-func TestStoreListOffset(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var store Store
-	var listTasks []Task
-	var listErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing offset list`
-	var quantity = 10
-
-	var limit uint = 10
-	var offset uint = 5
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	for i := 0; i < quantity; i++ {
-		var model = newValidTask()
-		model.Name = fmt.Sprintf(`%s %d`, name, i)
-
-		if err := store.(*postgresStore).insert(ctx, model); err != nil {
-			test.Fatalf(`unexpected error when inserting record: %s`, err)
-		}
-	}
-
-	//-- Action ----------
-	listTasks, listErr = store.(*postgresStore).list(ctx, limit, offset)
-
-	//-- Post-conditions ----------
-	assert.Nil(test, listErr)
-	assert.Equal(test, 5, len(listTasks))
-}
-
-func TestStoreListOverOffset(test *testing.T) {
-	//-- Shared Variables ----------
-	var ctx context.Context
-	var store Store
-	var listTasks []Task
-	var listErr error
-
-	//-- Test Parameters ----------
-	var name = `Testing over offset list`
-	var quantity = 10
-
-	var limit uint = 10
-	var offset uint = 10
-
-	//-- Pre-conditions ----------
-	ctx = context.Background()
-
-	store = openStore(test)
-	defer closeStore(test, store)
-	resetStore(test, store)
-
-	for i := 0; i < quantity; i++ {
-		var model = newValidTask()
-		model.Name = fmt.Sprintf(`%s %d`, name, i)
-
-		if err := store.(*postgresStore).insert(ctx, model); err != nil {
-			test.Fatalf(`unexpected error when inserting record: %s`, err)
-		}
-	}
-
-	//-- Action ----------
-	listTasks, listErr = store.(*postgresStore).list(ctx, limit, offset)
-
-	//-- Post-conditions ----------
-	assert.Nil(test, listErr)
-	assert.Equal(test, 0, len(listTasks))
-}
@@ -0,0 +1,2672 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package storetest provides a conformance suite which any task.Store implementation can run to prove it behaves the
+// same way as the stores shipped with the task service.
+package storetest
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/JustonDavies/go_serverless_api/pkg/services/task"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+var (
+	checks = []check{
+		{`Insert`, testInsert},
+		{`InsertTimestamps`, testInsertTimestamps},
+		{`InsertSanitize`, testInsertSanitize},
+		{`InsertInvalidName`, testInsertInvalidName},
+		{`InsertInvalidDetails`, testInsertInvalidDetails},
+		{`InsertIllAdvised`, testInsertIllAdvised},
+		{`InsertDuplicate`, testInsertDuplicate},
+		{`Update`, testUpdate},
+		{`UpdateTimestamps`, testUpdateTimestamps},
+		{`UpdateInvalid`, testUpdateInvalid},
+		{`UpdatePreDated`, testUpdatePreDated},
+		{`UpdateNotFound`, testUpdateNotFound},
+		{`UpdateStoredFields`, testUpdateStoredFields},
+		{`Patch`, testPatch},
+		{`PatchProtected`, testPatchProtected},
+		{`PatchUnchanged`, testPatchUnchanged},
+		{`PatchInvalid`, testPatchInvalid},
+		{`PatchAbandoned`, testPatchAbandoned},
+		{`PatchStaleVersion`, testPatchStaleVersion},
+		{`PatchNotFound`, testPatchNotFound},
+		{`Read`, testRead},
+		{`ReadNotFound`, testReadNotFound},
+		{`Delete`, testDelete},
+		{`DeleteNotFound`, testDeleteNotFound},
+		{`List`, testList},
+		{`ListEmpty`, testListEmpty},
+		{`ListOrder`, testListOrder},
+		{`ListLimit`, testListLimit},
+		{`ListZeroLimit`, testListZeroLimit},
+		{`ListOverLimit`, testListOverLimit},
+		{`ListOffset`, testListOffset},
+		{`ListOverOffset`, testListOverOffset},
+		{`ListAfterDelete`, testListAfterDelete},
+		{`SeekFirst`, testSeekFirst},
+		{`SeekAfter`, testSeekAfter},
+		{`SeekBefore`, testSeekBefore},
+		{`SeekZeroLimit`, testSeekZeroLimit},
+		{`SeekPastEnd`, testSeekPastEnd},
+		{`SeekDeletedCursor`, testSeekDeletedCursor},
+		{`ListFilterResolved`, testListFilterResolved},
+		{`ListFilterName`, testListFilterName},
+		{`ListFilterNameRejectsWildcards`, testListFilterNameRejectsWildcards},
+		{`ListFilterResolvedRange`, testListFilterResolvedRange},
+		{`ListFilterCreatedRange`, testListFilterCreatedRange},
+		{`ListSortDescending`, testListSortDescending},
+		{`ListSortResolvedAt`, testListSortResolvedAt},
+		{`ListInvalidQuery`, testListInvalidQuery},
+		{`SeekSorted`, testSeekSorted},
+		{`InsertVersion`, testInsertVersion},
+		{`UpdateVersion`, testUpdateVersion},
+		{`UpdateStaleVersion`, testUpdateStaleVersion},
+		{`UpdateVersionNotFound`, testUpdateVersionNotFound},
+		{`DeleteTwice`, testDeleteTwice},
+		{`UpdateDeleted`, testUpdateDeleted},
+		{`Restore`, testRestore},
+		{`RestoreNotDeleted`, testRestoreNotDeleted},
+		{`ListFilterDeleted`, testListFilterDeleted},
+		{`SeekOnlyDeleted`, testSeekOnlyDeleted},
+		{`Purge`, testPurge},
+		{`HistoryInsert`, testHistoryInsert},
+		{`HistoryUpdate`, testHistoryUpdate},
+		{`HistoryDeleteRestore`, testHistoryDeleteRestore},
+		{`HistoryFailedUpdate`, testHistoryFailedUpdate},
+		{`HistoryPurge`, testHistoryPurge},
+		{`HistoryPaging`, testHistoryPaging},
+		{`InsertOwner`, testInsertOwner},
+		{`ListFilterOwner`, testListFilterOwner},
+		{`ScopeRead`, testScopeRead},
+		{`ScopeChange`, testScopeChange},
+		{`ScopeList`, testScopeList},
+		{`ScopeHistory`, testScopeHistory},
+		{`ScopeAdmin`, testScopeAdmin},
+		{`InsertTenant`, testInsertTenant},
+		{`TenantRead`, testTenantRead},
+		{`TenantChange`, testTenantChange},
+		{`TenantList`, testTenantList},
+		{`TenantHistory`, testTenantHistory},
+		{`TenantPurge`, testTenantPurge},
+		{`BulkCreate`, testBulkCreate},
+		{`BulkCreateAtomic`, testBulkCreateAtomic},
+		{`BulkUpdate`, testBulkUpdate},
+		{`BulkUpdateAtomic`, testBulkUpdateAtomic},
+		{`BulkDelete`, testBulkDelete},
+		{`BulkDeleteAtomic`, testBulkDeleteAtomic},
+		{`TenantBulk`, testTenantBulk},
+	}
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// Factory returns an opened store with no existing Tasks. The suite calls it once per check and closes the store
+// itself when the check completes.
+type Factory func(test *testing.T) task.Store
+
+type check struct {
+	name string
+	run  func(test *testing.T, store task.Store)
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// Run executes every conformance check as a sub-test against a fresh store built by factory.
+func Run(test *testing.T, factory Factory) {
+	for _, item := range checks {
+		var item = item
+
+		test.Run(item.name, func(test *testing.T) {
+			var store = factory(test)
+			if store == nil {
+				test.Fatal(`the store factory returned a nil store`)
+			}
+
+			defer func() {
+				if err := store.Close(); err != nil {
+					test.Errorf(`an unexpected error occurred while closing the store: %s`, err)
+				}
+			}()
+
+			item.run(test, store)
+		})
+	}
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+func newValidTask(name string) *task.Task {
+	var details = `The default valid Task structure used in conformance testing`
+
+	return &task.Task{
+		Name:    name,
+		Details: &details,
+	}
+}
+
+func insertTask(test *testing.T, store task.Store, name string) *task.Task {
+	var model = newValidTask(name)
+
+	if err := store.Insert(context.Background(), model); err != nil {
+		test.Fatalf(`unexpected error when inserting record: %s`, err)
+	}
+
+	return model
+}
+
+func insertTasks(test *testing.T, store task.Store, name string, quantity int) []*task.Task {
+	var models = make([]*task.Task, 0, quantity)
+
+	for i := 0; i < quantity; i++ {
+		models = append(models, insertTask(test, store, fmt.Sprintf(`%s %d`, name, i)))
+	}
+
+	return models
+}
+
+func insertResolvedTasks(test *testing.T, store task.Store, name string, resolutions []*time.Time) []*task.Task {
+	var models = make([]*task.Task, 0, len(resolutions))
+
+	for index, resolvedAt := range resolutions {
+		var model = newValidTask(fmt.Sprintf(`%s %d`, name, index))
+		model.ResolvedAt = resolvedAt
+
+		if err := store.Insert(context.Background(), model); err != nil {
+			test.Fatalf(`unexpected error when inserting record: %s`, err)
+		}
+
+		models = append(models, model)
+	}
+
+	return models
+}
+
+func hoursAfter(base time.Time, hours int) *time.Time {
+	var timestamp = base.Add(time.Duration(hours) * time.Hour)
+	return &timestamp
+}
+
+func ids(tasks []task.Task) []uint {
+	var output = make([]uint, 0, len(tasks))
+
+	for _, item := range tasks {
+		output = append(output, item.ID)
+	}
+
+	return output
+}
+
+func modelIDs(models ...*task.Task) []uint {
+	var output = make([]uint, 0, len(models))
+
+	for _, model := range models {
+		output = append(output, model.ID)
+	}
+
+	return output
+}
+
+func equal(expected task.Task, actual task.Task) bool {
+	return expected.ID == actual.ID &&
+		expected.Name == actual.Name &&
+		equalString(expected.Details, actual.Details) &&
+		equalTime(expected.ResolvedAt, actual.ResolvedAt) &&
+		expected.CreatedAt.Unix() == actual.CreatedAt.Unix() &&
+		equalTime(expected.UpdatedAt, actual.UpdatedAt) &&
+		equalTime(expected.DeletedAt, actual.DeletedAt) &&
+		expected.Version == actual.Version
+}
+
+func equalString(expected *string, actual *string) bool {
+	if expected == nil || actual == nil {
+		return expected == actual
+	}
+	return *expected == *actual
+}
+
+func equalTime(expected *time.Time, actual *time.Time) bool {
+	if expected == nil || actual == nil {
+		return expected == actual
+	}
+	return expected.Unix() == actual.Unix()
+}
+
+//-- Insert Checks -----------------------------------------------------------------------------------------------------
+func testInsert(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var insertErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing valid model store insert`
+
+	//-- Pre-conditions ----------
+	model = newValidTask(name)
+
+	//-- Action ----------
+	insertErr = store.Insert(context.Background(), model)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, insertErr)
+	assert.NotEqual(test, uint(0), model.ID)
+	assert.NotEqual(test, time.Time{}, model.CreatedAt)
+	assert.Nil(test, model.UpdatedAt)
+}
+
+func testInsertTimestamps(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, readTask *task.Task
+	var before, after int64
+
+	//-- Test Parameters ----------
+	var name = `Testing store insert timestamps`
+	var resolvedAt = time.Date(2019, 1, 1, 8, 0, 1, 0, time.FixedZone(`UTC-8`, -8*60*60))
+
+	//-- Pre-conditions ----------
+	model = newValidTask(name)
+	model.ResolvedAt = &resolvedAt
+
+	//-- Action ----------
+	before = time.Now().Unix()
+	if err := store.Insert(context.Background(), model); err != nil {
+		test.Fatalf(`unexpected error when inserting record: %s`, err)
+	}
+	after = time.Now().Unix()
+
+	readTask, _ = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.True(test, model.CreatedAt.Unix() >= before && model.CreatedAt.Unix() <= after)
+	assert.Equal(test, time.UTC, model.CreatedAt.Location())
+	assert.Equal(test, time.UTC, model.ResolvedAt.Location())
+	assert.Equal(test, resolvedAt.Unix(), model.ResolvedAt.Unix())
+
+	if assert.NotNil(test, readTask) {
+		assert.Equal(test, model.CreatedAt.Unix(), readTask.CreatedAt.Unix())
+		assert.Equal(test, resolvedAt.Unix(), readTask.ResolvedAt.Unix())
+		assert.Nil(test, readTask.UpdatedAt)
+	}
+}
+
+func testInsertSanitize(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, readTask *task.Task
+	var insertErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing sanitized store insert`
+	var details = ``
+
+	//-- Pre-conditions ----------
+	model = newValidTask(name)
+	model.Details = &details
+
+	//-- Action ----------
+	insertErr = store.Insert(context.Background(), model)
+	readTask, _ = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, insertErr)
+	assert.Nil(test, model.Details)
+
+	if assert.NotNil(test, readTask) {
+		assert.Nil(test, readTask.Details)
+	}
+}
+
+func testInsertInvalidName(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var insertErr error
+	var listTasks []task.Task
+
+	//-- Test Parameters ----------
+	var name = `Testing invalid model store insert ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~`
+
+	//-- Pre-conditions ----------
+	model = newValidTask(name)
+
+	//-- Action ----------
+	insertErr = store.Insert(context.Background(), model)
+	listTasks, _ = store.List(context.Background(), task.Query{}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, insertErr)
+	assert.Equal(test, uint(0), model.ID)
+	assert.Equal(test, time.Time{}, model.CreatedAt)
+	assert.Equal(test, 0, len(listTasks))
+}
+
+func testInsertInvalidDetails(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var insertErr error
+	var listTasks []task.Task
+
+	//-- Test Parameters ----------
+	var name = `Testing invalid details store insert`
+	var details = `Details may not contain punctuation like this!`
+
+	//-- Pre-conditions ----------
+	model = newValidTask(name)
+	model.Details = &details
+
+	//-- Action ----------
+	insertErr = store.Insert(context.Background(), model)
+	listTasks, _ = store.List(context.Background(), task.Query{}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, insertErr)
+	assert.Equal(test, uint(0), model.ID)
+	assert.Equal(test, 0, len(listTasks))
+}
+
+func testInsertIllAdvised(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var insertErr error
+
+	//-- Test Parameters ----------
+	var id uint = 1
+	var name = `Testing non-zero ID model store insert`
+
+	//-- Pre-conditions ----------
+	model = newValidTask(name)
+	model.ID = id
+
+	//-- Action ----------
+	insertErr = store.Insert(context.Background(), model)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, task.ErrIllAdvisedInsert, insertErr)
+	assert.Equal(test, time.Time{}, model.CreatedAt)
+}
+
+func testInsertDuplicate(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, duplicateTask *task.Task
+	var insertErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing duplicate model store insert`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	duplicateTask = new(task.Task)
+	*duplicateTask = *model
+
+	insertErr = store.Insert(context.Background(), model)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, task.ErrIllAdvisedInsert, insertErr)
+	assert.True(test, equal(*model, *duplicateTask))
+}
+
+//-- Update Checks -----------------------------------------------------------------------------------------------------
+func testUpdate(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, updatedTask, readTask *task.Task
+	var updateErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing valid update`
+	var updatedName = `Testing valid name update`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	updatedTask = new(task.Task)
+	*updatedTask = *model
+	updatedTask.Name = updatedName
+
+	updateErr = store.Update(context.Background(), updatedTask)
+	readTask, _ = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, updateErr)
+	assert.Equal(test, model.ID, updatedTask.ID)
+	assert.NotNil(test, updatedTask.UpdatedAt)
+	assert.Nil(test, model.UpdatedAt)
+
+	if assert.NotNil(test, readTask) {
+		assert.True(test, equal(*updatedTask, *readTask))
+	}
+}
+
+func testUpdateTimestamps(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, readTask *task.Task
+	var before, after int64
+
+	//-- Test Parameters ----------
+	var name = `Testing update timestamps`
+	var updatedName = `Testing updated timestamps`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	model.Name = updatedName
+
+	before = time.Now().Unix()
+	if err := store.Update(context.Background(), model); err != nil {
+		test.Fatalf(`unexpected error when updating record: %s`, err)
+	}
+	after = time.Now().Unix()
+
+	readTask, _ = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	if assert.NotNil(test, model.UpdatedAt) {
+		assert.True(test, model.UpdatedAt.Unix() >= before && model.UpdatedAt.Unix() <= after)
+		assert.True(test, model.UpdatedAt.Unix() >= model.CreatedAt.Unix())
+		assert.Equal(test, time.UTC, model.UpdatedAt.Location())
+	}
+
+	if assert.NotNil(test, readTask) && assert.NotNil(test, readTask.UpdatedAt) {
+		assert.Equal(test, model.CreatedAt.Unix(), readTask.CreatedAt.Unix())
+		assert.Equal(test, model.UpdatedAt.Unix(), readTask.UpdatedAt.Unix())
+	}
+}
+
+func testUpdateInvalid(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, readTask *task.Task
+	var updateErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing invalid update`
+	var updatedName = `Testing invalid name update ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	model.Name = updatedName
+
+	updateErr = store.Update(context.Background(), model)
+	readTask, _ = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, updateErr)
+	assert.Nil(test, model.UpdatedAt)
+
+	if assert.NotNil(test, readTask) {
+		assert.Equal(test, name, readTask.Name)
+		assert.Nil(test, readTask.UpdatedAt)
+	}
+}
+
+func testUpdatePreDated(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var updateErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing pre-dated update`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	var updatedAt = model.CreatedAt.Add(-time.Hour)
+	model.UpdatedAt = &updatedAt
+
+	updateErr = store.Update(context.Background(), model)
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, updateErr)
+}
+
+func testUpdateNotFound(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var updateErr error
+
+	//-- Test Parameters ----------
+	var id uint = 1
+	var name = `Testing not-found update`
+
+	//-- Pre-conditions ----------
+	model = newValidTask(name)
+	model.ID = id
+
+	//-- Action ----------
+	updateErr = store.Update(context.Background(), model)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, sql.ErrNoRows, updateErr)
+	assert.Nil(test, model.UpdatedAt)
+}
+
+func testUpdateStoredFields(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, updatedTask, readTask *task.Task
+	var updateErr error
+
+	//-- Test Parameters ----------
+	var owner = `conformance-stored-owner`
+	var updatedName = `Testing updated stored fields`
+
+	//-- Pre-conditions ----------
+	model = insertOwnedTask(test, store, `Testing update stored fields`, owner)
+
+	//-- Action ----------
+	updatedTask = &task.Task{ID: model.ID, Name: updatedName}
+
+	updateErr = store.Update(context.Background(), updatedTask)
+	readTask, _ = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, updateErr)
+	assert.Equal(test, model.CreatedAt.Unix(), updatedTask.CreatedAt.Unix())
+	assert.Equal(test, owner, updatedTask.OwnerID)
+
+	if assert.NotNil(test, readTask) {
+		assert.True(test, equal(*readTask, *updatedTask))
+		assert.Equal(test, readTask.OwnerID, updatedTask.OwnerID)
+	}
+}
+
+//-- Patch Checks ------------------------------------------------------------------------------------------------------
+func testPatch(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, patchedTask, readTask *task.Task
+	var entries []task.History
+	var patchErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing valid patch`
+	var details = `Testing details kept by a patch`
+	var resolvedAt = time.Now().UTC().Truncate(time.Second)
+
+	//-- Pre-conditions ----------
+	model = newValidTask(name)
+	model.Details = &details
+
+	if err := store.Insert(context.Background(), model); err != nil {
+		test.Fatalf(`unexpected error when inserting record: %s`, err)
+	}
+
+	//-- Action ----------
+	patchedTask, patchErr = store.Patch(context.Background(), model.ID, model.Version, func(subject *task.Task) error {
+		subject.ResolvedAt = &resolvedAt
+		return nil
+	})
+
+	readTask, _ = store.Read(context.Background(), model.ID)
+	entries = history(test, store, model.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, patchErr)
+
+	if assert.NotNil(test, patchedTask) {
+		assert.Equal(test, model.ID, patchedTask.ID)
+		assert.Equal(test, uint(2), patchedTask.Version)
+		assert.NotNil(test, patchedTask.UpdatedAt)
+		assert.True(test, equalString(&details, patchedTask.Details))
+		assert.True(test, equalTime(&resolvedAt, patchedTask.ResolvedAt))
+
+		if assert.NotNil(test, readTask) {
+			assert.True(test, equal(*patchedTask, *readTask))
+		}
+	}
+
+	if assert.Equal(test, 2, len(entries)) {
+		var fields = changes(entries[1])
+
+		assert.Equal(test, task.Updated, entries[1].Action)
+		assert.Equal(test, uint(2), entries[1].Version)
+		assert.Equal(test, 1, len(fields))
+		assert.Contains(test, fields, `resolved_at`)
+	}
+}
+
+func testPatchProtected(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, patchedTask *task.Task
+	var patchErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing protected patch`
+	var patchedName = `Testing protected patch renamed`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	patchedTask, patchErr = store.Patch(context.Background(), model.ID, 0, func(subject *task.Task) error {
+		subject.ID = model.ID + 100
+		subject.Name = patchedName
+		subject.OwnerID = `someone-else`
+		subject.CreatedAt = model.CreatedAt.Add(-time.Hour)
+		subject.Version = 10
+		return nil
+	})
+
+	//-- Post-conditions ----------
+	assert.Nil(test, patchErr)
+
+	if assert.NotNil(test, patchedTask) {
+		assert.Equal(test, model.ID, patchedTask.ID)
+		assert.Equal(test, patchedName, patchedTask.Name)
+		assert.Equal(test, model.OwnerID, patchedTask.OwnerID)
+		assert.Equal(test, model.CreatedAt.Unix(), patchedTask.CreatedAt.Unix())
+		assert.Equal(test, uint(2), patchedTask.Version)
+	}
+}
+
+func testPatchUnchanged(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, patchedTask, readTask *task.Task
+	var patchErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing unchanged patch`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	patchedTask, patchErr = store.Patch(context.Background(), model.ID, model.Version, func(subject *task.Task) error {
+		subject.Name = name
+		return nil
+	})
+
+	readTask, _ = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, patchErr)
+	assert.Equal(test, 1, len(history(test, store, model.ID)))
+
+	if assert.NotNil(test, patchedTask) {
+		assert.Equal(test, uint(1), patchedTask.Version)
+		assert.Nil(test, patchedTask.UpdatedAt)
+	}
+	if assert.NotNil(test, readTask) {
+		assert.Equal(test, uint(1), readTask.Version)
+		assert.Nil(test, readTask.UpdatedAt)
+	}
+}
+
+func testPatchInvalid(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, patchedTask, readTask *task.Task
+	var patchErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing invalid patch`
+	var patchedName = `Testing invalid patch ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	patchedTask, patchErr = store.Patch(context.Background(), model.ID, 0, func(subject *task.Task) error {
+		subject.Name = patchedName
+		return nil
+	})
+
+	readTask, _ = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, patchErr)
+	assert.Nil(test, patchedTask)
+
+	if assert.NotNil(test, readTask) {
+		assert.Equal(test, name, readTask.Name)
+		assert.Equal(test, uint(1), readTask.Version)
+		assert.Nil(test, readTask.UpdatedAt)
+	}
+}
+
+func testPatchAbandoned(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, readTask *task.Task
+	var patchErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing abandoned patch`
+	var abandonErr = errors.New(`patch abandoned`)
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	_, patchErr = store.Patch(context.Background(), model.ID, 0, func(subject *task.Task) error {
+		subject.Name = `Testing abandoned rename`
+		return abandonErr
+	})
+
+	readTask, _ = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, abandonErr, patchErr)
+	assert.Equal(test, 1, len(history(test, store, model.ID)))
+
+	if assert.NotNil(test, readTask) {
+		assert.Equal(test, name, readTask.Name)
+		assert.Equal(test, uint(1), readTask.Version)
+	}
+}
+
+func testPatchStaleVersion(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var applied bool
+	var patchErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing stale patch`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	_, patchErr = store.Patch(context.Background(), model.ID, model.Version+1, func(subject *task.Task) error {
+		applied = true
+		return nil
+	})
+
+	//-- Post-conditions ----------
+	assert.Equal(test, task.ErrVersionConflict, patchErr)
+	assert.False(test, applied)
+}
+
+func testPatchNotFound(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var missingErr, deletedErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing not-found patch`
+	var apply = func(subject *task.Task) error { return nil }
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+	deleteTasks(test, store, model)
+
+	//-- Action ----------
+	_, missingErr = store.Patch(context.Background(), model.ID+1000, 0, apply)
+	_, deletedErr = store.Patch(context.Background(), model.ID, 0, apply)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, sql.ErrNoRows, missingErr)
+	assert.Equal(test, sql.ErrNoRows, deletedErr)
+}
+
+//-- Read Checks -------------------------------------------------------------------------------------------------------
+func testRead(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, readTask *task.Task
+	var readErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing valid read`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	readTask, readErr = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, readErr)
+
+	if assert.NotNil(test, readTask) {
+		assert.True(test, equal(*model, *readTask))
+	}
+}
+
+func testReadNotFound(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var readTask *task.Task
+	var readErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	readTask, readErr = store.Read(context.Background(), uint(0))
+
+	//-- Post-conditions ----------
+	assert.Equal(test, sql.ErrNoRows, readErr)
+	assert.Nil(test, readTask)
+}
+
+//-- Delete Checks -----------------------------------------------------------------------------------------------------
+func testDelete(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, deleteTask *task.Task
+	var deleteErr, readErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing valid delete`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	deleteTask, deleteErr = store.Delete(context.Background(), model.ID)
+	_, readErr = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, deleteErr)
+	assert.Equal(test, sql.ErrNoRows, readErr)
+
+	if assert.NotNil(test, deleteTask) && assert.NotNil(test, deleteTask.DeletedAt) {
+		model.DeletedAt = deleteTask.DeletedAt
+		assert.True(test, equal(*model, *deleteTask))
+	}
+}
+
+func testDeleteNotFound(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var deleteTask *task.Task
+	var deleteErr error
+
+	//-- Test Parameters ----------
+	var id uint = 1
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	deleteTask, deleteErr = store.Delete(context.Background(), id)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, sql.ErrNoRows, deleteErr)
+	assert.Nil(test, deleteTask)
+}
+
+//-- List Checks -------------------------------------------------------------------------------------------------------
+func testList(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var listTasks []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing valid list`
+	var quantity = 10
+
+	var limit uint = 25
+	var offset uint = 0
+
+	//-- Pre-conditions ----------
+	insertTasks(test, store, name, quantity)
+
+	//-- Action ----------
+	listTasks, listErr = store.List(context.Background(), task.Query{}, limit, offset)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.Equal(test, quantity, len(listTasks))
+}
+
+func testListEmpty(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var listTasks []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var limit uint = 25
+	var offset uint = 0
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	listTasks, listErr = store.List(context.Background(), task.Query{}, limit, offset)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.NotNil(test, listTasks)
+	assert.Equal(test, 0, len(listTasks))
+}
+
+func testListOrder(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var listTasks []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing ordered list`
+	var quantity = 10
+
+	var limit uint = 25
+	var offset uint = 0
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, quantity)
+
+	//-- Action ----------
+	listTasks, listErr = store.List(context.Background(), task.Query{}, limit, offset)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+
+	if assert.Equal(test, quantity, len(listTasks)) {
+		for index, model := range models {
+			assert.True(test, equal(*model, listTasks[index]))
+		}
+	}
+}
+
+func testListLimit(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var listTasks []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing limit list`
+	var quantity = 10
+
+	var limit uint = 5
+	var offset uint = 0
+
+	//-- Pre-conditions ----------
+	insertTasks(test, store, name, quantity)
+
+	//-- Action ----------
+	listTasks, listErr = store.List(context.Background(), task.Query{}, limit, offset)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.Equal(test, 5, len(listTasks))
+}
+
+func testListZeroLimit(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var listTasks []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing zero limit list`
+	var quantity = 10
+
+	var limit uint = 0
+	var offset uint = 0
+
+	//-- Pre-conditions ----------
+	insertTasks(test, store, name, quantity)
+
+	//-- Action ----------
+	listTasks, listErr = store.List(context.Background(), task.Query{}, limit, offset)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.Equal(test, 0, len(listTasks))
+}
+
+func testListOverLimit(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var listTasks []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing over limit list`
+	var quantity = 10
+
+	var limit uint = 100
+	var offset uint = 0
+
+	//-- Pre-conditions ----------
+	insertTasks(test, store, name, quantity)
+
+	//-- Action ----------
+	listTasks, listErr = store.List(context.Background(), task.Query{}, limit, offset)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.Equal(test, 10, len(listTasks))
+}
+
+func testListOffset(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var listTasks []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing offset list`
+	var quantity = 10
+
+	var limit uint = 3
+	var offset uint = 5
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, quantity)
+
+	//-- Action ----------
+	listTasks, listErr = store.List(context.Background(), task.Query{}, limit, offset)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+
+	if assert.Equal(test, 3, len(listTasks)) {
+		assert.Equal(test, models[5].ID, listTasks[0].ID)
+		assert.Equal(test, models[7].ID, listTasks[2].ID)
+	}
+}
+
+func testListOverOffset(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var listTasks []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing over offset list`
+	var quantity = 10
+
+	var limit uint = 10
+	var offset uint = 10
+
+	//-- Pre-conditions ----------
+	insertTasks(test, store, name, quantity)
+
+	//-- Action ----------
+	listTasks, listErr = store.List(context.Background(), task.Query{}, limit, offset)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.Equal(test, 0, len(listTasks))
+}
+
+func testListAfterDelete(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var listTasks []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing list after delete`
+	var quantity = 5
+
+	var limit uint = 10
+	var offset uint = 0
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, quantity)
+
+	if _, err := store.Delete(context.Background(), models[2].ID); err != nil {
+		test.Fatalf(`unexpected error when deleting record: %s`, err)
+	}
+
+	//-- Action ----------
+	listTasks, listErr = store.List(context.Background(), task.Query{}, limit, offset)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+
+	if assert.Equal(test, quantity-1, len(listTasks)) {
+		assert.Equal(test, models[1].ID, listTasks[1].ID)
+		assert.Equal(test, models[3].ID, listTasks[2].ID)
+	}
+}
+
+//-- Seek Checks -------------------------------------------------------------------------------------------------------
+func testSeekFirst(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var seekTasks []task.Task
+	var seekErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing first seek`
+	var quantity = 10
+
+	var limit uint = 4
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, quantity)
+
+	//-- Action ----------
+	seekTasks, seekErr = store.Seek(context.Background(), task.Query{}, nil, limit)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, seekErr)
+
+	if assert.Equal(test, 4, len(seekTasks)) {
+		for index := range seekTasks {
+			assert.True(test, equal(*models[index], seekTasks[index]))
+		}
+	}
+}
+
+func testSeekAfter(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var seekTasks []task.Task
+	var seekErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing forward seek`
+	var quantity = 10
+
+	var limit uint = 3
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, quantity)
+
+	//-- Action ----------
+	seekTasks, seekErr = store.Seek(context.Background(), task.Query{}, &task.Cursor{ID: models[4].ID}, limit)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, seekErr)
+
+	if assert.Equal(test, 3, len(seekTasks)) {
+		assert.Equal(test, models[5].ID, seekTasks[0].ID)
+		assert.Equal(test, models[6].ID, seekTasks[1].ID)
+		assert.Equal(test, models[7].ID, seekTasks[2].ID)
+	}
+}
+
+func testSeekBefore(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var seekTasks []task.Task
+	var seekErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing backward seek`
+	var quantity = 10
+
+	var limit uint = 3
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, quantity)
+
+	//-- Action ----------
+	seekTasks, seekErr = store.Seek(context.Background(), task.Query{}, &task.Cursor{ID: models[4].ID, Backward: true}, limit)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, seekErr)
+
+	if assert.Equal(test, 3, len(seekTasks)) {
+		assert.Equal(test, models[1].ID, seekTasks[0].ID)
+		assert.Equal(test, models[2].ID, seekTasks[1].ID)
+		assert.Equal(test, models[3].ID, seekTasks[2].ID)
+	}
+}
+
+func testSeekZeroLimit(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var seekTasks []task.Task
+	var seekErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing zero limit seek`
+	var quantity = 5
+
+	var limit uint = 0
+
+	//-- Pre-conditions ----------
+	insertTasks(test, store, name, quantity)
+
+	//-- Action ----------
+	seekTasks, seekErr = store.Seek(context.Background(), task.Query{}, nil, limit)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, seekErr)
+	assert.NotNil(test, seekTasks)
+	assert.Equal(test, 0, len(seekTasks))
+}
+
+func testSeekPastEnd(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var afterTasks, beforeTasks []task.Task
+	var afterErr, beforeErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing past end seek`
+	var quantity = 5
+
+	var limit uint = 10
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, quantity)
+
+	//-- Action ----------
+	afterTasks, afterErr = store.Seek(context.Background(), task.Query{}, &task.Cursor{ID: models[quantity-1].ID}, limit)
+	beforeTasks, beforeErr = store.Seek(context.Background(), task.Query{}, &task.Cursor{ID: models[0].ID, Backward: true}, limit)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, afterErr)
+	assert.Nil(test, beforeErr)
+	assert.Equal(test, 0, len(afterTasks))
+	assert.Equal(test, 0, len(beforeTasks))
+}
+
+func testSeekDeletedCursor(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var seekTasks []task.Task
+	var seekErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing deleted cursor seek`
+	var quantity = 6
+
+	var limit uint = 2
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, quantity)
+
+	if _, err := store.Delete(context.Background(), models[2].ID); err != nil {
+		test.Fatalf(`unexpected error when deleting record: %s`, err)
+	}
+
+	//-- Action ----------
+	seekTasks, seekErr = store.Seek(context.Background(), task.Query{}, &task.Cursor{ID: models[2].ID}, limit)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, seekErr)
+
+	if assert.Equal(test, 2, len(seekTasks)) {
+		assert.Equal(test, models[3].ID, seekTasks[0].ID)
+		assert.Equal(test, models[4].ID, seekTasks[1].ID)
+	}
+}
+
+//-- Filter & Sort Checks ----------------------------------------------------------------------------------------------
+func testListFilterResolved(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var resolvedTasks, unresolvedTasks []task.Task
+	var resolvedErr, unresolvedErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing resolved filter`
+	var base = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var resolved, unresolved = true, false
+
+	//-- Pre-conditions ----------
+	models = insertResolvedTasks(test, store, name, []*time.Time{hoursAfter(base, 1), nil, hoursAfter(base, 2), nil, hoursAfter(base, 3)})
+
+	//-- Action ----------
+	resolvedTasks, resolvedErr = store.List(context.Background(), task.Query{Filter: task.Filter{Resolved: &resolved}}, 10, 0)
+	unresolvedTasks, unresolvedErr = store.List(context.Background(), task.Query{Filter: task.Filter{Resolved: &unresolved}}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, resolvedErr)
+	assert.Nil(test, unresolvedErr)
+	assert.Equal(test, modelIDs(models[0], models[2], models[4]), ids(resolvedTasks))
+	assert.Equal(test, modelIDs(models[1], models[3]), ids(unresolvedTasks))
+}
+
+func testListFilterName(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var alphaModels, betaModels []*task.Task
+	var listTasks []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var filter = task.Filter{Name: `bEtA`}
+
+	//-- Pre-conditions ----------
+	alphaModels = insertTasks(test, store, `Alpha task`, 3)
+	betaModels = insertTasks(test, store, `Beta task`, 2)
+
+	//-- Action ----------
+	listTasks, listErr = store.List(context.Background(), task.Query{Filter: filter}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.Equal(test, 3, len(alphaModels))
+	assert.Equal(test, modelIDs(betaModels...), ids(listTasks))
+}
+
+func testListFilterNameRejectsWildcards(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var listTasks = make(map[string][]task.Task)
+	var listErrs = make(map[string]error)
+
+	//-- Test Parameters ----------
+	var filters = []string{`%`, `_`, `G_mma`, `Gamma%task`, `Gamma\_task`}
+
+	//-- Pre-conditions ----------
+	insertTasks(test, store, `Gamma task`, 2)
+
+	//-- Action ----------
+	for _, name := range filters {
+		listTasks[name], listErrs[name] = store.List(context.Background(), task.Query{Filter: task.Filter{Name: name}}, 10, 0)
+	}
+
+	//-- Post-conditions ----------
+	for _, name := range filters {
+		assert.NotNil(test, listErrs[name], name)
+		assert.Equal(test, task.Query{Filter: task.Filter{Name: name}}.Validate(), listErrs[name], name)
+		assert.Empty(test, listTasks[name], name)
+	}
+}
+
+func testListFilterResolvedRange(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var listTasks []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing resolved range filter`
+	var base = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var filter = task.Filter{ResolvedAt: task.TimeRange{From: hoursAfter(base, 1), To: hoursAfter(base, 3)}}
+
+	//-- Pre-conditions ----------
+	models = insertResolvedTasks(test, store, name, []*time.Time{hoursAfter(base, 0), hoursAfter(base, 1), nil, hoursAfter(base, 2), hoursAfter(base, 3)})
+
+	//-- Action ----------
+	listTasks, listErr = store.List(context.Background(), task.Query{Filter: filter}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.Equal(test, modelIDs(models[1], models[3]), ids(listTasks))
+}
+
+func testListFilterCreatedRange(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var laterModels []*task.Task
+	var listTasks []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing created range filter`
+	var separation = 10 * time.Millisecond
+
+	var from time.Time
+
+	//-- Pre-conditions ----------
+	insertTasks(test, store, name, 2)
+
+	time.Sleep(separation)
+	from = time.Now().UTC()
+	time.Sleep(separation)
+
+	laterModels = insertTasks(test, store, name, 3)
+
+	//-- Action ----------
+	listTasks, listErr = store.List(context.Background(), task.Query{Filter: task.Filter{CreatedAt: task.TimeRange{From: &from}}}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.Equal(test, modelIDs(laterModels...), ids(listTasks))
+}
+
+func testListSortDescending(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var listTasks []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing descending sort`
+	var quantity = 4
+
+	var sort = task.Sort{Direction: task.Descending}
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, quantity)
+
+	//-- Action ----------
+	listTasks, listErr = store.List(context.Background(), task.Query{Sort: sort}, 3, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.Equal(test, modelIDs(models[3], models[2], models[1]), ids(listTasks))
+}
+
+func testListSortResolvedAt(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var ascendingTasks, descendingTasks []task.Task
+	var ascendingErr, descendingErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing resolved sort`
+	var base = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var ascending = task.Sort{Field: task.SortByResolvedAt, Direction: task.Ascending}
+	var descending = task.Sort{Field: task.SortByResolvedAt, Direction: task.Descending}
+
+	//-- Pre-conditions ----------
+	models = insertResolvedTasks(test, store, name, []*time.Time{hoursAfter(base, 3), nil, hoursAfter(base, 1), hoursAfter(base, 4), nil, hoursAfter(base, 2)})
+
+	//-- Action ----------
+	ascendingTasks, ascendingErr = store.List(context.Background(), task.Query{Sort: ascending}, 10, 0)
+	descendingTasks, descendingErr = store.List(context.Background(), task.Query{Sort: descending}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, ascendingErr)
+	assert.Nil(test, descendingErr)
+	assert.Equal(test, modelIDs(models[1], models[4], models[2], models[5], models[0], models[3]), ids(ascendingTasks))
+	assert.Equal(test, modelIDs(models[3], models[0], models[5], models[2], models[4], models[1]), ids(descendingTasks))
+}
+
+func testListInvalidQuery(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var listTasks []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing invalid query`
+	var sort = task.Sort{Field: task.SortField(`name; DROP TABLE tasks`)}
+
+	//-- Pre-conditions ----------
+	insertTasks(test, store, name, 2)
+
+	//-- Action ----------
+	listTasks, listErr = store.List(context.Background(), task.Query{Sort: sort}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, listErr)
+	assert.Nil(test, listTasks)
+}
+
+func testSeekSorted(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var afterTasks, beforeTasks, missingTasks []task.Task
+	var afterErr, beforeErr, missingErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing sorted seek`
+	var base = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var query = task.Query{Sort: task.Sort{Field: task.SortByResolvedAt, Direction: task.Descending}}
+	var limit uint = 2
+
+	//-- Pre-conditions ----------
+	models = insertResolvedTasks(test, store, name, []*time.Time{hoursAfter(base, 3), nil, hoursAfter(base, 1), hoursAfter(base, 4), nil, hoursAfter(base, 2)})
+
+	var position = task.Cursor{ID: models[5].ID, Field: task.SortByResolvedAt, Direction: task.Descending, Value: models[5].ResolvedAt}
+	var backward = position
+	backward.Backward = true
+	var missing = task.Cursor{ID: models[4].ID, Field: task.SortByResolvedAt, Direction: task.Descending}
+
+	//-- Action ----------
+	afterTasks, afterErr = store.Seek(context.Background(), query, &position, limit)
+	beforeTasks, beforeErr = store.Seek(context.Background(), query, &backward, limit)
+	missingTasks, missingErr = store.Seek(context.Background(), query, &missing, limit)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, afterErr)
+	assert.Nil(test, beforeErr)
+	assert.Nil(test, missingErr)
+	assert.Equal(test, modelIDs(models[2], models[4]), ids(afterTasks))
+	assert.Equal(test, modelIDs(models[3], models[0]), ids(beforeTasks))
+	assert.Equal(test, modelIDs(models[1]), ids(missingTasks))
+}
+
+//-- Version Checks ----------------------------------------------------------------------------------------------------
+func testInsertVersion(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var readTask *task.Task
+
+	//-- Test Parameters ----------
+	var name = `Testing insert version`
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	model = insertTask(test, store, name)
+	readTask, _ = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, uint(1), model.Version)
+
+	if assert.NotNil(test, readTask) {
+		assert.Equal(test, uint(1), readTask.Version)
+	}
+}
+
+func testUpdateVersion(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var readTask *task.Task
+	var firstErr, secondErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing update version`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	model.Name = `Testing conditional update`
+	firstErr = store.Update(context.Background(), model)
+
+	model.Name = `Testing unconditional update`
+	model.Version = 0
+	secondErr = store.Update(context.Background(), model)
+
+	readTask, _ = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, firstErr)
+	assert.Nil(test, secondErr)
+	assert.Equal(test, uint(3), model.Version)
+
+	if assert.NotNil(test, readTask) {
+		assert.Equal(test, uint(3), readTask.Version)
+		assert.Equal(test, `Testing unconditional update`, readTask.Name)
+	}
+}
+
+func testUpdateStaleVersion(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var first, second task.Task
+	var readTask *task.Task
+	var updateErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing stale version`
+
+	//-- Pre-conditions ----------
+	first = *insertTask(test, store, name)
+	second = first
+
+	first.Name = `Testing first writer`
+	if err := store.Update(context.Background(), &first); err != nil {
+		test.Fatalf(`unexpected error when updating record: %s`, err)
+	}
+
+	//-- Action ----------
+	second.Name = `Testing second writer`
+	updateErr = store.Update(context.Background(), &second)
+
+	readTask, _ = store.Read(context.Background(), first.ID)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, task.ErrVersionConflict, updateErr)
+	assert.Equal(test, uint(1), second.Version)
+
+	if assert.NotNil(test, readTask) {
+		assert.Equal(test, `Testing first writer`, readTask.Name)
+		assert.Equal(test, uint(2), readTask.Version)
+	}
+}
+
+func testUpdateVersionNotFound(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var updateErr error
+
+	//-- Test Parameters ----------
+	var model = newValidTask(`Testing missing versioned update`)
+
+	//-- Pre-conditions ----------
+	model.ID = 1000
+	model.Version = 3
+
+	//-- Action ----------
+	updateErr = store.Update(context.Background(), model)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, sql.ErrNoRows, updateErr)
+}
+
+//-- Trash Checks ------------------------------------------------------------------------------------------------------
+func deleteTasks(test *testing.T, store task.Store, models ...*task.Task) {
+	for _, model := range models {
+		if deleted, err := store.Delete(context.Background(), model.ID); err != nil {
+			test.Fatalf(`unexpected error when deleting record: %s`, err)
+		} else {
+			model.DeletedAt = deleted.DeletedAt
+		}
+	}
+}
+
+func testDeleteTwice(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, deleteTask *task.Task
+	var deleteErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing repeated delete`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+	deleteTasks(test, store, model)
+
+	//-- Action ----------
+	deleteTask, deleteErr = store.Delete(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, sql.ErrNoRows, deleteErr)
+	assert.Nil(test, deleteTask)
+}
+
+func testUpdateDeleted(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var updateErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing update of deleted`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+	deleteTasks(test, store, model)
+
+	//-- Action ----------
+	model.Name = `Testing updated deleted`
+	updateErr = store.Update(context.Background(), model)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, sql.ErrNoRows, updateErr)
+}
+
+func testRestore(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, restoreTask, readTask *task.Task
+	var restoreErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing valid restore`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+	deleteTasks(test, store, model)
+
+	//-- Action ----------
+	restoreTask, restoreErr = store.Restore(context.Background(), model.ID)
+	readTask, _ = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, restoreErr)
+	model.DeletedAt = nil
+
+	if assert.NotNil(test, restoreTask) {
+		assert.True(test, equal(*model, *restoreTask))
+	}
+	if assert.NotNil(test, readTask) {
+		assert.True(test, equal(*model, *readTask))
+	}
+}
+
+func testRestoreNotDeleted(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var liveErr, missingErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing restore of live task`
+	var missingID uint = 1000
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	_, liveErr = store.Restore(context.Background(), model.ID)
+	_, missingErr = store.Restore(context.Background(), missingID)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, sql.ErrNoRows, liveErr)
+	assert.Equal(test, sql.ErrNoRows, missingErr)
+}
+
+func testListFilterDeleted(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var excluded, included, only []task.Task
+	var excludedErr, includedErr, onlyErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing deleted filter`
+	var quantity = 5
+
+	var limit uint = 10
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, quantity)
+	deleteTasks(test, store, models[1], models[3])
+
+	//-- Action ----------
+	excluded, excludedErr = store.List(context.Background(), task.Query{Filter: task.Filter{Deleted: task.ExcludeDeleted}}, limit, 0)
+	included, includedErr = store.List(context.Background(), task.Query{Filter: task.Filter{Deleted: task.IncludeDeleted}}, limit, 0)
+	only, onlyErr = store.List(context.Background(), task.Query{Filter: task.Filter{Deleted: task.OnlyDeleted}}, limit, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, excludedErr)
+	assert.Nil(test, includedErr)
+	assert.Nil(test, onlyErr)
+
+	assert.Equal(test, modelIDs(models[0], models[2], models[4]), ids(excluded))
+	assert.Equal(test, modelIDs(models...), ids(included))
+	assert.Equal(test, modelIDs(models[1], models[3]), ids(only))
+
+	if assert.Equal(test, 2, len(only)) {
+		assert.True(test, equal(*models[1], only[0]))
+	}
+}
+
+func testSeekOnlyDeleted(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var first, second []task.Task
+	var firstErr, secondErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing trash seek`
+	var quantity = 4
+
+	var query = task.Query{
+		Filter: task.Filter{Deleted: task.OnlyDeleted},
+		Sort:   task.Sort{Field: task.SortByDeletedAt, Direction: task.Descending},
+	}
+	var limit uint = 2
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, quantity)
+
+	for _, model := range []*task.Task{models[2], models[0], models[3]} {
+		deleteTasks(test, store, model)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	//-- Action ----------
+	first, firstErr = store.Seek(context.Background(), query, nil, limit)
+
+	if len(first) > 0 {
+		var cursor = task.Cursor{ID: first[len(first)-1].ID, Field: task.SortByDeletedAt, Direction: task.Descending, Value: first[len(first)-1].DeletedAt}
+		second, secondErr = store.Seek(context.Background(), query, &cursor, limit)
+	}
+
+	//-- Post-conditions ----------
+	assert.Nil(test, firstErr)
+	assert.Nil(test, secondErr)
+
+	assert.Equal(test, modelIDs(models[3], models[0]), ids(first))
+	assert.Equal(test, modelIDs(models[2]), ids(second))
+}
+
+func testPurge(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var remaining []task.Task
+	var cutoff time.Time
+	var purged uint
+	var purgeErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing purge`
+	var quantity = 4
+
+	var limit uint = 10
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, quantity)
+	deleteTasks(test, store, models[0], models[1])
+
+	time.Sleep(10 * time.Millisecond)
+	cutoff = time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	deleteTasks(test, store, models[2])
+
+	//-- Action ----------
+	purged, purgeErr = store.Purge(context.Background(), cutoff)
+	remaining, _ = store.List(context.Background(), task.Query{Filter: task.Filter{Deleted: task.IncludeDeleted}}, limit, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, purgeErr)
+	assert.Equal(test, uint(2), purged)
+	assert.Equal(test, modelIDs(models[2], models[3]), ids(remaining))
+}
+
+//-- History Checks ----------------------------------------------------------------------------------------------------
+func history(test *testing.T, store task.Store, id uint) []task.History {
+	if entries, err := store.History(context.Background(), id, 0, 100); err != nil {
+		test.Fatalf(`unexpected error when reading history: %s`, err)
+		return nil
+	} else {
+		return entries
+	}
+}
+
+func changes(entry task.History) map[string][2]*string {
+	var output = make(map[string][2]*string)
+
+	for _, change := range entry.Changes {
+		output[change.Field] = [2]*string{change.Old, change.New}
+	}
+
+	return output
+}
+
+func testHistoryInsert(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model = newValidTask(`Testing history insert`)
+	var entries []task.History
+	var historyErr error
+
+	//-- Test Parameters ----------
+	var actor = `conformance-insert`
+
+	//-- Pre-conditions ----------
+	if err := store.Insert(task.WithActor(context.Background(), actor), model); err != nil {
+		test.Fatalf(`unexpected error when inserting record: %s`, err)
+	}
+
+	//-- Action ----------
+	entries, historyErr = store.History(context.Background(), model.ID, 0, 10)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, historyErr)
+
+	if assert.Equal(test, 1, len(entries)) {
+		var fields = changes(entries[0])
+
+		assert.Equal(test, model.ID, entries[0].TaskID)
+		assert.Equal(test, task.Created, entries[0].Action)
+		assert.Equal(test, actor, entries[0].Actor)
+		assert.Equal(test, uint(1), entries[0].Version)
+		assert.Equal(test, 2, len(fields))
+
+		if assert.Contains(test, fields, `name`) {
+			assert.Nil(test, fields[`name`][0])
+			assert.Equal(test, model.Name, *fields[`name`][1])
+		}
+	}
+}
+
+func testHistoryUpdate(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var entries []task.History
+
+	//-- Test Parameters ----------
+	var name = `Testing history update`
+	var updatedName = `Testing history updated`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	model.Name = updatedName
+	if err := store.Update(context.Background(), model); err != nil {
+		test.Fatalf(`unexpected error when updating record: %s`, err)
+	}
+
+	entries = history(test, store, model.ID)
+
+	//-- Post-conditions ----------
+	if assert.Equal(test, 2, len(entries)) {
+		var fields = changes(entries[1])
+
+		assert.True(test, entries[0].ID < entries[1].ID)
+		assert.Equal(test, task.Updated, entries[1].Action)
+		assert.Equal(test, task.AnonymousActor, entries[1].Actor)
+		assert.Equal(test, uint(2), entries[1].Version)
+
+		if assert.Equal(test, 1, len(fields)) && assert.Contains(test, fields, `name`) {
+			assert.Equal(test, name, *fields[`name`][0])
+			assert.Equal(test, updatedName, *fields[`name`][1])
+		}
+	}
+}
+
+func testHistoryDeleteRestore(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var entries []task.History
+
+	//-- Test Parameters ----------
+	var name = `Testing history delete`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	deleteTasks(test, store, model)
+	if _, err := store.Restore(context.Background(), model.ID); err != nil {
+		test.Fatalf(`unexpected error when restoring record: %s`, err)
+	}
+
+	entries = history(test, store, model.ID)
+
+	//-- Post-conditions ----------
+	if assert.Equal(test, 3, len(entries)) {
+		var deleted, restored = changes(entries[1]), changes(entries[2])
+
+		assert.Equal(test, task.Deleted, entries[1].Action)
+		assert.Equal(test, task.Restored, entries[2].Action)
+
+		if assert.Equal(test, 1, len(deleted)) && assert.Contains(test, deleted, `deleted_at`) {
+			assert.Nil(test, deleted[`deleted_at`][0])
+			assert.NotNil(test, deleted[`deleted_at`][1])
+		}
+		if assert.Equal(test, 1, len(restored)) && assert.Contains(test, restored, `deleted_at`) {
+			assert.NotNil(test, restored[`deleted_at`][0])
+			assert.Nil(test, restored[`deleted_at`][1])
+		}
+	}
+}
+
+func testHistoryFailedUpdate(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var invalidErr, staleErr error
+	var entries []task.History
+
+	//-- Test Parameters ----------
+	var name = `Testing history failed update`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+
+	//-- Action ----------
+	model.Name = `~`
+	invalidErr = store.Update(context.Background(), model)
+
+	model.Name = name
+	model.Version = 5
+	staleErr = store.Update(context.Background(), model)
+
+	entries = history(test, store, model.ID)
+
+	//-- Post-conditions ----------
+	assert.NotNil(test, invalidErr)
+	assert.Equal(test, task.ErrVersionConflict, staleErr)
+	assert.Equal(test, 1, len(entries))
+}
+
+func testHistoryPurge(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var entries []task.History
+
+	//-- Test Parameters ----------
+	var name = `Testing history purge`
+	var actor = `conformance-purge`
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+	deleteTasks(test, store, model)
+	time.Sleep(10 * time.Millisecond)
+
+	//-- Action ----------
+	if _, err := store.Purge(task.WithActor(context.Background(), actor), time.Now()); err != nil {
+		test.Fatalf(`unexpected error when purging records: %s`, err)
+	}
+
+	entries = history(test, store, model.ID)
+
+	//-- Post-conditions ----------
+	if assert.Equal(test, 3, len(entries)) {
+		assert.Equal(test, task.Purged, entries[2].Action)
+		assert.Equal(test, actor, entries[2].Actor)
+		assert.Equal(test, model.Version, entries[2].Version)
+		assert.Empty(test, entries[2].Changes)
+	}
+}
+
+func testHistoryPaging(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model, other *task.Task
+	var all, first, second []task.History
+	var firstErr, secondErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing history paging`
+	var updates = 4
+	var limit uint = 3
+
+	//-- Pre-conditions ----------
+	model = insertTask(test, store, name)
+	other = insertTask(test, store, name)
+
+	for index := 0; index < updates; index++ {
+		model.Name = fmt.Sprintf(`%s %d`, name, index)
+		if err := store.Update(context.Background(), model); err != nil {
+			test.Fatalf(`unexpected error when updating record: %s`, err)
+		}
+	}
+
+	all = history(test, store, model.ID)
+
+	//-- Action ----------
+	first, firstErr = store.History(context.Background(), model.ID, 0, limit)
+	if len(first) > 0 {
+		second, secondErr = store.History(context.Background(), model.ID, first[len(first)-1].ID, limit)
+	}
+
+	//-- Post-conditions ----------
+	assert.Nil(test, firstErr)
+	assert.Nil(test, secondErr)
+	assert.Equal(test, 1, len(history(test, store, other.ID)))
+
+	if assert.Equal(test, updates+1, len(all)) {
+		assert.Equal(test, all[:3], first)
+		assert.Equal(test, all[3:], second)
+	}
+}
+
+//-- Ownership Checks --------------------------------------------------------------------------------------------------
+// as returns a context carrying a principal for subject holding roles
+func as(subject string, roles ...string) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Subject: subject, Roles: roles})
+}
+
+func insertOwnedTask(test *testing.T, store task.Store, name string, owner string) *task.Task {
+	var model = newValidTask(name)
+	model.OwnerID = owner
+
+	if err := store.Insert(context.Background(), model); err != nil {
+		test.Fatalf(`unexpected error when inserting record: %s`, err)
+	}
+
+	return model
+}
+
+func testInsertOwner(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var result *task.Task
+	var readErr error
+
+	//-- Test Parameters ----------
+	var owner = `conformance-owner`
+
+	//-- Pre-conditions ----------
+	model = insertOwnedTask(test, store, `Testing insert owner`, owner)
+
+	//-- Action ----------
+	result, readErr = store.Read(context.Background(), model.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, readErr)
+	assert.Equal(test, owner, result.OwnerID)
+	assert.Equal(test, owner, history(test, store, model.ID)[0].OwnerID)
+}
+
+func testListFilterOwner(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var mine, theirs *task.Task
+	var result []task.Task
+	var listErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing list filter owner`
+
+	//-- Pre-conditions ----------
+	mine = insertOwnedTask(test, store, name, `conformance-mine`)
+	theirs = insertOwnedTask(test, store, name, `conformance-theirs`)
+
+	//-- Action ----------
+	result, listErr = store.List(context.Background(), task.Query{Filter: task.Filter{Owner: theirs.OwnerID}}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.Equal(test, modelIDs(theirs), ids(result))
+	assert.NotContains(test, ids(result), mine.ID)
+}
+
+func testScopeRead(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var mine, theirs *task.Task
+	var ownResult *task.Task
+	var ownErr, otherErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing scope read`
+	var member = as(`conformance-mine`)
+
+	//-- Pre-conditions ----------
+	mine = insertOwnedTask(test, store, name, `conformance-mine`)
+	theirs = insertOwnedTask(test, store, name, `conformance-theirs`)
+
+	//-- Action ----------
+	ownResult, ownErr = store.Read(member, mine.ID)
+	_, otherErr = store.Read(member, theirs.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, ownErr)
+	assert.Equal(test, mine.ID, ownResult.ID)
+	assert.Equal(test, sql.ErrNoRows, otherErr)
+}
+
+func testScopeChange(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var theirs *task.Task
+	var updateErr, deleteErr, restoreErr error
+
+	//-- Test Parameters ----------
+	var member = as(`conformance-mine`)
+
+	//-- Pre-conditions ----------
+	theirs = insertOwnedTask(test, store, `Testing scope change`, `conformance-theirs`)
+
+	//-- Action ----------
+	var changed = *theirs
+	changed.Name = `Testing scope changed`
+
+	updateErr = store.Update(member, &changed)
+	_, deleteErr = store.Delete(member, theirs.ID)
+
+	deleteTasks(test, store, theirs)
+	_, restoreErr = store.Restore(member, theirs.ID)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, sql.ErrNoRows, updateErr)
+	assert.Equal(test, sql.ErrNoRows, deleteErr)
+	assert.Equal(test, sql.ErrNoRows, restoreErr)
+	assert.Equal(test, 2, len(history(test, store, theirs.ID)))
+}
+
+func testScopeList(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var mine, theirs []*task.Task
+	var listed, sought []task.Task
+	var listErr, seekErr, filterErr error
+	var filtered []task.Task
+
+	//-- Test Parameters ----------
+	var name = `Testing scope list`
+	var member = as(`conformance-mine`)
+
+	//-- Pre-conditions ----------
+	mine = append(mine, insertOwnedTask(test, store, name, `conformance-mine`))
+	theirs = append(theirs, insertOwnedTask(test, store, name, `conformance-theirs`))
+	mine = append(mine, insertOwnedTask(test, store, name, `conformance-mine`))
+
+	//-- Action ----------
+	listed, listErr = store.List(member, task.Query{}, 10, 0)
+	sought, seekErr = store.Seek(member, task.Query{}, nil, 10)
+	filtered, filterErr = store.List(member, task.Query{Filter: task.Filter{Owner: theirs[0].OwnerID}}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.Nil(test, seekErr)
+	assert.Nil(test, filterErr)
+	assert.Equal(test, modelIDs(mine...), ids(listed))
+	assert.Equal(test, modelIDs(mine...), ids(sought))
+	assert.Equal(test, 0, len(filtered))
+}
+
+func testScopeHistory(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var mine, theirs *task.Task
+	var own, other []task.History
+	var ownErr, otherErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing scope history`
+	var member = as(`conformance-mine`)
+
+	//-- Pre-conditions ----------
+	mine = insertOwnedTask(test, store, name, `conformance-mine`)
+	theirs = insertOwnedTask(test, store, name, `conformance-theirs`)
+
+	//-- Action ----------
+	own, ownErr = store.History(member, mine.ID, 0, 10)
+	other, otherErr = store.History(member, theirs.ID, 0, 10)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, ownErr)
+	assert.Nil(test, otherErr)
+	assert.Equal(test, 1, len(own))
+	assert.Equal(test, 0, len(other))
+}
+
+func testScopeAdmin(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var theirs *task.Task
+	var listed []task.Task
+	var readErr, deleteErr, listErr error
+
+	//-- Test Parameters ----------
+	var admin = as(`conformance-admin`, string(task.AdminRole))
+
+	//-- Pre-conditions ----------
+	theirs = insertOwnedTask(test, store, `Testing scope admin`, `conformance-theirs`)
+
+	//-- Action ----------
+	_, readErr = store.Read(admin, theirs.ID)
+	listed, listErr = store.List(admin, task.Query{}, 10, 0)
+	_, deleteErr = store.Delete(admin, theirs.ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, readErr)
+	assert.Nil(test, listErr)
+	assert.Nil(test, deleteErr)
+	assert.Equal(test, modelIDs(theirs), ids(listed))
+}
+
+//-- Tenancy Checks ----------------------------------------------------------------------------------------------------
+// within returns a context carrying an admin of tenant, so only the tenant confines what it reaches
+func within(tenant string) context.Context {
+	return task.WithTenant(as(`conformance-admin`, string(task.AdminRole)), tenant)
+}
+
+func insertTenantTask(test *testing.T, store task.Store, name string, tenant string) *task.Task {
+	var model = newValidTask(name)
+
+	if err := store.Insert(within(tenant), model); err != nil {
+		test.Fatalf(`unexpected error when inserting record: %s`, err)
+	}
+
+	return model
+}
+
+func testInsertTenant(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var model *task.Task
+	var result *task.Task
+	var insertErr, readErr error
+	var entries []task.History
+
+	//-- Test Parameters ----------
+	var tenant = `conformance-tenant`
+
+	//-- Pre-conditions ----------
+	model = newValidTask(`Testing insert tenant`)
+	model.TenantID = `conformance-elsewhere`
+
+	//-- Action ----------
+	insertErr = store.Insert(within(tenant), model)
+	result, readErr = store.Read(within(tenant), model.ID)
+	entries, _ = store.History(within(tenant), model.ID, 0, 10)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, insertErr)
+	assert.Nil(test, readErr)
+	assert.Equal(test, tenant, model.TenantID)
+	assert.Equal(test, tenant, result.TenantID)
+	if assert.Equal(test, 1, len(entries)) {
+		assert.Equal(test, tenant, entries[0].TenantID)
+	}
+}
+
+func testTenantRead(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var theirs *task.Task
+	var otherErr, defaultErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	theirs = insertTenantTask(test, store, `Testing tenant read`, `conformance-theirs`)
+
+	//-- Action ----------
+	_, otherErr = store.Read(within(`conformance-mine`), theirs.ID)
+	_, defaultErr = store.Read(context.Background(), theirs.ID)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, sql.ErrNoRows, otherErr)
+	assert.Equal(test, sql.ErrNoRows, defaultErr)
+}
+
+func testTenantChange(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var theirs *task.Task
+	var result, patchedTask *task.Task
+	var updateErr, patchErr, deleteErr, restoreErr error
+
+	//-- Test Parameters ----------
+	var mine = within(`conformance-mine`)
+
+	//-- Pre-conditions ----------
+	theirs = insertTenantTask(test, store, `Testing tenant change`, `conformance-theirs`)
+
+	//-- Action ----------
+	var changed = *theirs
+	changed.Name = `Testing tenant changed`
+
+	updateErr = store.Update(mine, &changed)
+	patchedTask, patchErr = store.Patch(mine, theirs.ID, 0, func(model *task.Task) error {
+		model.Name = `Testing tenant patched`
+		return nil
+	})
+	_, deleteErr = store.Delete(mine, theirs.ID)
+
+	if _, err := store.Delete(within(`conformance-theirs`), theirs.ID); err != nil {
+		test.Fatalf(`unexpected error when deleting record: %s`, err)
+	}
+	_, restoreErr = store.Restore(mine, theirs.ID)
+
+	result, _ = store.Restore(within(`conformance-theirs`), theirs.ID)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, sql.ErrNoRows, updateErr)
+	assert.Equal(test, sql.ErrNoRows, patchErr)
+	assert.Nil(test, patchedTask)
+	assert.Equal(test, sql.ErrNoRows, deleteErr)
+	assert.Equal(test, sql.ErrNoRows, restoreErr)
+	if assert.NotNil(test, result) {
+		assert.Equal(test, theirs.Name, result.Name)
+		assert.Equal(test, theirs.Version, result.Version)
+	}
+}
+
+func testTenantList(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var mine, theirs []*task.Task
+	var listed, sought []task.Task
+	var listErr, seekErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing tenant list`
+
+	//-- Pre-conditions ----------
+	mine = append(mine, insertTenantTask(test, store, name, `conformance-mine`))
+	theirs = append(theirs, insertTenantTask(test, store, name, `conformance-theirs`))
+	mine = append(mine, insertTenantTask(test, store, name, `conformance-mine`))
+
+	//-- Action ----------
+	listed, listErr = store.List(within(`conformance-mine`), task.Query{Filter: task.Filter{Deleted: task.IncludeDeleted}}, 10, 0)
+	sought, seekErr = store.Seek(within(`conformance-mine`), task.Query{}, nil, 10)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, listErr)
+	assert.Nil(test, seekErr)
+	assert.Equal(test, modelIDs(mine...), ids(listed))
+	assert.Equal(test, modelIDs(mine...), ids(sought))
+	assert.NotContains(test, ids(listed), theirs[0].ID)
+}
+
+func testTenantHistory(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var theirs *task.Task
+	var other []task.History
+	var otherErr error
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	theirs = insertTenantTask(test, store, `Testing tenant history`, `conformance-theirs`)
+
+	//-- Action ----------
+	other, otherErr = store.History(within(`conformance-mine`), theirs.ID, 0, 10)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, otherErr)
+	assert.Equal(test, 0, len(other))
+}
+
+func testTenantPurge(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var mine, theirs *task.Task
+	var purged, everywhere uint
+	var purgeErr, everywhereErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing tenant purge`
+
+	//-- Pre-conditions ----------
+	mine = insertTenantTask(test, store, name, `conformance-mine`)
+	theirs = insertTenantTask(test, store, name, `conformance-theirs`)
+
+	for _, model := range []*task.Task{mine, theirs} {
+		if _, err := store.Delete(within(model.TenantID), model.ID); err != nil {
+			test.Fatalf(`unexpected error when deleting record: %s`, err)
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	//-- Action ----------
+	purged, purgeErr = store.Purge(within(`conformance-mine`), time.Now())
+	everywhere, everywhereErr = store.Purge(task.AcrossTenants(context.Background()), time.Now())
+
+	//-- Post-conditions ----------
+	assert.Nil(test, purgeErr)
+	assert.Nil(test, everywhereErr)
+	assert.Equal(test, uint(1), purged)
+	assert.Equal(test, uint(1), everywhere)
+}
+
+//-- Batch Checks ------------------------------------------------------------------------------------------------------
+// outcomes lists the error of each item of results in order, nil for the items which were stored
+func outcomes(results []task.BatchResult) []error {
+	var output = make([]error, 0, len(results))
+
+	for index, result := range results {
+		if result.Index != index {
+			output = append(output, errors.New(fmt.Sprintf(`result %d is reported at index %d`, index, result.Index)))
+		} else {
+			output = append(output, result.Err)
+		}
+	}
+
+	return output
+}
+
+func testBulkCreate(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var results []task.BatchResult
+	var listTasks []task.Task
+	var bulkErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing bulk create`
+
+	//-- Pre-conditions ----------
+	models = []*task.Task{newValidTask(name + ` 0`), newValidTask(`~~~`), newValidTask(name + ` 2`)}
+
+	//-- Action ----------
+	results, bulkErr = store.BulkCreate(context.Background(), models, task.PerItem)
+	listTasks, _ = store.List(context.Background(), task.Query{}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, bulkErr)
+
+	if assert.Equal(test, 3, len(results)) {
+		assert.Nil(test, results[0].Err)
+		assert.NotNil(test, results[1].Err)
+		assert.Nil(test, results[1].Task)
+		assert.Nil(test, results[2].Err)
+		assert.Equal(test, []uint{results[0].Task.ID, results[2].Task.ID}, ids(listTasks))
+		assert.Equal(test, uint(1), results[2].Task.Version)
+		assert.Equal(test, 1, len(history(test, store, results[2].Task.ID)))
+	}
+
+	for _, model := range models {
+		assert.Equal(test, uint(0), model.ID)
+	}
+}
+
+func testBulkCreateAtomic(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var results []task.BatchResult
+	var listTasks []task.Task
+	var bulkErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing atomic bulk create`
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	results, bulkErr = store.BulkCreate(context.Background(), []*task.Task{newValidTask(name), newValidTask(`~~~`), newValidTask(name)}, task.Atomic)
+	listTasks, _ = store.List(context.Background(), task.Query{}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, bulkErr)
+	assert.Equal(test, 0, len(listTasks))
+
+	if errs := outcomes(results); assert.Equal(test, 3, len(errs)) {
+		assert.Equal(test, task.ErrBatchAborted, errs[0])
+		assert.NotNil(test, errs[1])
+		assert.NotEqual(test, task.ErrBatchAborted, errs[1])
+		assert.Equal(test, task.ErrBatchAborted, errs[2])
+	}
+}
+
+func testBulkUpdate(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var results []task.BatchResult
+	var readTask *task.Task
+	var bulkErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing bulk update`
+	var updatedName = `Testing bulk update renamed`
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, 2)
+
+	//-- Action ----------
+	results, bulkErr = store.BulkUpdate(context.Background(), []*task.Task{
+		{ID: models[0].ID, Name: updatedName, Version: models[0].Version},
+		{ID: models[1].ID, Name: updatedName, Version: models[1].Version + 1},
+		{ID: models[1].ID + 1000, Name: updatedName},
+	}, task.PerItem)
+
+	readTask, _ = store.Read(context.Background(), models[0].ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, bulkErr)
+	assert.Equal(test, []error{nil, task.ErrVersionConflict, sql.ErrNoRows}, outcomes(results))
+
+	if assert.NotNil(test, readTask) && assert.NotNil(test, results[0].Task) {
+		assert.Equal(test, updatedName, readTask.Name)
+		assert.Equal(test, uint(2), readTask.Version)
+		assert.True(test, equal(*readTask, *results[0].Task))
+	}
+}
+
+func testBulkUpdateAtomic(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var results []task.BatchResult
+	var readTask *task.Task
+	var bulkErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing atomic bulk update`
+	var updatedName = `Testing atomic bulk update renamed`
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, 2)
+
+	//-- Action ----------
+	results, bulkErr = store.BulkUpdate(context.Background(), []*task.Task{
+		{ID: models[0].ID, Name: updatedName},
+		{ID: models[1].ID, Name: updatedName, Version: models[1].Version + 1},
+	}, task.Atomic)
+
+	readTask, _ = store.Read(context.Background(), models[0].ID)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, bulkErr)
+	assert.Equal(test, []error{task.ErrBatchAborted, task.ErrVersionConflict}, outcomes(results))
+	assert.Equal(test, 1, len(history(test, store, models[0].ID)))
+
+	if assert.NotNil(test, readTask) {
+		assert.Equal(test, models[0].Name, readTask.Name)
+		assert.Equal(test, uint(1), readTask.Version)
+	}
+}
+
+func testBulkDelete(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var results []task.BatchResult
+	var listTasks []task.Task
+	var bulkErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing bulk delete`
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, 3)
+
+	//-- Action ----------
+	results, bulkErr = store.BulkDelete(context.Background(), []uint{models[0].ID, models[2].ID + 1000, models[2].ID}, task.PerItem)
+	listTasks, _ = store.List(context.Background(), task.Query{}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, bulkErr)
+	assert.Equal(test, []error{nil, sql.ErrNoRows, nil}, outcomes(results))
+	assert.Equal(test, modelIDs(models[1]), ids(listTasks))
+
+	if assert.NotNil(test, results[2].Task) {
+		assert.Equal(test, models[2].ID, results[2].Task.ID)
+		assert.NotNil(test, results[2].Task.DeletedAt)
+	}
+}
+
+func testBulkDeleteAtomic(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var models []*task.Task
+	var results []task.BatchResult
+	var listTasks []task.Task
+	var bulkErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing atomic bulk delete`
+
+	//-- Pre-conditions ----------
+	models = insertTasks(test, store, name, 2)
+
+	//-- Action ----------
+	results, bulkErr = store.BulkDelete(context.Background(), []uint{models[0].ID, models[1].ID, models[0].ID}, task.Atomic)
+	listTasks, _ = store.List(context.Background(), task.Query{}, 10, 0)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, bulkErr)
+	assert.Equal(test, []error{task.ErrBatchAborted, task.ErrBatchAborted, sql.ErrNoRows}, outcomes(results))
+	assert.Equal(test, modelIDs(models...), ids(listTasks))
+}
+
+func testTenantBulk(test *testing.T, store task.Store) {
+	//-- Shared Variables ----------
+	var mine, theirs *task.Task
+	var updated, deleted []task.BatchResult
+	var readTask *task.Task
+	var entries []task.History
+	var updateErr, deleteErr, readErr, historyErr error
+
+	//-- Test Parameters ----------
+	var name = `Testing tenant bulk`
+	var expected = map[task.BatchMode][]error{
+		task.PerItem: {nil, sql.ErrNoRows},
+		task.Atomic:  {task.ErrBatchAborted, sql.ErrNoRows},
+	}
+
+	for mode, outcome := range expected {
+		//-- Pre-conditions ----------
+		mine = insertTenantTask(test, store, name, `conformance-mine`)
+		theirs = insertTenantTask(test, store, name, `conformance-theirs`)
+
+		//-- Action ----------
+		updated, updateErr = store.BulkUpdate(within(`conformance-mine`), []*task.Task{
+			{ID: mine.ID, Name: `Testing tenant bulk renamed`},
+			{ID: theirs.ID, Name: `Testing tenant bulk renamed`},
+		}, mode)
+		deleted, deleteErr = store.BulkDelete(within(`conformance-mine`), []uint{mine.ID, theirs.ID}, mode)
+
+		readTask, readErr = store.Read(within(`conformance-theirs`), theirs.ID)
+		entries, historyErr = store.History(within(`conformance-theirs`), theirs.ID, 0, 100)
+
+		//-- Post-conditions ----------
+		assert.Nil(test, updateErr, mode)
+		assert.Nil(test, deleteErr, mode)
+		assert.Equal(test, outcome, outcomes(updated), mode)
+		assert.Equal(test, outcome, outcomes(deleted), mode)
+
+		assert.Nil(test, readErr, mode)
+		if assert.NotNil(test, readTask, mode) {
+			assert.Equal(test, theirs.Name, readTask.Name, mode)
+			assert.Equal(test, theirs.Version, readTask.Version, mode)
+			assert.Nil(test, readTask.DeletedAt, mode)
+		}
+
+		assert.Nil(test, historyErr, mode)
+		assert.Equal(test, 1, len(entries), mode)
+	}
+}
@@ -0,0 +1,66 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// DefaultTenant holds the Tasks of callers who name no tenant, along with every Task created before tenancy
+	DefaultTenant = `default`
+)
+
+var (
+	tenantPattern = regexp.MustCompile(`\A[a-zA-Z0-9_\-.]{1,255}\z`)
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type tenantKey struct{}
+
+type everyTenantKey struct{}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// WithTenant returns a copy of ctx whose reads and changes are confined to the Tasks of tenant
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// AcrossTenants returns a copy of ctx which reaches the Tasks of every tenant. It exists for maintenance such as the
+// scheduled purge and must never be derived from a request.
+func AcrossTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, everyTenantKey{}, true)
+}
+
+// TenantFrom returns the tenant carried by ctx, falling back to the tenant of its authenticated principal and then to
+// DefaultTenant
+func TenantFrom(ctx context.Context) string {
+	if tenant, ok := ctx.Value(tenantKey{}).(string); ok && len(tenant) > 0 {
+		return tenant
+	} else if principal := auth.PrincipalFrom(ctx); principal != nil && len(principal.Tenant) > 0 {
+		return principal.Tenant
+	}
+	return DefaultTenant
+}
+
+// ValidateTenant checks that tenant is a well formed tenant ID, one made only of letters, numbers, hyphens, underscores
+// and periods which fits the tenant_id column
+func ValidateTenant(tenant string) error {
+	if !tenantPattern.MatchString(tenant) {
+		return errors.New(fmt.Sprintf(`validation - TenantID '%s' must be comprised only of letters, numbers, hyphens, underscores and periods and may not be empty and may not exceed 255 characters`, tenant))
+	}
+	return nil
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// acrossTenants reports whether ctx was lifted out of its tenant by AcrossTenants
+func acrossTenants(ctx context.Context) bool {
+	var across, _ = ctx.Value(everyTenantKey{}).(bool)
+	return across
+}
@@ -0,0 +1,152 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+// withTenant returns a context carrying an admin of tenant, so only the tenant confines what it reaches
+func withTenant(tenant string) context.Context {
+	return WithTenant(withPrincipal(`admin-`+tenant, AdminRole), tenant)
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestTenantFrom(test *testing.T) {
+	//-- Shared Variables ----------
+
+	//-- Test Parameters ----------
+	var principal = auth.WithPrincipal(context.Background(), &auth.Principal{Subject: `user-1`, Tenant: `tenant-a`})
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+
+	//-- Post-conditions ----------
+	assert.Equal(test, DefaultTenant, TenantFrom(context.Background()))
+	assert.Equal(test, DefaultTenant, TenantFrom(WithTenant(context.Background(), ``)))
+	assert.Equal(test, `tenant-a`, TenantFrom(principal))
+	assert.Equal(test, `tenant-b`, TenantFrom(WithTenant(principal, `tenant-b`)))
+
+	assert.Equal(test, Scope{Tenant: `tenant-a`, Owner: `user-1`, Confined: true}, ScopeFrom(principal))
+	assert.Equal(test, Scope{}, ScopeFrom(AcrossTenants(context.Background())))
+	assert.False(test, ScopeFrom(principal).Allows(Task{OwnerID: `user-1`, TenantID: `tenant-b`}))
+
+	assert.Nil(test, ValidateTenant(`tenant-a.example_1`))
+	assert.NotNil(test, ValidateTenant(``))
+	assert.NotNil(test, ValidateTenant(`tenant a`))
+	assert.NotNil(test, ValidateTenant(strings.Repeat(`a`, 256)))
+}
+
+func TestServiceTenantIsolation(test *testing.T) {
+	//-- Shared Variables ----------
+	var service Service
+	var theirs, trashed, resolved *Task
+	var readErr, updateErr, patchErr, resolveErr, reopenErr, deleteErr, restoreErr, listErr, paginateErr, historyErr, purgeErr error
+	var listed []Task
+	var batches [][]BatchResult
+	var page *Page
+	var history *HistoryPage
+	var purged uint
+
+	//-- Test Parameters ----------
+	var tenantA = withTenant(`tenant-a`)
+	var tenantB = withTenant(`tenant-b`)
+	var resolvedAt = time.Now().UTC().Add(-time.Hour)
+
+	//-- Pre-conditions ----------
+	service = NewService(nil, openMemoryStore(test))
+	defer shutdownService(test, service)
+
+	theirs = &Task{Name: `Test tenant isolation`}
+	trashed = &Task{Name: `Test tenant isolation trash`}
+	resolved = &Task{Name: `Test tenant isolation resolved`, ResolvedAt: &resolvedAt}
+
+	for _, model := range []*Task{theirs, trashed, resolved} {
+		if err := service.Create(tenantA, model); err != nil {
+			test.Fatalf(`unable to create a task: %s`, err)
+		}
+	}
+
+	if _, err := service.Delete(tenantA, trashed.ID); err != nil {
+		test.Fatalf(`unable to delete a task: %s`, err)
+	}
+
+	//-- Action ----------
+	_, readErr = service.Read(tenantB, theirs.ID)
+	updateErr = service.Update(tenantB, &Task{ID: theirs.ID, Name: `Test tenant isolation changed`})
+	_, patchErr = service.Patch(tenantB, theirs.ID, 0, func(task *Task) error {
+		task.Name = `Test tenant isolation patched`
+		return nil
+	})
+	_, resolveErr = service.Resolve(tenantB, theirs.ID)
+	_, reopenErr = service.Reopen(tenantB, resolved.ID)
+	_, deleteErr = service.Delete(tenantB, theirs.ID)
+	for _, mode := range []BatchMode{Atomic, PerItem} {
+		if results, err := service.BulkUpdate(tenantB, []*Task{{ID: theirs.ID, Name: `Test tenant isolation batched`}}, mode); err != nil {
+			test.Fatalf(`unexpected error from a batch update: %s`, err)
+		} else if deleted, err := service.BulkDelete(tenantB, []uint{theirs.ID}, mode); err != nil {
+			test.Fatalf(`unexpected error from a batch delete: %s`, err)
+		} else {
+			batches = append(batches, results, deleted)
+		}
+	}
+	_, restoreErr = service.Restore(tenantB, trashed.ID)
+	listed, listErr = service.List(tenantB, Query{Filter: Filter{Deleted: IncludeDeleted}}, 10, 0)
+	page, paginateErr = service.Paginate(tenantB, Query{Filter: Filter{Deleted: IncludeDeleted}}, 10, ``)
+	history, historyErr = service.History(tenantB, theirs.ID, 10, ``)
+	purged, purgeErr = service.Purge(tenantB, time.Nanosecond)
+
+	//-- Post-conditions ----------
+	assert.Equal(test, `tenant-a`, theirs.TenantID)
+
+	assert.Equal(test, sql.ErrNoRows, readErr)
+	assert.Equal(test, sql.ErrNoRows, updateErr)
+	assert.Equal(test, sql.ErrNoRows, patchErr)
+	assert.Equal(test, sql.ErrNoRows, resolveErr)
+	assert.Equal(test, sql.ErrNoRows, reopenErr)
+	assert.Equal(test, sql.ErrNoRows, deleteErr)
+	assert.Equal(test, sql.ErrNoRows, restoreErr)
+	for _, results := range batches {
+		if assert.Equal(test, 1, len(results)) {
+			assert.Equal(test, sql.ErrNoRows, results[0].Err)
+		}
+	}
+
+	assert.Nil(test, listErr)
+	assert.Equal(test, 0, len(listed))
+	assert.Nil(test, paginateErr)
+	assert.Equal(test, 0, len(page.Tasks))
+	assert.Nil(test, historyErr)
+	assert.Equal(test, 0, len(history.Entries))
+	assert.Nil(test, purgeErr)
+	assert.Equal(test, uint(0), purged)
+
+	if result, err := service.Read(tenantA, theirs.ID); assert.Nil(test, err) {
+		assert.Equal(test, theirs.Name, result.Name)
+		assert.Equal(test, theirs.Version, result.Version)
+		assert.Nil(test, result.ResolvedAt)
+	}
+	if result, err := service.History(tenantA, theirs.ID, 10, ``); assert.Nil(test, err) {
+		assert.Equal(test, 1, len(result.Entries))
+	}
+	if result, err := service.Read(tenantA, resolved.ID); assert.Nil(test, err) && assert.NotNil(test, result.ResolvedAt) {
+		assert.Equal(test, resolvedAt.Unix(), result.ResolvedAt.Unix())
+		assert.Equal(test, resolved.Version, result.Version)
+	}
+	if result, err := service.List(tenantA, Query{Filter: Filter{Deleted: OnlyDeleted}}, 10, 0); assert.Nil(test, err) {
+		assert.Equal(test, 1, len(result))
+	}
+}
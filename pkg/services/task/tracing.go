@@ -0,0 +1,238 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"time"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/tracing"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type traceMiddleware struct {
+	next Service
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// NewTraceMiddleware wraps every call to the Service in a span of the default tracer, the spans of the store's
+// statements are its children
+func NewTraceMiddleware() Middleware {
+	return func(next Service) Service {
+		return traceMiddleware{next}
+	}
+}
+
+func (middleware traceMiddleware) Create(ctx context.Context, task *Task) error {
+	var traced, span = tracing.Start(ctx, `task create`, tracing.KindInternal)
+	defer span.End()
+
+	var err = middleware.next.Create(traced, task)
+
+	span.SetAttribute(`task.id`, task.ID)
+	traceOutcome(span, err)
+	return err
+}
+
+func (middleware traceMiddleware) Update(ctx context.Context, task *Task) error {
+	var traced, span = tracing.Start(ctx, `task update`, tracing.KindInternal)
+	defer span.End()
+
+	span.SetAttribute(`task.id`, task.ID)
+	span.SetAttribute(`task.version`, task.Version)
+
+	var err = middleware.next.Update(traced, task)
+
+	traceOutcome(span, err)
+	return err
+}
+
+func (middleware traceMiddleware) Patch(ctx context.Context, id uint, version uint, apply func(task *Task) error) (*Task, error) {
+	var traced, span = tracing.Start(ctx, `task patch`, tracing.KindInternal)
+	defer span.End()
+
+	span.SetAttribute(`task.id`, id)
+	span.SetAttribute(`task.version`, version)
+
+	var result, err = middleware.next.Patch(traced, id, version, apply)
+
+	traceOutcome(span, err)
+	return result, err
+}
+
+func (middleware traceMiddleware) Resolve(ctx context.Context, id uint) (*Task, error) {
+	var traced, span = tracing.Start(ctx, `task resolve`, tracing.KindInternal)
+	defer span.End()
+
+	span.SetAttribute(`task.id`, id)
+
+	var result, err = middleware.next.Resolve(traced, id)
+
+	traceOutcome(span, err)
+	return result, err
+}
+
+func (middleware traceMiddleware) Reopen(ctx context.Context, id uint) (*Task, error) {
+	var traced, span = tracing.Start(ctx, `task reopen`, tracing.KindInternal)
+	defer span.End()
+
+	span.SetAttribute(`task.id`, id)
+
+	var result, err = middleware.next.Reopen(traced, id)
+
+	traceOutcome(span, err)
+	return result, err
+}
+
+func (middleware traceMiddleware) Read(ctx context.Context, id uint) (*Task, error) {
+	var traced, span = tracing.Start(ctx, `task read`, tracing.KindInternal)
+	defer span.End()
+
+	span.SetAttribute(`task.id`, id)
+
+	var result, err = middleware.next.Read(traced, id)
+
+	traceOutcome(span, err)
+	return result, err
+}
+
+func (middleware traceMiddleware) Delete(ctx context.Context, id uint) (*Task, error) {
+	var traced, span = tracing.Start(ctx, `task delete`, tracing.KindInternal)
+	defer span.End()
+
+	span.SetAttribute(`task.id`, id)
+
+	var result, err = middleware.next.Delete(traced, id)
+
+	traceOutcome(span, err)
+	return result, err
+}
+
+func (middleware traceMiddleware) Restore(ctx context.Context, id uint) (*Task, error) {
+	var traced, span = tracing.Start(ctx, `task restore`, tracing.KindInternal)
+	defer span.End()
+
+	span.SetAttribute(`task.id`, id)
+
+	var result, err = middleware.next.Restore(traced, id)
+
+	traceOutcome(span, err)
+	return result, err
+}
+
+func (middleware traceMiddleware) Purge(ctx context.Context, retention time.Duration) (uint, error) {
+	var traced, span = tracing.Start(ctx, `task purge`, tracing.KindInternal)
+	defer span.End()
+
+	span.SetAttribute(`task.retention`, retention.String())
+
+	var result, err = middleware.next.Purge(traced, retention)
+
+	span.SetAttribute(`task.purged`, result)
+	traceOutcome(span, err)
+	return result, err
+}
+
+func (middleware traceMiddleware) BulkCreate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error) {
+	var traced, span = tracing.Start(ctx, `task bulk create`, tracing.KindInternal)
+	defer span.End()
+
+	var results, err = middleware.next.BulkCreate(traced, tasks, mode)
+
+	traceBatch(span, len(tasks), mode, results)
+	traceOutcome(span, err)
+	return results, err
+}
+
+func (middleware traceMiddleware) BulkUpdate(ctx context.Context, tasks []*Task, mode BatchMode) ([]BatchResult, error) {
+	var traced, span = tracing.Start(ctx, `task bulk update`, tracing.KindInternal)
+	defer span.End()
+
+	var results, err = middleware.next.BulkUpdate(traced, tasks, mode)
+
+	traceBatch(span, len(tasks), mode, results)
+	traceOutcome(span, err)
+	return results, err
+}
+
+func (middleware traceMiddleware) BulkDelete(ctx context.Context, ids []uint, mode BatchMode) ([]BatchResult, error) {
+	var traced, span = tracing.Start(ctx, `task bulk delete`, tracing.KindInternal)
+	defer span.End()
+
+	var results, err = middleware.next.BulkDelete(traced, ids, mode)
+
+	traceBatch(span, len(ids), mode, results)
+	traceOutcome(span, err)
+	return results, err
+}
+
+func (middleware traceMiddleware) List(ctx context.Context, query Query, limit uint, offset uint) ([]Task, error) {
+	var traced, span = tracing.Start(ctx, `task list`, tracing.KindInternal)
+	defer span.End()
+
+	span.SetAttribute(`task.limit`, limit)
+	span.SetAttribute(`task.offset`, offset)
+
+	var result, err = middleware.next.List(traced, query, limit, offset)
+
+	span.SetAttribute(`task.count`, len(result))
+	traceOutcome(span, err)
+	return result, err
+}
+
+func (middleware traceMiddleware) Paginate(ctx context.Context, query Query, limit uint, cursor string) (*Page, error) {
+	var traced, span = tracing.Start(ctx, `task paginate`, tracing.KindInternal)
+	defer span.End()
+
+	span.SetAttribute(`task.limit`, limit)
+	span.SetAttribute(`task.cursor`, len(cursor) > 0)
+
+	var result, err = middleware.next.Paginate(traced, query, limit, cursor)
+
+	if result != nil {
+		span.SetAttribute(`task.count`, len(result.Tasks))
+	}
+	traceOutcome(span, err)
+	return result, err
+}
+
+func (middleware traceMiddleware) History(ctx context.Context, id uint, limit uint, cursor string) (*HistoryPage, error) {
+	var traced, span = tracing.Start(ctx, `task history`, tracing.KindInternal)
+	defer span.End()
+
+	span.SetAttribute(`task.id`, id)
+	span.SetAttribute(`task.limit`, limit)
+
+	var result, err = middleware.next.History(traced, id, limit, cursor)
+
+	if result != nil {
+		span.SetAttribute(`task.count`, len(result.Entries))
+	}
+	traceOutcome(span, err)
+	return result, err
+}
+
+func (middleware traceMiddleware) Shutdown() error {
+	return middleware.next.Shutdown()
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// traceOutcome records the outcome of a call on span, classifying a failure the same way the log middleware does
+// traceBatch describes a batch of size items and how many of them failed
+func traceBatch(span *tracing.Span, size int, mode BatchMode, results []BatchResult) {
+	span.SetAttribute(`task.batch.size`, size)
+	span.SetAttribute(`task.batch.mode`, string(mode))
+
+	if results != nil {
+		span.SetAttribute(`task.batch.failed`, failures(results))
+	}
+}
+
+func traceOutcome(span *tracing.Span, err error) {
+	if err != nil {
+		span.SetAttribute(`error.class`, errorClass(err))
+		span.RecordError(err)
+	}
+}
@@ -0,0 +1,106 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package task
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"testing"
+
+	"github.com/JustonDavies/go_serverless_api/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+// traceWith installs a tracer exporting to exporter for the duration of the test
+func traceWith(test *testing.T, exporter tracing.Exporter) {
+	var previous = tracing.Default()
+
+	tracing.SetDefault(tracing.NewTracer(`test-service`, exporter))
+	test.Cleanup(func() { tracing.SetDefault(previous) })
+}
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestTraceMiddleware(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx context.Context
+	var exporter *tracing.MemoryExporter
+	var parent *tracing.Span
+	var service Service
+	var model *Task
+	var spans []tracing.SpanData
+
+	//-- Test Parameters ----------
+	var missing uint = 9999
+
+	//-- Pre-conditions ----------
+	exporter = tracing.NewMemoryExporter()
+	traceWith(test, exporter)
+
+	ctx, parent = tracing.Start(context.Background(), `test request`, tracing.KindServer)
+
+	service = NewService([]Middleware{NewTraceMiddleware()}, openMemoryStore(test))
+	defer shutdownService(test, service)
+
+	model = newValidTask()
+
+	//-- Action ----------
+	_ = service.Create(ctx, model)
+	_, _ = service.Read(ctx, missing)
+	parent.End()
+
+	//-- Post-conditions ----------
+	spans = exporter.Spans()
+
+	if assert.Len(test, spans, 3) {
+		assert.Equal(test, `task create`, spans[0].Name)
+		assert.Equal(test, parent.Context().SpanID, spans[0].Parent)
+		assert.Equal(test, parent.Context().TraceID, spans[0].Context.TraceID)
+		assert.Equal(test, model.ID, spans[0].Attributes[`task.id`])
+		assert.Equal(test, tracing.StatusUnset, spans[0].Status)
+
+		assert.Equal(test, `task read`, spans[1].Name)
+		assert.Equal(test, parent.Context().SpanID, spans[1].Parent)
+		assert.Equal(test, tracing.StatusError, spans[1].Status)
+		assert.Equal(test, NotFoundClass, spans[1].Attributes[`error.class`])
+	}
+}
+
+func TestTraceStatementNames(test *testing.T) {
+	//-- Shared Variables ----------
+	var exporter *tracing.MemoryExporter
+
+	//-- Test Parameters ----------
+	var cases = map[string]string{
+		queryMap[`insertTask`]:      `INSERT tasks`,
+		queryMap[`updateTask`]:      `UPDATE tasks`,
+		queryMap[`readTask`]:        `SELECT tasks`,
+		queryMap[`purgeTasks`]:      `WITH tasks`,
+		queryMap[`insertHistory`]:   `INSERT task_history`,
+		queryMap[`seekTasksBefore`]: `SELECT tasks`,
+		`COMMIT`:                    `COMMIT`,
+	}
+
+	//-- Pre-conditions ----------
+	exporter = tracing.NewMemoryExporter()
+	traceWith(test, exporter)
+
+	//-- Action ----------
+	for query := range cases {
+		var _, span = startStatement(context.Background(), query)
+		span.End()
+	}
+
+	//-- Post-conditions ----------
+	for _, span := range exporter.Spans() {
+		var query = span.Attributes[`db.statement`].(string)
+
+		assert.Equal(test, cases[query], span.Name, query)
+		assert.Equal(test, tracing.KindClient, span.Kind)
+		assert.Equal(test, `postgresql`, span.Attributes[`db.system`])
+	}
+	assert.Len(test, exporter.Spans(), len(cases))
+}
@@ -0,0 +1,179 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package tracing
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	StdoutTarget = `stdout`
+	StderrTarget = `stderr`
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+// writerExporter writes one OTLP JSON span per line, so the output can be replayed into a collector or read directly
+type writerExporter struct {
+	lock   *sync.Mutex
+	writer io.Writer
+}
+
+// MemoryExporter keeps every span it receives so tests can inspect them
+type MemoryExporter struct {
+	lock  sync.Mutex
+	spans []SpanData
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              Kind            `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+	Resource          otlpResource    `json:"resource"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    Status `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+// NewExporter chooses an exporter from target: empty disables tracing, stdout and stderr write to those streams and any
+// other value is the path of a file which spans are appended to
+func NewExporter(target string) (Exporter, error) {
+	switch target {
+	case ``:
+		return nil, nil
+	case StdoutTarget:
+		return NewWriterExporter(os.Stdout), nil
+	case StderrTarget:
+		return NewWriterExporter(os.Stderr), nil
+	default:
+		if file, err := os.OpenFile(target, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+			return nil, err
+		} else {
+			return NewWriterExporter(file), nil
+		}
+	}
+}
+
+// NewWriterExporter returns an Exporter which writes each span to writer as a line of OTLP JSON
+func NewWriterExporter(writer io.Writer) Exporter {
+	return writerExporter{lock: &sync.Mutex{}, writer: writer}
+}
+
+func NewMemoryExporter() *MemoryExporter {
+	return &MemoryExporter{}
+}
+
+// Export writes span as a single line, a failed write is dropped rather than failing the traced operation
+func (exporter writerExporter) Export(span SpanData) {
+	var document = otlpSpan{
+		TraceID:           span.Context.TraceID.String(),
+		SpanID:            span.Context.SpanID.String(),
+		Name:              span.Name,
+		Kind:              span.Kind,
+		StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+		Attributes:        attributes(span.Attributes),
+		Status:            otlpStatus{Code: span.Status, Message: span.StatusMessage},
+		Resource:          otlpResource{Attributes: attributes(map[string]interface{}{`service.name`: span.Service})},
+	}
+
+	if span.Parent.IsValid() {
+		document.ParentSpanID = span.Parent.String()
+	}
+
+	if line, err := json.Marshal(document); err == nil {
+		exporter.lock.Lock()
+		defer exporter.lock.Unlock()
+
+		_, _ = exporter.writer.Write(append(line, '\n'))
+	}
+}
+
+func (exporter *MemoryExporter) Export(span SpanData) {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+
+	exporter.spans = append(exporter.spans, span)
+}
+
+// Spans returns a copy of every span exported so far, in the order they ended
+func (exporter *MemoryExporter) Spans() []SpanData {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+
+	return append([]SpanData{}, exporter.spans...)
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+// attributes converts values to OTLP attributes sorted by key, a value without an OTLP type is formatted as a string
+func attributes(values map[string]interface{}) []otlpAttribute {
+	var keys = make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var converted = make([]otlpAttribute, 0, len(keys))
+	for _, key := range keys {
+		var value otlpValue
+
+		switch typed := values[key].(type) {
+		case bool:
+			value.BoolValue = &typed
+		case int:
+			var formatted = strconv.FormatInt(int64(typed), 10)
+			value.IntValue = &formatted
+		case int64:
+			var formatted = strconv.FormatInt(typed, 10)
+			value.IntValue = &formatted
+		case uint:
+			var formatted = strconv.FormatUint(uint64(typed), 10)
+			value.IntValue = &formatted
+		case uint64:
+			var formatted = strconv.FormatUint(typed, 10)
+			value.IntValue = &formatted
+		case float64:
+			value.DoubleValue = &typed
+		case string:
+			value.StringValue = &typed
+		default:
+			var formatted = fmt.Sprint(typed)
+			value.StringValue = &formatted
+		}
+
+		converted = append(converted, otlpAttribute{Key: key, Value: value})
+	}
+
+	return converted
+}
@@ -0,0 +1,313 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+// Package tracing records spans which follow the OpenTelemetry data model and propagates them with W3C traceparent
+// headers. Spans are handed to an Exporter when they end, the bundled exporters write OTLP shaped JSON lines to stdout or
+// a file, or keep the spans in memory, so traces can be inspected without running a collector.
+package tracing
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//-- Constants ---------------------------------------------------------------------------------------------------------
+const (
+	// TraceparentHeader is the W3C Trace Context header which carries the caller's span
+	TraceparentHeader = `traceparent`
+
+	KindInternal Kind = `SPAN_KIND_INTERNAL`
+	KindServer   Kind = `SPAN_KIND_SERVER`
+	KindClient   Kind = `SPAN_KIND_CLIENT`
+
+	StatusUnset Status = `STATUS_CODE_UNSET`
+	StatusOK    Status = `STATUS_CODE_OK`
+	StatusError Status = `STATUS_CODE_ERROR`
+
+	traceparentVersion = `00`
+	sampledFlag        = 0x01
+)
+
+var (
+	ErrInvalidTraceparent = errors.New(`the traceparent header is not a valid W3C trace context`)
+
+	defaultTracer atomic.Pointer[Tracer]
+)
+
+//-- Structs -----------------------------------------------------------------------------------------------------------
+type TraceID [16]byte
+type SpanID [8]byte
+
+// Kind is the OpenTelemetry span kind, the values are the OTLP enumeration names
+type Kind string
+
+// Status is the OpenTelemetry span status code, the values are the OTLP enumeration names
+type Status string
+
+// SpanContext identifies a span across process boundaries
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// SpanData is the immutable record of an ended span handed to an Exporter
+type SpanData struct {
+	Service       string
+	Name          string
+	Kind          Kind
+	Context       SpanContext
+	Parent        SpanID
+	Start         time.Time
+	End           time.Time
+	Attributes    map[string]interface{}
+	Status        Status
+	StatusMessage string
+}
+
+// Span is an operation in progress, every method is safe to call on a nil Span so untraced code paths need no checks
+type Span struct {
+	tracer *Tracer
+	lock   sync.Mutex
+	data   SpanData
+	ended  bool
+}
+
+// Exporter receives every sampled span once it has ended, implementations must be safe for concurrent use
+type Exporter interface {
+	Export(span SpanData)
+}
+
+// Tracer starts spans for service and exports them when they end, a Tracer without an Exporter records nothing
+type Tracer struct {
+	service  string
+	exporter Exporter
+}
+
+type spanKey struct{}
+type remoteKey struct{}
+
+//-- Exported Functions ------------------------------------------------------------------------------------------------
+func init() {
+	SetDefault(NewTracer(``, nil))
+}
+
+func NewTracer(service string, exporter Exporter) *Tracer {
+	return &Tracer{service: service, exporter: exporter}
+}
+
+// SetDefault replaces the Tracer used by Start
+func SetDefault(tracer *Tracer) {
+	defaultTracer.Store(tracer)
+}
+
+// Default returns the Tracer used by Start
+func Default() *Tracer {
+	return defaultTracer.Load()
+}
+
+// Start begins a span with the default Tracer, see Tracer.Start
+func Start(ctx context.Context, name string, kind Kind) (context.Context, *Span) {
+	return Default().Start(ctx, name, kind)
+}
+
+// Start begins a span named name as a child of the span carried by ctx, or of the remote parent carried by ctx, or as
+// the root of a new trace. The returned context carries the new span. A span whose remote parent was not sampled is
+// never exported, nor is any span of a Tracer without an Exporter.
+func (tracer *Tracer) Start(ctx context.Context, name string, kind Kind) (context.Context, *Span) {
+	if tracer == nil || tracer.exporter == nil {
+		return ctx, nil
+	}
+
+	var span = &Span{
+		tracer: tracer,
+		data: SpanData{
+			Service:    tracer.service,
+			Name:       name,
+			Kind:       kind,
+			Start:      time.Now(),
+			Attributes: make(map[string]interface{}),
+			Status:     StatusUnset,
+		},
+	}
+
+	if parent := SpanContextFrom(ctx); parent.IsValid() {
+		span.data.Context = SpanContext{TraceID: parent.TraceID, SpanID: newSpanID(), Sampled: parent.Sampled}
+		span.data.Parent = parent.SpanID
+	} else {
+		span.data.Context = SpanContext{TraceID: newTraceID(), SpanID: newSpanID(), Sampled: true}
+	}
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// FromContext returns the span carried by ctx, or nil when there is none
+func FromContext(ctx context.Context) *Span {
+	if span, ok := ctx.Value(spanKey{}).(*Span); ok {
+		return span
+	}
+	return nil
+}
+
+// ContextWithSpan returns a copy of ctx which carries span, so spans started from it become children of span. A nil
+// span leaves ctx without a span of its own.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// WithRemoteParent returns a copy of ctx whose spans continue the trace of parent, usually parsed from a traceparent
+func WithRemoteParent(ctx context.Context, parent SpanContext) context.Context {
+	return context.WithValue(ctx, remoteKey{}, parent)
+}
+
+// SpanContextFrom returns the context of the span carried by ctx, falling back to its remote parent, the result is not
+// valid when ctx carries neither
+func SpanContextFrom(ctx context.Context) SpanContext {
+	if span := FromContext(ctx); span != nil {
+		return span.Context()
+	} else if remote, ok := ctx.Value(remoteKey{}).(SpanContext); ok {
+		return remote
+	}
+	return SpanContext{}
+}
+
+// ParseTraceparent reads a version 00 W3C traceparent header, later versions are read by their first four fields as
+// the specification requires
+func ParseTraceparent(value string) (SpanContext, error) {
+	var parsed SpanContext
+	var fields = strings.Split(strings.TrimSpace(value), `-`)
+
+	if len(fields) < 4 || len(fields[0]) != 2 || fields[0] == `ff` || (fields[0] == traceparentVersion && len(fields) != 4) {
+		return SpanContext{}, ErrInvalidTraceparent
+	} else if err := decodeID(fields[1], parsed.TraceID[:]); err != nil {
+		return SpanContext{}, err
+	} else if err := decodeID(fields[2], parsed.SpanID[:]); err != nil {
+		return SpanContext{}, err
+	} else if flags, err := hex.DecodeString(fields[3]); err != nil || len(flags) != 1 {
+		return SpanContext{}, ErrInvalidTraceparent
+	} else {
+		parsed.Sampled = flags[0]&sampledFlag != 0
+	}
+
+	if !parsed.IsValid() {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+
+	return parsed, nil
+}
+
+// Traceparent formats spanContext as a version 00 W3C traceparent header
+func (spanContext SpanContext) Traceparent() string {
+	var flags byte
+	if spanContext.Sampled {
+		flags = sampledFlag
+	}
+
+	return fmt.Sprintf(`%s-%s-%s-%02x`, traceparentVersion, spanContext.TraceID, spanContext.SpanID, flags)
+}
+
+// IsValid reports whether both IDs are set, an all zero ID is invalid in the W3C specification
+func (spanContext SpanContext) IsValid() bool {
+	return spanContext.TraceID != TraceID{} && spanContext.SpanID != SpanID{}
+}
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid reports whether id is set, a root span has no parent
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+// Context returns the identity of span, which is not valid for a nil Span
+func (span *Span) Context() SpanContext {
+	if span == nil {
+		return SpanContext{}
+	}
+	return span.data.Context
+}
+
+// SetAttribute records value against key, later values replace earlier ones
+func (span *Span) SetAttribute(key string, value interface{}) {
+	if span == nil {
+		return
+	}
+
+	span.lock.Lock()
+	defer span.lock.Unlock()
+
+	span.data.Attributes[key] = value
+}
+
+// RecordError marks span as failed with err, a nil err leaves it unchanged
+func (span *Span) RecordError(err error) {
+	if span == nil || err == nil {
+		return
+	}
+
+	span.lock.Lock()
+	defer span.lock.Unlock()
+
+	span.data.Status = StatusError
+	span.data.StatusMessage = err.Error()
+}
+
+// End stamps the end of span and exports it, only the first call has any effect
+func (span *Span) End() {
+	if span == nil {
+		return
+	}
+
+	span.lock.Lock()
+	if span.ended {
+		span.lock.Unlock()
+		return
+	}
+
+	span.ended = true
+	span.data.End = time.Now()
+
+	var data = span.data
+	data.Attributes = make(map[string]interface{}, len(span.data.Attributes))
+	for key, value := range span.data.Attributes {
+		data.Attributes[key] = value
+	}
+	span.lock.Unlock()
+
+	if data.Context.Sampled {
+		span.tracer.exporter.Export(data)
+	}
+}
+
+//-- Internal Functions ------------------------------------------------------------------------------------------------
+func decodeID(value string, target []byte) error {
+	if len(value) != hex.EncodedLen(len(target)) || strings.ToLower(value) != value {
+		return ErrInvalidTraceparent
+	} else if _, err := hex.Decode(target, []byte(value)); err != nil {
+		return ErrInvalidTraceparent
+	}
+	return nil
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	_, _ = rand.Read(id[:])
+	return id
+}
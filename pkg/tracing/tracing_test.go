@@ -0,0 +1,210 @@
+//-- Package Declaration -----------------------------------------------------------------------------------------------
+package tracing
+
+//-- Imports -----------------------------------------------------------------------------------------------------------
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//-- Local Constants ---------------------------------------------------------------------------------------------------
+
+//-- Decorators --------------------------------------------------------------------------------------------------------
+
+//-- Helpers -----------------------------------------------------------------------------------------------------------
+
+//-- Tests -------------------------------------------------------------------------------------------------------------
+func TestParseTraceparent(test *testing.T) {
+	//-- Shared Variables ----------
+	var parsed SpanContext
+	var parseErr error
+
+	//-- Test Parameters ----------
+	var header = `00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01`
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	parsed, parseErr = ParseTraceparent(header)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, parseErr)
+	assert.Equal(test, `4bf92f3577b34da6a3ce929d0e0e4736`, parsed.TraceID.String())
+	assert.Equal(test, `00f067aa0ba902b7`, parsed.SpanID.String())
+	assert.True(test, parsed.Sampled)
+	assert.Equal(test, header, parsed.Traceparent())
+}
+
+func TestParseTraceparentInvalid(test *testing.T) {
+	//-- Shared Variables ----------
+
+	//-- Test Parameters ----------
+	var headers = []string{
+		``,
+		`00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7`,
+		`00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra`,
+		`ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01`,
+		`00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01`,
+		`00-00000000000000000000000000000000-00f067aa0ba902b7-01`,
+		`00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01`,
+		`00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1`,
+		`00-not a trace-00f067aa0ba902b7-01`,
+	}
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+
+	//-- Post-conditions ----------
+	for _, header := range headers {
+		var _, err = ParseTraceparent(header)
+		assert.Equal(test, ErrInvalidTraceparent, err, header)
+	}
+}
+
+func TestParseTraceparentFutureVersion(test *testing.T) {
+	//-- Shared Variables ----------
+	var parsed SpanContext
+	var parseErr error
+
+	//-- Test Parameters ----------
+	var header = `01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-future`
+
+	//-- Pre-conditions ----------
+
+	//-- Action ----------
+	parsed, parseErr = ParseTraceparent(header)
+
+	//-- Post-conditions ----------
+	assert.Nil(test, parseErr)
+	assert.False(test, parsed.Sampled)
+}
+
+func TestTracerSpans(test *testing.T) {
+	//-- Shared Variables ----------
+	var exporter *MemoryExporter
+	var tracer *Tracer
+	var ctx context.Context
+	var parent, child *Span
+	var spans []SpanData
+
+	//-- Test Parameters ----------
+	var remote, _ = ParseTraceparent(`00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01`)
+	var failure = errors.New(`test failure`)
+
+	//-- Pre-conditions ----------
+	exporter = NewMemoryExporter()
+	tracer = NewTracer(`test-service`, exporter)
+	ctx = WithRemoteParent(context.Background(), remote)
+
+	//-- Action ----------
+	ctx, parent = tracer.Start(ctx, `parent`, KindServer)
+	_, child = tracer.Start(ctx, `child`, KindClient)
+
+	child.SetAttribute(`db.system`, `postgresql`)
+	child.RecordError(failure)
+	child.End()
+	child.End()
+	parent.End()
+
+	//-- Post-conditions ----------
+	spans = exporter.Spans()
+
+	if assert.Len(test, spans, 2) {
+		assert.Equal(test, `child`, spans[0].Name)
+		assert.Equal(test, KindClient, spans[0].Kind)
+		assert.Equal(test, remote.TraceID, spans[0].Context.TraceID)
+		assert.Equal(test, spans[1].Context.SpanID, spans[0].Parent)
+		assert.Equal(test, StatusError, spans[0].Status)
+		assert.Equal(test, failure.Error(), spans[0].StatusMessage)
+		assert.Equal(test, `postgresql`, spans[0].Attributes[`db.system`])
+		assert.False(test, spans[0].End.Before(spans[0].Start))
+
+		assert.Equal(test, `parent`, spans[1].Name)
+		assert.Equal(test, `test-service`, spans[1].Service)
+		assert.Equal(test, remote.TraceID, spans[1].Context.TraceID)
+		assert.Equal(test, remote.SpanID, spans[1].Parent)
+		assert.Equal(test, StatusUnset, spans[1].Status)
+	}
+}
+
+func TestTracerUnsampled(test *testing.T) {
+	//-- Shared Variables ----------
+	var exporter *MemoryExporter
+	var ctx context.Context
+	var span *Span
+
+	//-- Test Parameters ----------
+	var remote, _ = ParseTraceparent(`00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00`)
+
+	//-- Pre-conditions ----------
+	exporter = NewMemoryExporter()
+	ctx = WithRemoteParent(context.Background(), remote)
+
+	//-- Action ----------
+	_, span = NewTracer(`test-service`, exporter).Start(ctx, `unsampled`, KindServer)
+	span.End()
+
+	//-- Post-conditions ----------
+	assert.Equal(test, remote.TraceID, span.Context().TraceID)
+	assert.Empty(test, exporter.Spans())
+}
+
+func TestTracerDisabled(test *testing.T) {
+	//-- Shared Variables ----------
+	var ctx, traced context.Context
+	var span *Span
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	ctx = context.Background()
+
+	//-- Action ----------
+	traced, span = NewTracer(`test-service`, nil).Start(ctx, `disabled`, KindInternal)
+	span.SetAttribute(`ignored`, true)
+	span.RecordError(errors.New(`ignored`))
+	span.End()
+
+	//-- Post-conditions ----------
+	assert.Nil(test, span)
+	assert.Equal(test, ctx, traced)
+	assert.False(test, span.Context().IsValid())
+}
+
+func TestWriterExporter(test *testing.T) {
+	//-- Shared Variables ----------
+	var buffer bytes.Buffer
+	var span *Span
+	var document map[string]interface{}
+
+	//-- Test Parameters ----------
+
+	//-- Pre-conditions ----------
+	_, span = NewTracer(`test-service`, NewWriterExporter(&buffer)).Start(context.Background(), `root`, KindServer)
+
+	//-- Action ----------
+	span.SetAttribute(`http.response.status_code`, 200)
+	span.SetAttribute(`http.route`, `/tasks`)
+	span.End()
+
+	//-- Post-conditions ----------
+	if err := json.Unmarshal(buffer.Bytes(), &document); err != nil {
+		test.Fatalf(`unable to parse span line '%s': %s`, buffer.String(), err)
+	}
+
+	assert.Equal(test, span.Context().TraceID.String(), document[`traceId`])
+	assert.Equal(test, span.Context().SpanID.String(), document[`spanId`])
+	assert.NotContains(test, document, `parentSpanId`)
+	assert.Equal(test, `root`, document[`name`])
+	assert.Equal(test, string(KindServer), document[`kind`])
+	assert.Equal(test, []interface{}{
+		map[string]interface{}{`key`: `http.response.status_code`, `value`: map[string]interface{}{`intValue`: `200`}},
+		map[string]interface{}{`key`: `http.route`, `value`: map[string]interface{}{`stringValue`: `/tasks`}},
+	}, document[`attributes`])
+}